@@ -12,8 +12,13 @@ import (
 )
 
 var (
-	errNoPipelinePriority    = errors.New("No pipelines are defined in the priority list")
-	errInvalidRetryIntervals = errors.New("Retry interval must be positive")
+	errNoPipelinePriority         = errors.New("No pipelines are defined in the priority list")
+	errInvalidRetryIntervals      = errors.New("Retry interval must be positive")
+	errInvalidLatencyThreshold    = errors.New("Latency threshold must not be negative")
+	errInvalidConsecutiveBreaches = errors.New("Latency consecutive breaches must be positive")
+	errInvalidSaturationThreshold = errors.New("Queue saturation threshold must be between 0 and 1")
+	errSaturationWithoutQueue     = errors.New("Queue saturation requires the sending_queue to be enabled with the items sizer")
+	errInvalidHealthyCalls        = errors.New("Mirror healthy calls must be positive")
 )
 
 type Config struct {
@@ -38,6 +43,54 @@ type Config struct {
 	// MaxRetry is the maximum retries per level, once this limit is hit for a level, even if the next pipeline level fails,
 	// it will not try to recover the level that exceeded the maximum retries
 	MaxRetries int `mapstructure:"max_retries"` // **Deprecated**
+
+	// Latency fails over from a priority level which consistently takes too long to consume data,
+	// even if it does not return errors
+	Latency LatencyConfig `mapstructure:"latency"`
+
+	// QueueSaturation fails over from the current priority level when the sending_queue of the connector
+	// fills up, which indicates that the level cannot keep up with the incoming data
+	QueueSaturation QueueSaturationConfig `mapstructure:"queue_saturation"`
+
+	// Mirror sends data to both a recovering priority level and the current level, until the recovering
+	// level has proven to be healthy again
+	Mirror MirrorConfig `mapstructure:"mirror"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// LatencyConfig defines when a priority level is considered too slow
+type LatencyConfig struct {
+	// Threshold is the duration above which a consume call is considered slow. Zero disables
+	// latency based failover
+	Threshold time.Duration `mapstructure:"threshold"`
+
+	// ConsecutiveBreaches is the number of consecutive slow consume calls after which the connector
+	// fails over to the next priority level
+	ConsecutiveBreaches int `mapstructure:"consecutive_breaches"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// QueueSaturationConfig defines when the sending_queue of the connector is considered saturated
+type QueueSaturationConfig struct {
+	// Threshold is the fraction of the sending_queue capacity above which the connector fails over to
+	// the next priority level. The connector fails over at most once until the queue drains below the
+	// threshold. Zero disables queue saturation based failover
+	Threshold float64 `mapstructure:"threshold"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// MirrorConfig defines how a priority level is confirmed healthy before it is used again
+type MirrorConfig struct {
+	// Enabled makes the connector mirror data to a priority level which succeeded a retry, instead of
+	// switching back to it right away
+	Enabled bool `mapstructure:"enabled"`
+
+	// HealthyCalls is the number of consecutive successful calls, including the retry, after which the
+	// recovering level is confirmed healthy and mirroring stops
+	HealthyCalls int `mapstructure:"healthy_calls"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -50,5 +103,21 @@ func (c *Config) Validate() error {
 	if c.RetryInterval <= 0 {
 		return errInvalidRetryIntervals
 	}
+	if c.Latency.Threshold < 0 {
+		return errInvalidLatencyThreshold
+	}
+	if c.Latency.Threshold > 0 && c.Latency.ConsecutiveBreaches <= 0 {
+		return errInvalidConsecutiveBreaches
+	}
+	if c.QueueSaturation.Threshold < 0 || c.QueueSaturation.Threshold > 1 {
+		return errInvalidSaturationThreshold
+	}
+	if c.QueueSaturation.Threshold > 0 &&
+		(!c.QueueSettings.Enabled || c.QueueSettings.Sizer != exporterhelper.RequestSizerTypeItems) {
+		return errSaturationWithoutQueue
+	}
+	if c.Mirror.Enabled && c.Mirror.HealthyCalls <= 0 {
+		return errInvalidHealthyCalls
+	}
 	return nil
 }
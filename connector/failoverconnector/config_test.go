@@ -34,6 +34,8 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 				RetryInterval: 10 * time.Minute,
+				Latency:       LatencyConfig{ConsecutiveBreaches: 3},
+				Mirror:        MirrorConfig{HealthyCalls: 10},
 			},
 		},
 		{
@@ -56,8 +58,42 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 				RetryInterval: 5 * time.Minute,
+				Latency:       LatencyConfig{ConsecutiveBreaches: 3},
+				Mirror:        MirrorConfig{HealthyCalls: 10},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "degraded"),
+			expected: func() *Config {
+				queue := exporterhelper.NewDefaultQueueConfig()
+				queue.Enabled = true
+				queue.Sizer = exporterhelper.RequestSizerTypeItems
+				queue.QueueSize = 10000
+				return &Config{
+					QueueSettings: queue,
+					PipelinePriority: [][]pipeline.ID{
+						{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "first"),
+						},
+						{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "second"),
+						},
+					},
+					RetryInterval: 1 * time.Minute,
+					Latency: LatencyConfig{
+						Threshold:           2 * time.Second,
+						ConsecutiveBreaches: 5,
+					},
+					QueueSaturation: QueueSaturationConfig{
+						Threshold: 0.8,
+					},
+					Mirror: MirrorConfig{
+						Enabled:      true,
+						HealthyCalls: 20,
+					},
+				}
+			}(),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -94,6 +130,26 @@ func TestValidateConfig(t *testing.T) {
 			id:   component.NewIDWithName(metadata.Type, "invalid"),
 			err:  errInvalidRetryIntervals,
 		},
+		{
+			name: "invalid latency consecutive_breaches",
+			id:   component.NewIDWithName(metadata.Type, "invalid_latency"),
+			err:  errInvalidConsecutiveBreaches,
+		},
+		{
+			name: "invalid queue_saturation threshold",
+			id:   component.NewIDWithName(metadata.Type, "invalid_saturation"),
+			err:  errInvalidSaturationThreshold,
+		},
+		{
+			name: "queue_saturation without sending_queue",
+			id:   component.NewIDWithName(metadata.Type, "saturation_without_queue"),
+			err:  errSaturationWithoutQueue,
+		},
+		{
+			name: "invalid mirror healthy_calls",
+			id:   component.NewIDWithName(metadata.Type, "invalid_mirror"),
+			err:  errInvalidHealthyCalls,
+		},
 	}
 
 	for _, tc := range testcases {
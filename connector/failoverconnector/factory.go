@@ -32,6 +32,12 @@ func createDefaultConfig() component.Config {
 		RetryInterval: 10 * time.Minute,
 		RetryGap:      0,
 		MaxRetries:    0,
+		Latency: LatencyConfig{
+			ConsecutiveBreaches: 3,
+		},
+		Mirror: MirrorConfig{
+			HealthyCalls: 10,
+		},
 	}
 }
 
@@ -4,7 +4,9 @@
 package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/pipeline"
 
@@ -23,6 +25,7 @@ type baseFailoverRouter[C any] struct {
 	cfg       *Config
 	pS        *state.PipelineSelector
 	consumers []C
+	health    *levelHealth
 
 	errTryLock  *state.TryLock
 	notifyRetry chan struct{}
@@ -44,6 +47,86 @@ func (f *baseFailoverRouter[C]) getConsumerAtIndex(idx int) C {
 	return f.consumers[idx]
 }
 
+// consume sends the data to the current healthy level, unless a retry is due and one of the unhealthy
+// levels with higher priority accepts it. send delivers the data to a consumer, and mirror delivers a
+// copy of the data to a consumer.
+func (f *baseFailoverRouter[C]) consume(ctx context.Context, send, mirror func(context.Context, C) error) error {
+	select {
+	case <-f.notifyRetry:
+		if f.sampleRetryConsumers(ctx, send) {
+			return nil
+		}
+	default:
+	}
+	return f.consumeByHealthyPipeline(ctx, send, mirror)
+}
+
+// consumeByHealthyPipeline will consume the data by the current healthy level
+func (f *baseFailoverRouter[C]) consumeByHealthyPipeline(ctx context.Context, send, mirror func(context.Context, C) error) error {
+	// the data is mirrored once, even if it is sent to several levels
+	var mirrored bool
+	for {
+		c, idx := f.getCurrentConsumer()
+		if idx >= len(f.cfg.PipelinePriority) {
+			return errNoValidPipeline
+		}
+		lastLevel := idx == len(f.cfg.PipelinePriority)-1
+
+		if !lastLevel && f.health.saturated() {
+			f.reportConsumerError(idx)
+			continue
+		}
+
+		if recovering, ok := f.health.recovering(idx); ok && !mirrored {
+			f.mirrorToRecoveringLevel(ctx, recovering, mirror)
+			mirrored = true
+		}
+
+		start := time.Now()
+		if err := send(ctx, c); err != nil {
+			f.reportConsumerError(idx)
+			continue
+		}
+		// the data was delivered, but the following data is sent to the next level if this one is too slow
+		if f.health.recordLatency(idx, time.Since(start)) && !lastLevel {
+			f.reportConsumerError(idx)
+		}
+		return nil
+	}
+}
+
+// sampleRetryConsumers iterates through all unhealthy consumers to re-establish a healthy connection
+func (f *baseFailoverRouter[C]) sampleRetryConsumers(ctx context.Context, send func(context.Context, C) error) bool {
+	stableIndex := f.pS.CurrentPipeline()
+	for i := 0; i < stableIndex; i++ {
+		start := time.Now()
+		if err := send(ctx, f.getConsumerAtIndex(i)); err != nil {
+			continue
+		}
+		// a slow level accepted the data, but is not healthy yet
+		if f.health.isSlow(time.Since(start)) {
+			return true
+		}
+		if !f.health.mirrorEnabled() || f.health.startRecovery(i) {
+			f.pS.ResetHealthyPipeline(i)
+		}
+		return true
+	}
+	return false
+}
+
+// mirrorToRecoveringLevel sends a copy of the data to the recovering level, and makes it the current
+// level once it is confirmed healthy. Errors of the recovering level are not returned, since the data
+// is also sent to the current level.
+func (f *baseFailoverRouter[C]) mirrorToRecoveringLevel(ctx context.Context, idx int, mirror func(context.Context, C) error) {
+	start := time.Now()
+	err := mirror(ctx, f.getConsumerAtIndex(idx))
+	healthy := err == nil && !f.health.isSlow(time.Since(start))
+	if f.health.recordRecovery(idx, healthy) {
+		f.pS.ResetHealthyPipeline(idx)
+	}
+}
+
 // reportConsumerError ensures only one consumer is reporting an error at a time to avoid multiple failovers
 func (f *baseFailoverRouter[C]) reportConsumerError(idx int) {
 	f.errTryLock.TryExecute(f.pS.HandleError, idx)
@@ -80,6 +163,7 @@ func newBaseFailoverRouter[C any](provider consumerProvider[C], cfg *Config) (*b
 		consumers:   consumers,
 		cfg:         cfg,
 		pS:          selector,
+		health:      newLevelHealth(cfg),
 		errTryLock:  state.NewTryLock(),
 		done:        done,
 		notifyRetry: notifyRetry,
@@ -3,6 +3,7 @@
 
 package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pipeline"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/metadata"
@@ -131,6 +134,183 @@ func TestFailoverRecovery(t *testing.T) {
 	})
 }
 
+func TestFailoverLatency(t *testing.T) {
+	var sinkFirst, sinkSecond consumertest.TracesSink
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/second")
+
+	cfg := &Config{
+		PipelinePriority: [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:    time.Hour,
+		Latency:          LatencyConfig{Threshold: 10 * time.Millisecond, ConsecutiveBreaches: 2},
+	}
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  &sinkFirst,
+		tracesSecond: &sinkSecond,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	failoverConnector := conn.(*tracesFailover)
+	failoverConnector.failover.ModifyConsumerAtIndex(0, &slowTraces{TracesSink: &sinkFirst, delay: 20 * time.Millisecond})
+	defer func() {
+		assert.NoError(t, failoverConnector.Shutdown(t.Context()))
+	}()
+
+	tr := sampleTrace()
+
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	assert.Equal(t, 0, failoverConnector.failover.TestGetCurrentConsumerIndex())
+
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	assert.Equal(t, 1, failoverConnector.failover.TestGetCurrentConsumerIndex())
+	assert.Equal(t, 2, sinkFirst.SpanCount(), "the data of slow calls is delivered")
+
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	assert.Equal(t, 1, sinkSecond.SpanCount())
+}
+
+func TestFailoverQueueSaturation(t *testing.T) {
+	var sinkFirst, sinkSecond consumertest.TracesSink
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/second")
+
+	queue := exporterhelper.NewDefaultQueueConfig()
+	queue.Enabled = true
+	queue.Sizer = exporterhelper.RequestSizerTypeItems
+	queue.QueueSize = 10
+	cfg := &Config{
+		PipelinePriority: [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:    time.Hour,
+		QueueSettings:    queue,
+		QueueSaturation:  QueueSaturationConfig{Threshold: 0.8},
+	}
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  &sinkFirst,
+		tracesSecond: &sinkSecond,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	wrappedConn := conn.(*wrappedTracesConnector)
+	failoverRouter := wrappedConn.GetFailoverRouter()
+	defer func() {
+		assert.NoError(t, wrappedConn.Shutdown(t.Context()))
+	}()
+
+	tr := sampleTrace()
+
+	// simulate a backlog of spans waiting in the sending_queue
+	failoverRouter.health.queue.enqueued(9)
+	require.NoError(t, failoverRouter.Consume(t.Context(), tr))
+	assert.Equal(t, 1, failoverRouter.TestGetCurrentConsumerIndex())
+	assert.Equal(t, 0, sinkFirst.SpanCount())
+	assert.Equal(t, 1, sinkSecond.SpanCount())
+}
+
+func TestFailoverMirror(t *testing.T) {
+	var sinkFirst, sinkSecond consumertest.TracesSink
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/second")
+
+	cfg := &Config{
+		PipelinePriority: [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:    50 * time.Millisecond,
+		Mirror:           MirrorConfig{Enabled: true, HealthyCalls: 3},
+	}
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  &sinkFirst,
+		tracesSecond: &sinkSecond,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	failoverConnector := conn.(*tracesFailover)
+	tRouter := failoverConnector.failover
+	defer func() {
+		assert.NoError(t, failoverConnector.Shutdown(t.Context()))
+	}()
+
+	tr := sampleTrace()
+
+	tRouter.ModifyConsumerAtIndex(0, consumertest.NewErr(errTracesConsumer))
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	require.Equal(t, 1, tRouter.TestGetCurrentConsumerIndex())
+
+	tRouter.ModifyConsumerAtIndex(0, &sinkFirst)
+	sinkSecond.Reset()
+
+	require.Eventually(t, func() bool {
+		return consumeTracesAndCheckStable(tRouter, 0, tr)
+	}, 3*time.Second, 5*time.Millisecond)
+
+	// the retry and the two following calls are sent to the recovering level, and the
+	// two following calls are also sent to the current level
+	assert.Equal(t, 3, sinkFirst.SpanCount())
+	assert.GreaterOrEqual(t, sinkSecond.SpanCount(), 2)
+}
+
+func TestFailoverMirrorOncePerCall(t *testing.T) {
+	var sinkFirst, sinkThird consumertest.TracesSink
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/second")
+	tracesThird := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/third")
+
+	cfg := &Config{
+		PipelinePriority: [][]pipeline.ID{{tracesFirst}, {tracesSecond}, {tracesThird}},
+		RetryInterval:    time.Hour,
+		Mirror:           MirrorConfig{Enabled: true, HealthyCalls: 10},
+	}
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  &sinkFirst,
+		tracesSecond: consumertest.NewErr(errTracesConsumer),
+		tracesThird:  &sinkThird,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	failoverConnector := conn.(*tracesFailover)
+	tRouter := failoverConnector.failover
+	defer func() {
+		assert.NoError(t, failoverConnector.Shutdown(t.Context()))
+	}()
+
+	// the first level is recovering while the second one is the current level
+	tRouter.TestSetStableConsumerIndex(1)
+	require.False(t, tRouter.health.startRecovery(0))
+
+	tr := sampleTrace()
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	require.Equal(t, 2, tRouter.TestGetCurrentConsumerIndex())
+
+	// the second level failed, the data is sent to the third one but mirrored only once
+	assert.Equal(t, tr.SpanCount(), sinkFirst.SpanCount())
+	assert.Equal(t, tr.SpanCount(), sinkThird.SpanCount())
+}
+
+// slowTraces is a sink which takes delay to consume traces
+type slowTraces struct {
+	*consumertest.TracesSink
+	delay time.Duration
+}
+
+func (s *slowTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	time.Sleep(s.delay)
+	return s.TracesSink.ConsumeTraces(ctx, td)
+}
+
 func resetConsumers(router *tracesRouter, consumers ...consumer.Traces) {
 	for i, sink := range consumers {
 		router.ModifyConsumerAtIndex(i, sink)
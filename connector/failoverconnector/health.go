@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// queueTracker keeps track of the number of items waiting in the sending_queue of the connector
+type queueTracker struct {
+	capacity int64
+	pending  atomic.Int64
+}
+
+func newQueueTracker(cfg *Config) *queueTracker {
+	if cfg.QueueSaturation.Threshold == 0 || !cfg.QueueSettings.Enabled || cfg.QueueSettings.QueueSize <= 0 {
+		return nil
+	}
+	return &queueTracker{capacity: cfg.QueueSettings.QueueSize}
+}
+
+// enqueued records items added to the queue
+func (q *queueTracker) enqueued(items int) {
+	if q != nil {
+		q.pending.Add(int64(items))
+	}
+}
+
+// dequeued records items taken out of the queue to be sent to a priority level. The count doesn't
+// go below zero, since the items replayed from a persistent queue on start were never enqueued.
+func (q *queueTracker) dequeued(items int) {
+	if q == nil {
+		return
+	}
+	for {
+		pending := q.pending.Load()
+		if q.pending.CompareAndSwap(pending, max(pending-int64(items), 0)) {
+			return
+		}
+	}
+}
+
+// occupancy returns the fraction of the queue capacity in use
+func (q *queueTracker) occupancy() float64 {
+	return float64(q.pending.Load()) / float64(q.capacity)
+}
+
+// levelHealth tracks the signals, other than consume errors, which make the connector fail over:
+// slow consume calls and saturation of the sending_queue. It also tracks the priority level
+// which is mirrored while it is confirmed healthy.
+type levelHealth struct {
+	latency             time.Duration
+	consecutiveBreaches int
+	saturation          float64
+	healthyCalls        int
+	queue               *queueTracker
+
+	lock sync.Mutex
+	// slowLevel is the level for which slowCalls consecutive slow calls were observed
+	slowLevel int
+	slowCalls int
+	// saturationArmed is false after a saturation failover, until the queue drained below the threshold
+	saturationArmed bool
+	// recoveringLevel is the level being mirrored, or -1 if there is none
+	recoveringLevel  int
+	recoveringHealth int
+}
+
+func newLevelHealth(cfg *Config) *levelHealth {
+	h := &levelHealth{
+		latency:             cfg.Latency.Threshold,
+		consecutiveBreaches: cfg.Latency.ConsecutiveBreaches,
+		saturation:          cfg.QueueSaturation.Threshold,
+		queue:               newQueueTracker(cfg),
+		saturationArmed:     true,
+		recoveringLevel:     -1,
+	}
+	if cfg.Mirror.Enabled {
+		h.healthyCalls = cfg.Mirror.HealthyCalls
+	}
+	return h
+}
+
+// isSlow returns whether a consume call which took the given duration breaches the latency threshold
+func (h *levelHealth) isSlow(d time.Duration) bool {
+	return h.latency > 0 && d > h.latency
+}
+
+// recordLatency records the duration of a consume call to the level, and returns true if the level
+// breached the latency threshold for the configured number of consecutive calls
+func (h *levelHealth) recordLatency(level int, d time.Duration) bool {
+	if h.latency <= 0 {
+		return false
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if level != h.slowLevel {
+		h.slowLevel = level
+		h.slowCalls = 0
+	}
+	if !h.isSlow(d) {
+		h.slowCalls = 0
+		return false
+	}
+	h.slowCalls++
+	if h.slowCalls < h.consecutiveBreaches {
+		return false
+	}
+	h.slowCalls = 0
+	return true
+}
+
+// saturated returns true if the sending_queue is saturated and the connector did not already fail
+// over since the queue was last below the threshold
+func (h *levelHealth) saturated() bool {
+	if h.queue == nil {
+		return false
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.queue.occupancy() < h.saturation {
+		h.saturationArmed = true
+		return false
+	}
+	if !h.saturationArmed {
+		return false
+	}
+	h.saturationArmed = false
+	return true
+}
+
+// mirrorEnabled returns whether levels are mirrored before being used again
+func (h *levelHealth) mirrorEnabled() bool {
+	return h.healthyCalls > 0
+}
+
+// startRecovery starts mirroring the level after a successful retry, and returns true if the
+// level is confirmed healthy
+func (h *levelHealth) startRecovery(level int) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	switch {
+	case h.recoveringLevel >= 0 && h.recoveringLevel < level:
+		// a level with higher priority is already recovering
+		return false
+	case h.recoveringLevel == level:
+		// the level is already recovering, the retry counts as one more healthy call
+		h.recoveringHealth++
+	default:
+		h.recoveringLevel = level
+		h.recoveringHealth = 1
+	}
+	return h.confirmRecovery()
+}
+
+// recovering returns the level which is mirrored while the given level is the current one
+func (h *levelHealth) recovering(current int) (int, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.recoveringLevel < 0 {
+		return 0, false
+	}
+	if h.recoveringLevel >= current {
+		h.recoveringLevel = -1
+		return 0, false
+	}
+	return h.recoveringLevel, true
+}
+
+// recordRecovery records the outcome of a mirrored call to the recovering level, and returns true
+// once the level is confirmed healthy. An unhealthy call stops the recovery.
+func (h *levelHealth) recordRecovery(level int, healthy bool) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if level != h.recoveringLevel {
+		return false
+	}
+	if !healthy {
+		h.recoveringLevel = -1
+		return false
+	}
+	h.recoveringHealth++
+	return h.confirmRecovery()
+}
+
+func (h *levelHealth) confirmRecovery() bool {
+	if h.recoveringHealth < h.healthyCalls {
+		return false
+	}
+	h.recoveringLevel = -1
+	return true
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLevelHealthLatency(t *testing.T) {
+	h := newLevelHealth(&Config{
+		Latency: LatencyConfig{Threshold: 10 * time.Millisecond, ConsecutiveBreaches: 2},
+	})
+
+	assert.False(t, h.recordLatency(0, 20*time.Millisecond))
+	assert.False(t, h.recordLatency(0, 5*time.Millisecond), "a fast call resets the breaches")
+	assert.False(t, h.recordLatency(0, 20*time.Millisecond))
+	assert.True(t, h.recordLatency(0, 20*time.Millisecond))
+
+	assert.False(t, h.recordLatency(0, 20*time.Millisecond), "breaches are reset after a failover")
+	assert.False(t, h.recordLatency(1, 20*time.Millisecond), "breaches are counted per level")
+	assert.True(t, h.recordLatency(1, 20*time.Millisecond))
+}
+
+func TestLevelHealthLatencyDisabled(t *testing.T) {
+	h := newLevelHealth(createDefaultConfig().(*Config))
+	for range 10 {
+		assert.False(t, h.recordLatency(0, time.Hour))
+	}
+	assert.False(t, h.isSlow(time.Hour))
+}
+
+func TestLevelHealthSaturation(t *testing.T) {
+	queue := exporterhelper.NewDefaultQueueConfig()
+	queue.Enabled = true
+	queue.Sizer = exporterhelper.RequestSizerTypeItems
+	queue.QueueSize = 10
+	h := newLevelHealth(&Config{
+		QueueSettings:   queue,
+		QueueSaturation: QueueSaturationConfig{Threshold: 0.5},
+	})
+
+	h.queue.enqueued(4)
+	assert.False(t, h.saturated())
+
+	h.queue.enqueued(2)
+	assert.True(t, h.saturated())
+	assert.False(t, h.saturated(), "the connector fails over once per saturation")
+
+	h.queue.dequeued(3)
+	assert.False(t, h.saturated())
+	h.queue.enqueued(3)
+	assert.True(t, h.saturated(), "the connector fails over again once the queue drained")
+}
+
+func TestLevelHealthSaturationReplayedItems(t *testing.T) {
+	queue := exporterhelper.NewDefaultQueueConfig()
+	queue.Enabled = true
+	queue.Sizer = exporterhelper.RequestSizerTypeItems
+	queue.QueueSize = 10
+	h := newLevelHealth(&Config{
+		QueueSettings:   queue,
+		QueueSaturation: QueueSaturationConfig{Threshold: 0.5},
+	})
+
+	// items replayed from a persistent queue are dequeued without having been enqueued
+	h.queue.dequeued(8)
+	assert.Equal(t, int64(0), h.queue.pending.Load())
+
+	h.queue.enqueued(5)
+	assert.True(t, h.saturated())
+}
+
+func TestLevelHealthSaturationDisabled(t *testing.T) {
+	h := newLevelHealth(createDefaultConfig().(*Config))
+	assert.Nil(t, h.queue)
+	h.queue.enqueued(100)
+	assert.False(t, h.saturated())
+}
+
+func TestLevelHealthRecovery(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Mirror = MirrorConfig{Enabled: true, HealthyCalls: 3}
+	h := newLevelHealth(cfg)
+	assert.True(t, h.mirrorEnabled())
+
+	assert.False(t, h.startRecovery(1))
+	level, ok := h.recovering(2)
+	assert.True(t, ok)
+	assert.Equal(t, 1, level)
+
+	assert.False(t, h.recordRecovery(1, true))
+	assert.True(t, h.recordRecovery(1, true))
+	_, ok = h.recovering(2)
+	assert.False(t, ok, "the level is no longer mirrored once confirmed")
+
+	assert.False(t, h.startRecovery(1))
+	assert.False(t, h.recordRecovery(1, false))
+	_, ok = h.recovering(2)
+	assert.False(t, ok, "an unhealthy call stops the recovery")
+}
+
+func TestLevelHealthRecoverySingleCall(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Mirror = MirrorConfig{Enabled: true, HealthyCalls: 1}
+	h := newLevelHealth(cfg)
+	assert.True(t, h.startRecovery(0))
+	_, ok := h.recovering(1)
+	assert.False(t, ok)
+}
@@ -27,46 +27,17 @@ func newLogsRouter(provider consumerProvider[consumer.Logs], cfg *Config) (*logs
 
 // Consume is the logs-specific consumption method
 func (f *logsRouter) Consume(ctx context.Context, ld plog.Logs) error {
-	select {
-	case <-f.notifyRetry:
-		if !f.sampleRetryConsumers(ctx, ld) {
-			return f.consumeByHealthyPipeline(ctx, ld)
-		}
-		return nil
-	default:
-		return f.consumeByHealthyPipeline(ctx, ld)
-	}
-}
-
-// consumeByHealthyPipeline will consume the logs by the current healthy level
-func (f *logsRouter) consumeByHealthyPipeline(ctx context.Context, ld plog.Logs) error {
-	for {
-		tc, idx := f.getCurrentConsumer()
-		if idx >= len(f.cfg.PipelinePriority) {
-			return errNoValidPipeline
-		}
-
-		if err := tc.ConsumeLogs(ctx, ld); err != nil {
-			f.reportConsumerError(idx)
-			continue
-		}
-
-		return nil
-	}
-}
-
-// sampleRetryConsumers iterates through all unhealthy consumers to re-establish a healthy connection
-func (f *logsRouter) sampleRetryConsumers(ctx context.Context, ld plog.Logs) bool {
-	stableIndex := f.pS.CurrentPipeline()
-	for i := 0; i < stableIndex; i++ {
-		consumer := f.getConsumerAtIndex(i)
-		err := consumer.ConsumeLogs(ctx, ld)
-		if err == nil {
-			f.pS.ResetHealthyPipeline(i)
-			return true
-		}
-	}
-	return false
+	f.health.queue.dequeued(ld.LogRecordCount())
+	return f.consume(ctx,
+		func(ctx context.Context, c consumer.Logs) error {
+			return c.ConsumeLogs(ctx, ld)
+		},
+		func(ctx context.Context, c consumer.Logs) error {
+			mirrored := plog.NewLogs()
+			ld.CopyTo(mirrored)
+			return c.ConsumeLogs(ctx, mirrored)
+		},
+	)
 }
 
 type logsFailover struct {
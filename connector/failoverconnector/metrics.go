@@ -27,46 +27,17 @@ func newMetricsRouter(provider consumerProvider[consumer.Metrics], cfg *Config)
 
 // Consume is the metrics-specific consumption method
 func (f *metricsRouter) Consume(ctx context.Context, md pmetric.Metrics) error {
-	select {
-	case <-f.notifyRetry:
-		if !f.sampleRetryConsumers(ctx, md) {
-			return f.consumeByHealthyPipeline(ctx, md)
-		}
-		return nil
-	default:
-		return f.consumeByHealthyPipeline(ctx, md)
-	}
-}
-
-// consumeByHealthyPipeline will consume the metrics by the current healthy level
-func (f *metricsRouter) consumeByHealthyPipeline(ctx context.Context, md pmetric.Metrics) error {
-	for {
-		tc, idx := f.getCurrentConsumer()
-		if idx >= len(f.cfg.PipelinePriority) {
-			return errNoValidPipeline
-		}
-
-		if err := tc.ConsumeMetrics(ctx, md); err != nil {
-			f.reportConsumerError(idx)
-			continue
-		}
-
-		return nil
-	}
-}
-
-// sampleRetryConsumers iterates through all unhealthy consumers to re-establish a healthy connection
-func (f *metricsRouter) sampleRetryConsumers(ctx context.Context, md pmetric.Metrics) bool {
-	stableIndex := f.pS.CurrentPipeline()
-	for i := 0; i < stableIndex; i++ {
-		consumer := f.getConsumerAtIndex(i)
-		err := consumer.ConsumeMetrics(ctx, md)
-		if err == nil {
-			f.pS.ResetHealthyPipeline(i)
-			return true
-		}
-	}
-	return false
+	f.health.queue.dequeued(md.DataPointCount())
+	return f.consume(ctx,
+		func(ctx context.Context, c consumer.Metrics) error {
+			return c.ConsumeMetrics(ctx, md)
+		},
+		func(ctx context.Context, c consumer.Metrics) error {
+			mirrored := pmetric.NewMetrics()
+			md.CopyTo(mirrored)
+			return c.ConsumeMetrics(ctx, mirrored)
+		},
+	)
 }
 
 type metricsFailover struct {
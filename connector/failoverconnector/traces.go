@@ -28,46 +28,17 @@ func newTracesRouter(provider consumerProvider[consumer.Traces], cfg *Config) (*
 
 // Consume is the traces-specific consumption method
 func (f *tracesRouter) Consume(ctx context.Context, td ptrace.Traces) error {
-	select {
-	case <-f.notifyRetry:
-		if !f.sampleRetryConsumers(ctx, td) {
-			return f.consumeByHealthyPipeline(ctx, td)
-		}
-		return nil
-	default:
-		return f.consumeByHealthyPipeline(ctx, td)
-	}
-}
-
-// consumeByHealthyPipeline will consume the traces by the current healthy level
-func (f *tracesRouter) consumeByHealthyPipeline(ctx context.Context, td ptrace.Traces) error {
-	for {
-		tc, idx := f.getCurrentConsumer()
-		if idx >= len(f.cfg.PipelinePriority) {
-			return errNoValidPipeline
-		}
-
-		if err := tc.ConsumeTraces(ctx, td); err != nil {
-			f.reportConsumerError(idx)
-			continue
-		}
-
-		return nil
-	}
-}
-
-// sampleRetryConsumers iterates through all unhealthy consumers to re-establish a healthy connection
-func (f *tracesRouter) sampleRetryConsumers(ctx context.Context, td ptrace.Traces) bool {
-	stableIndex := f.pS.CurrentPipeline()
-	for i := 0; i < stableIndex; i++ {
-		consumer := f.getConsumerAtIndex(i)
-		err := consumer.ConsumeTraces(ctx, td)
-		if err == nil {
-			f.pS.ResetHealthyPipeline(i)
-			return true
-		}
-	}
-	return false
+	f.health.queue.dequeued(td.SpanCount())
+	return f.consume(ctx,
+		func(ctx context.Context, c consumer.Traces) error {
+			return c.ConsumeTraces(ctx, td)
+		},
+		func(ctx context.Context, c consumer.Traces) error {
+			mirrored := ptrace.NewTraces()
+			td.CopyTo(mirrored)
+			return c.ConsumeTraces(ctx, mirrored)
+		},
+	)
 }
 
 type tracesFailover struct {
@@ -29,7 +29,14 @@ type wrappedLogsConnector struct {
 }
 
 func (w *wrappedTracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
-	return w.consumer.ConsumeTraces(ctx, td)
+	queue := w.failoverCore.failover.health.queue
+	queue.enqueued(td.SpanCount())
+	err := w.consumer.ConsumeTraces(ctx, td)
+	if err != nil {
+		// the data was not added to the sending_queue
+		queue.dequeued(td.SpanCount())
+	}
+	return err
 }
 
 func (w *wrappedTracesConnector) Capabilities() consumer.Capabilities {
@@ -44,7 +51,14 @@ func (w *wrappedTracesConnector) Start(ctx context.Context, host component.Host)
 }
 
 func (w *wrappedMetricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
-	return w.consumer.ConsumeMetrics(ctx, md)
+	queue := w.failoverCore.failover.health.queue
+	queue.enqueued(md.DataPointCount())
+	err := w.consumer.ConsumeMetrics(ctx, md)
+	if err != nil {
+		// the data was not added to the sending_queue
+		queue.dequeued(md.DataPointCount())
+	}
+	return err
 }
 
 func (w *wrappedMetricsConnector) Capabilities() consumer.Capabilities {
@@ -59,7 +73,14 @@ func (w *wrappedMetricsConnector) Start(ctx context.Context, host component.Host
 }
 
 func (w *wrappedLogsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
-	return w.consumer.ConsumeLogs(ctx, ld)
+	queue := w.failoverCore.failover.health.queue
+	queue.enqueued(ld.LogRecordCount())
+	err := w.consumer.ConsumeLogs(ctx, ld)
+	if err != nil {
+		// the data was not added to the sending_queue
+		queue.dequeued(ld.LogRecordCount())
+	}
+	return err
 }
 
 func (w *wrappedLogsConnector) Capabilities() consumer.Capabilities {
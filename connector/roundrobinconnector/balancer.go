@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package roundrobinconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/roundrobinconnector"
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/roundrobinconnector/internal/metadata"
+)
+
+// target is a pipeline the balancer distributes data to.
+type target[T any] struct {
+	pipeline pipeline.ID
+	consumer T
+	weight   int
+
+	// current is the state of the smooth weighted round-robin selection.
+	current int
+	// consecutiveErrors is the number of failed calls since the last successful one.
+	consecutiveErrors int
+	// ejections is the number of times the pipeline was ejected since the last successful call.
+	ejections    int
+	ejectedUntil time.Time
+
+	successAttrs  metric.MeasurementOption
+	failureAttrs  metric.MeasurementOption
+	pipelineAttrs metric.MeasurementOption
+}
+
+// balancer selects the pipeline to send the next request to, using a smooth weighted
+// round-robin over the pipelines which are not currently ejected.
+type balancer[T any] struct {
+	mu        sync.Mutex
+	targets   []*target[T]
+	ejection  EjectionConfig
+	now       func() time.Time
+	logger    *zap.Logger
+	telemetry *metadata.TelemetryBuilder
+}
+
+// newBalancer creates the balancer of the pipelines of the given signal. Only the weights of
+// the pipelines of that signal are checked, the other weights belong to the balancers of the
+// other signals of the connector.
+func newBalancer[T any](signal pipeline.Signal, r router[T], cfg *Config, logger *zap.Logger, telemetry *metadata.TelemetryBuilder) (*balancer[T], error) {
+	// sort the pipelines so that the rotation order does not depend on map iteration
+	pipeIDs := slices.Clone(r.PipelineIDs())
+	slices.SortFunc(pipeIDs, func(a, b pipeline.ID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	b := &balancer[T]{
+		targets:   make([]*target[T], len(pipeIDs)),
+		ejection:  cfg.Ejection,
+		now:       time.Now,
+		logger:    logger,
+		telemetry: telemetry,
+	}
+	for i, pipeID := range pipeIDs {
+		cons, err := r.Consumer(pipeID)
+		if err != nil {
+			return nil, err
+		}
+		weight, ok := cfg.Weights[pipeID]
+		if !ok {
+			weight = 1
+		}
+		pipelineAttr := attribute.String("pipeline", pipeID.String())
+		b.targets[i] = &target[T]{
+			pipeline:      pipeID,
+			consumer:      cons,
+			weight:        weight,
+			successAttrs:  metric.WithAttributeSet(attribute.NewSet(pipelineAttr, attribute.String("outcome", "success"))),
+			failureAttrs:  metric.WithAttributeSet(attribute.NewSet(pipelineAttr, attribute.String("outcome", "failure"))),
+			pipelineAttrs: metric.WithAttributeSet(attribute.NewSet(pipelineAttr)),
+		}
+	}
+
+	for pipeID := range cfg.Weights {
+		if pipeID.Signal() == signal && !b.hasPipeline(pipeID) {
+			return nil, fmt.Errorf("weight configured for pipeline %q which is not connected to the connector", pipeID.String())
+		}
+	}
+	return b, nil
+}
+
+func (b *balancer[T]) hasPipeline(pipeID pipeline.ID) bool {
+	for _, t := range b.targets {
+		if t.pipeline == pipeID {
+			return true
+		}
+	}
+	return false
+}
+
+// next returns the pipeline to send the next request to. Ejected pipelines are skipped
+// until their back-off expires. If all pipelines are ejected, the one due to be
+// re-admitted first is returned, so that data is not dropped by the connector itself.
+func (b *balancer[T]) next() *target[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	var selected, earliest *target[T]
+	total := 0
+	for _, t := range b.targets {
+		if now.Before(t.ejectedUntil) {
+			if earliest == nil || t.ejectedUntil.Before(earliest.ejectedUntil) {
+				earliest = t
+			}
+			continue
+		}
+		t.current += t.weight
+		total += t.weight
+		if selected == nil || t.current > selected.current {
+			selected = t
+		}
+	}
+	if selected == nil {
+		return earliest
+	}
+	selected.current -= total
+	return selected
+}
+
+// report records the outcome of a request sent to the pipeline, and ejects the pipeline
+// once it failed for the configured number of consecutive requests. A re-admitted
+// pipeline is ejected again on its first failure, with twice the previous back-off.
+// Permanent errors are caused by the data rather than by the pipeline, so they are
+// neither counted as failures of the pipeline nor reset its count of failures.
+func (b *balancer[T]) report(ctx context.Context, t *target[T], err error) {
+	if err == nil {
+		b.telemetry.ConnectorRoundrobinRequests.Add(ctx, 1, t.successAttrs)
+	} else {
+		b.telemetry.ConnectorRoundrobinRequests.Add(ctx, 1, t.failureAttrs)
+	}
+	if !b.ejection.Enabled || consumererror.IsPermanent(err) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		t.consecutiveErrors = 0
+		t.ejections = 0
+		return
+	}
+	t.consecutiveErrors++
+	now := b.now()
+	if t.consecutiveErrors < b.ejection.ConsecutiveErrors || now.Before(t.ejectedUntil) {
+		return
+	}
+
+	backoff := b.ejection.InitialBackoff
+	for i := 0; i < t.ejections && backoff < b.ejection.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, b.ejection.MaxBackoff)
+	t.ejections++
+	t.ejectedUntil = now.Add(backoff)
+
+	b.telemetry.ConnectorRoundrobinEjections.Add(ctx, 1, t.pipelineAttrs)
+	b.logger.Warn("Ejecting pipeline from the round-robin rotation",
+		zap.String("pipeline", t.pipeline.String()),
+		zap.Int("consecutive_errors", t.consecutiveErrors),
+		zap.Duration("backoff", backoff),
+		zap.Error(err))
+}
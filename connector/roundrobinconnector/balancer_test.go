@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package roundrobinconnector
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/roundrobinconnector/internal/metadata"
+)
+
+// testRouter routes to consumers which are simply the names of their pipelines.
+type testRouter []pipeline.ID
+
+func (r testRouter) PipelineIDs() []pipeline.ID {
+	return r
+}
+
+func (testRouter) Consumer(pipelineIDs ...pipeline.ID) (string, error) {
+	return pipelineIDs[0].Name(), nil
+}
+
+func newTestBalancer(t *testing.T, cfg *Config, names ...string) *balancer[string] {
+	r := make(testRouter, len(names))
+	for i, name := range names {
+		r[i] = pipeline.NewIDWithName(pipeline.SignalLogs, name)
+	}
+	tb, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	b, err := newBalancer[string](pipeline.SignalLogs, r, cfg, zap.NewNop(), tb)
+	require.NoError(t, err)
+	return b
+}
+
+func nextN(b *balancer[string], n int) []string {
+	picked := make([]string, n)
+	for i := range picked {
+		picked[i] = b.next().consumer
+	}
+	return picked
+}
+
+func TestBalancerRoundRobin(t *testing.T) {
+	b := newTestBalancer(t, createDefaultConfig().(*Config), "c", "a", "b")
+	assert.Equal(t, []string{"a", "b", "c", "a", "b", "c"}, nextN(b, 6))
+}
+
+func TestBalancerWeights(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Weights = map[pipeline.ID]int{
+		pipeline.NewIDWithName(pipeline.SignalLogs, "a"): 3,
+	}
+	b := newTestBalancer(t, cfg, "a", "b")
+	assert.Equal(t, []string{"a", "a", "b", "a", "a", "a", "b", "a"}, nextN(b, 8))
+}
+
+func TestBalancerUnknownWeightedPipeline(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Weights = map[pipeline.ID]int{
+		pipeline.NewIDWithName(pipeline.SignalLogs, "unknown"): 2,
+	}
+	tb, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	_, err = newBalancer[string](pipeline.SignalLogs, testRouter{pipeline.NewIDWithName(pipeline.SignalLogs, "a")}, cfg, zap.NewNop(), tb)
+	assert.EqualError(t, err, `weight configured for pipeline "logs/unknown" which is not connected to the connector`)
+}
+
+func TestBalancerOtherSignalWeights(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Weights = map[pipeline.ID]int{
+		pipeline.NewIDWithName(pipeline.SignalTraces, "a"): 2,
+	}
+	// the weight of traces/a is left to the traces balancer
+	b := newTestBalancer(t, cfg, "a", "b")
+	assert.Equal(t, []string{"a", "b", "a", "b"}, nextN(b, 4))
+}
+
+func TestBalancerEjection(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Ejection = EjectionConfig{
+		Enabled:           true,
+		ConsecutiveErrors: 2,
+		InitialBackoff:    10 * time.Second,
+		MaxBackoff:        25 * time.Second,
+	}
+	b := newTestBalancer(t, cfg, "a", "b", "c")
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+	a := b.targets[0]
+	errFailed := errors.New("failed")
+
+	b.report(t.Context(), a, errFailed)
+	assert.Equal(t, []string{"a", "b", "c"}, nextN(b, 3), "a single failure does not eject the pipeline")
+
+	b.report(t.Context(), a, errFailed)
+	assert.Equal(t, now.Add(10*time.Second), a.ejectedUntil)
+	assert.Equal(t, []string{"b", "c", "b", "c"}, nextN(b, 4))
+
+	// the pipeline is re-admitted once the back-off expired, and ejected again on the next failure
+	now = now.Add(10 * time.Second)
+	assert.Contains(t, nextN(b, 3), "a")
+	b.report(t.Context(), a, errFailed)
+	assert.Equal(t, now.Add(20*time.Second), a.ejectedUntil)
+
+	now = now.Add(20 * time.Second)
+	b.report(t.Context(), a, errFailed)
+	assert.Equal(t, now.Add(25*time.Second), a.ejectedUntil, "the back-off is capped")
+
+	// a successful call resets the back-off
+	now = now.Add(25 * time.Second)
+	b.report(t.Context(), a, nil)
+	b.report(t.Context(), a, errFailed)
+	assert.Equal(t, now, a.ejectedUntil, "a single failure after a success does not eject the pipeline")
+	b.report(t.Context(), a, errFailed)
+	assert.Equal(t, now.Add(10*time.Second), a.ejectedUntil)
+}
+
+func TestBalancerPermanentErrorsDoNotEject(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Ejection.Enabled = true
+	cfg.Ejection.ConsecutiveErrors = 2
+	b := newTestBalancer(t, cfg, "a", "b")
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+	a := b.targets[0]
+	errFailed := errors.New("failed")
+
+	for range 10 {
+		b.report(t.Context(), a, consumererror.NewPermanent(errFailed))
+	}
+	assert.Zero(t, a.consecutiveErrors)
+	assert.Equal(t, []string{"a", "b", "a", "b"}, nextN(b, 4), "malformed data does not eject the pipeline")
+
+	// permanent errors don't reset the count of failures either
+	b.report(t.Context(), a, errFailed)
+	b.report(t.Context(), a, consumererror.NewPermanent(errFailed))
+	b.report(t.Context(), a, errFailed)
+	assert.Equal(t, now.Add(cfg.Ejection.InitialBackoff), a.ejectedUntil)
+}
+
+func TestBalancerAllEjected(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Ejection.Enabled = true
+	cfg.Ejection.ConsecutiveErrors = 1
+	b := newTestBalancer(t, cfg, "a", "b")
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+	errFailed := errors.New("failed")
+
+	b.report(t.Context(), b.targets[1], errFailed)
+	now = now.Add(time.Second)
+	b.report(t.Context(), b.targets[0], errFailed)
+
+	assert.Equal(t, []string{"b", "b"}, nextN(b, 2), "the pipeline re-admitted first is used")
+}
+
+func TestBalancerEjectionDisabled(t *testing.T) {
+	b := newTestBalancer(t, createDefaultConfig().(*Config), "a", "b")
+	for range 10 {
+		b.report(t.Context(), b.targets[0], errors.New("failed"))
+	}
+	assert.True(t, b.targets[0].ejectedUntil.IsZero())
+	assert.Equal(t, []string{"a", "b"}, nextN(b, 2))
+}
@@ -3,5 +3,74 @@
 
 package roundrobinconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/roundrobinconnector"
 
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+var (
+	errInvalidConsecutiveErrors = errors.New("'ejection.consecutive_errors' must be positive")
+	errInvalidInitialBackoff    = errors.New("'ejection.initial_backoff' must be positive")
+	errInvalidMaxBackoff        = errors.New("'ejection.max_backoff' must not be less than 'ejection.initial_backoff'")
+)
+
 // Config for the connector
-type Config struct{}
+type Config struct {
+	// Weights sets the relative share of the data sent to each pipeline. Pipelines
+	// which are not listed have a weight of 1.
+	Weights map[pipeline.ID]int `mapstructure:"weights"`
+
+	// Ejection configures the temporary removal of pipelines which fail to consume data.
+	Ejection EjectionConfig `mapstructure:"ejection"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// EjectionConfig defines when a pipeline is ejected from the rotation and for how long.
+type EjectionConfig struct {
+	// Enabled turns on the ejection of failing pipelines.
+	Enabled bool `mapstructure:"enabled"`
+
+	// ConsecutiveErrors is the number of consecutive failed calls after which a pipeline is ejected.
+	ConsecutiveErrors int `mapstructure:"consecutive_errors"`
+
+	// InitialBackoff is how long a pipeline is ejected the first time. The duration doubles
+	// every time the pipeline fails again right after being re-admitted.
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+
+	// MaxBackoff is the upper bound of the ejection duration.
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks if the connector configuration is valid.
+func (c *Config) Validate() error {
+	for id, weight := range c.Weights {
+		if weight <= 0 {
+			return fmt.Errorf("weight of pipeline %q must be positive", id.String())
+		}
+		switch id.Signal() {
+		case pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs:
+		default:
+			return fmt.Errorf("weight configured for pipeline %q whose signal is not supported by the connector", id.String())
+		}
+	}
+
+	if !c.Ejection.Enabled {
+		return nil
+	}
+	if c.Ejection.ConsecutiveErrors <= 0 {
+		return errInvalidConsecutiveErrors
+	}
+	if c.Ejection.InitialBackoff <= 0 {
+		return errInvalidInitialBackoff
+	}
+	if c.Ejection.MaxBackoff < c.Ejection.InitialBackoff {
+		return errInvalidMaxBackoff
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package roundrobinconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/roundrobinconnector/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testcases := []struct {
+		id       component.ID
+		expected *Config
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig().(*Config),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "weighted"),
+			expected: &Config{
+				Weights: map[pipeline.ID]int{
+					pipeline.NewIDWithName(pipeline.SignalTraces, "large"): 3,
+					pipeline.NewIDWithName(pipeline.SignalTraces, "small"): 1,
+				},
+				Ejection: EjectionConfig{
+					Enabled:           true,
+					ConsecutiveErrors: 3,
+					InitialBackoff:    10 * time.Second,
+					MaxBackoff:        2 * time.Minute,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tc.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tc.expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	testcases := []struct {
+		id  component.ID
+		err string
+	}{
+		{
+			id:  component.NewIDWithName(metadata.Type, "bad_weight"),
+			err: `weight of pipeline "traces/large" must be positive`,
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "bad_backoff"),
+			err: errInvalidMaxBackoff.Error(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tc.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			assert.ErrorContains(t, xconfmap.Validate(cfg), tc.err)
+		})
+	}
+}
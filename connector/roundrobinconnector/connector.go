@@ -5,7 +5,6 @@ package roundrobinconnector // import "github.com/open-telemetry/opentelemetry-c
 
 import (
 	"context"
-	"sync/atomic"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
@@ -14,48 +13,49 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pipeline"
-)
 
-func allConsumers[T any](r router[T]) ([]T, error) {
-	pipeIDs := r.PipelineIDs()
-	consumers := make([]T, len(pipeIDs))
-	for i, pipeID := range pipeIDs {
-		cons, err := r.Consumer(pipeID)
-		if err != nil {
-			return nil, err
-		}
-		consumers[i] = cons
-	}
-	return consumers, nil
-}
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/roundrobinconnector/internal/metadata"
+)
 
 type router[T any] interface {
 	PipelineIDs() []pipeline.ID
 	Consumer(pipelineIDs ...pipeline.ID) (T, error)
 }
 
-func newLogs(nextConsumer consumer.Logs) (connector.Logs, error) {
-	nextConsumers, err := allConsumers[consumer.Logs](nextConsumer.(connector.LogsRouterAndConsumer))
+func newLogs(set connector.Settings, cfg *Config, nextConsumer consumer.Logs) (connector.Logs, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
 	if err != nil {
 		return nil, err
 	}
-	return &roundRobin{nextLogs: nextConsumers}, nil
+	b, err := newBalancer[consumer.Logs](pipeline.SignalLogs, nextConsumer.(connector.LogsRouterAndConsumer), cfg, set.Logger, telemetryBuilder)
+	if err != nil {
+		return nil, err
+	}
+	return &roundRobin{nextLogs: b, telemetryBuilder: telemetryBuilder}, nil
 }
 
-func newMetrics(nextConsumer consumer.Metrics) (connector.Metrics, error) {
-	nextConsumers, err := allConsumers[consumer.Metrics](nextConsumer.(connector.MetricsRouterAndConsumer))
+func newMetrics(set connector.Settings, cfg *Config, nextConsumer consumer.Metrics) (connector.Metrics, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	b, err := newBalancer[consumer.Metrics](pipeline.SignalMetrics, nextConsumer.(connector.MetricsRouterAndConsumer), cfg, set.Logger, telemetryBuilder)
 	if err != nil {
 		return nil, err
 	}
-	return &roundRobin{nextMetrics: nextConsumers}, nil
+	return &roundRobin{nextMetrics: b, telemetryBuilder: telemetryBuilder}, nil
 }
 
-func newTraces(nextConsumer consumer.Traces) (connector.Traces, error) {
-	nextConsumers, err := allConsumers[consumer.Traces](nextConsumer.(connector.TracesRouterAndConsumer))
+func newTraces(set connector.Settings, cfg *Config, nextConsumer consumer.Traces) (connector.Traces, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	b, err := newBalancer[consumer.Traces](pipeline.SignalTraces, nextConsumer.(connector.TracesRouterAndConsumer), cfg, set.Logger, telemetryBuilder)
 	if err != nil {
 		return nil, err
 	}
-	return &roundRobin{nextTraces: nextConsumers}, nil
+	return &roundRobin{nextTraces: b, telemetryBuilder: telemetryBuilder}, nil
 }
 
 // roundRobin is used to pass signals directly from one pipeline to one of the configured once in a round-robin mode.
@@ -63,25 +63,39 @@ func newTraces(nextConsumer consumer.Traces) (connector.Traces, error) {
 // handle concurrent requests very well.
 type roundRobin struct {
 	component.StartFunc
-	component.ShutdownFunc
-	nextConsumer atomic.Uint64
-	nextMetrics  []consumer.Metrics
-	nextLogs     []consumer.Logs
-	nextTraces   []consumer.Traces
+
+	nextMetrics      *balancer[consumer.Metrics]
+	nextLogs         *balancer[consumer.Logs]
+	nextTraces       *balancer[consumer.Traces]
+	telemetryBuilder *metadata.TelemetryBuilder
 }
 
 func (*roundRobin) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
+func (rr *roundRobin) Shutdown(context.Context) error {
+	rr.telemetryBuilder.Shutdown()
+	return nil
+}
+
 func (rr *roundRobin) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
-	return rr.nextLogs[rr.nextConsumer.Add(1)%uint64(len(rr.nextLogs))].ConsumeLogs(ctx, ld)
+	t := rr.nextLogs.next()
+	err := t.consumer.ConsumeLogs(ctx, ld)
+	rr.nextLogs.report(ctx, t, err)
+	return err
 }
 
 func (rr *roundRobin) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
-	return rr.nextMetrics[rr.nextConsumer.Add(1)%uint64(len(rr.nextMetrics))].ConsumeMetrics(ctx, md)
+	t := rr.nextMetrics.next()
+	err := t.consumer.ConsumeMetrics(ctx, md)
+	rr.nextMetrics.report(ctx, t, err)
+	return err
 }
 
 func (rr *roundRobin) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
-	return rr.nextTraces[rr.nextConsumer.Add(1)%uint64(len(rr.nextTraces))].ConsumeTraces(ctx, td)
+	t := rr.nextTraces.next()
+	err := t.consumer.ConsumeTraces(ctx, td)
+	rr.nextTraces.report(ctx, t, err)
+	return err
 }
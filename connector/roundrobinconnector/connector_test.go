@@ -4,21 +4,29 @@
 package roundrobinconnector
 
 import (
+	"context"
+	"errors"
 	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/roundrobinconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/roundrobinconnector/internal/metadatatest"
 )
 
 func newPipelineMap[T any](signal pipeline.Signal, consumers ...T) map[pipeline.ID]T {
@@ -32,7 +40,7 @@ func newPipelineMap[T any](signal pipeline.Signal, consumers ...T) map[pipeline.
 func TestLogsRoundRobin(t *testing.T) {
 	f := NewFactory()
 	cfg := f.CreateDefaultConfig()
-	assert.Equal(t, &Config{}, cfg)
+	assert.Equal(t, createDefaultConfig(), cfg)
 
 	ctx := t.Context()
 	set := connectortest.NewNopSettings(metadata.Type)
@@ -69,7 +77,7 @@ func TestLogsRoundRobin(t *testing.T) {
 func TestMetricsRoundRobin(t *testing.T) {
 	f := NewFactory()
 	cfg := f.CreateDefaultConfig()
-	assert.Equal(t, &Config{}, cfg)
+	assert.Equal(t, createDefaultConfig(), cfg)
 
 	ctx := t.Context()
 	set := connectortest.NewNopSettings(metadata.Type)
@@ -106,7 +114,7 @@ func TestMetricsRoundRobin(t *testing.T) {
 func TestTracesRoundRobin(t *testing.T) {
 	f := NewFactory()
 	cfg := f.CreateDefaultConfig()
-	assert.Equal(t, &Config{}, cfg)
+	assert.Equal(t, createDefaultConfig(), cfg)
 
 	ctx := t.Context()
 	set := connectortest.NewNopSettings(metadata.Type)
@@ -139,3 +147,116 @@ func TestTracesRoundRobin(t *testing.T) {
 
 	assert.NoError(t, traces.Shutdown(ctx))
 }
+
+func TestLogsEjectionTelemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) }) //nolint:usetesting
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Ejection.Enabled = true
+	cfg.Ejection.ConsecutiveErrors = 2
+
+	ctx := t.Context()
+	sink := new(consumertest.LogsSink)
+	errFailed := errors.New("failed")
+	logs, err := f.CreateLogsToLogs(ctx, metadatatest.NewSettings(tel), cfg,
+		connector.NewLogsRouter(newPipelineMap[consumer.Logs](pipeline.SignalLogs, consumertest.NewErr(errFailed), sink)))
+	require.NoError(t, err)
+	require.NoError(t, logs.Start(ctx, componenttest.NewNopHost()))
+
+	// logs/0 fails twice and is ejected, after which all logs are sent to logs/1
+	for range 6 {
+		err = logs.ConsumeLogs(ctx, plog.NewLogs())
+		if err != nil {
+			assert.ErrorIs(t, err, errFailed)
+		}
+	}
+	assert.Len(t, sink.AllLogs(), 4)
+
+	metadatatest.AssertEqualConnectorRoundrobinRequests(t, tel, []metricdata.DataPoint[int64]{
+		{
+			Attributes: attribute.NewSet(attribute.String("pipeline", "logs/0"), attribute.String("outcome", "failure")),
+			Value:      2,
+		},
+		{
+			Attributes: attribute.NewSet(attribute.String("pipeline", "logs/1"), attribute.String("outcome", "success")),
+			Value:      4,
+		},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualConnectorRoundrobinEjections(t, tel, []metricdata.DataPoint[int64]{
+		{
+			Attributes: attribute.NewSet(attribute.String("pipeline", "logs/0")),
+			Value:      1,
+		},
+	}, metricdatatest.IgnoreTimestamp())
+	assert.NoError(t, logs.Shutdown(ctx))
+}
+
+func TestLogsPermanentErrorTelemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) }) //nolint:usetesting
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Ejection.Enabled = true
+	cfg.Ejection.ConsecutiveErrors = 2
+
+	ctx := t.Context()
+	sink := new(consumertest.LogsSink)
+	errMalformed := consumererror.NewPermanent(errors.New("malformed"))
+	logs, err := f.CreateLogsToLogs(ctx, metadatatest.NewSettings(tel), cfg,
+		connector.NewLogsRouter(newPipelineMap[consumer.Logs](pipeline.SignalLogs, consumertest.NewErr(errMalformed), sink)))
+	require.NoError(t, err)
+	require.NoError(t, logs.Start(ctx, componenttest.NewNopHost()))
+
+	// logs/0 rejects the data as malformed, which is not a reason to eject it
+	for range 6 {
+		err = logs.ConsumeLogs(ctx, plog.NewLogs())
+		if err != nil {
+			assert.True(t, consumererror.IsPermanent(err))
+		}
+	}
+	assert.Len(t, sink.AllLogs(), 3)
+
+	metadatatest.AssertEqualConnectorRoundrobinRequests(t, tel, []metricdata.DataPoint[int64]{
+		{
+			Attributes: attribute.NewSet(attribute.String("pipeline", "logs/0"), attribute.String("outcome", "failure")),
+			Value:      3,
+		},
+		{
+			Attributes: attribute.NewSet(attribute.String("pipeline", "logs/1"), attribute.String("outcome", "success")),
+			Value:      3,
+		},
+	}, metricdatatest.IgnoreTimestamp())
+	assert.NoError(t, logs.Shutdown(ctx))
+}
+
+func TestTracesAndLogsWeights(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Weights = map[pipeline.ID]int{
+		pipeline.NewIDWithName(pipeline.SignalTraces, "0"): 3,
+	}
+	require.NoError(t, cfg.Validate())
+
+	ctx := t.Context()
+	set := connectortest.NewNopSettings(metadata.Type)
+
+	// the weights of the traces pipelines don't apply to the logs pipelines
+	logs, err := f.CreateLogsToLogs(ctx, set, cfg,
+		connector.NewLogsRouter(newPipelineMap[consumer.Logs](pipeline.SignalLogs, consumertest.NewNop(), consumertest.NewNop())))
+	require.NoError(t, err)
+	assert.NotNil(t, logs)
+
+	traces, err := f.CreateTracesToTraces(ctx, set, cfg,
+		connector.NewTracesRouter(newPipelineMap[consumer.Traces](pipeline.SignalTraces, consumertest.NewNop(), consumertest.NewNop())))
+	require.NoError(t, err)
+	assert.NotNil(t, traces)
+
+	// a weight of a traces pipeline which is not connected is still reported by the traces connector
+	cfg.Weights[pipeline.NewIDWithName(pipeline.SignalTraces, "unknown")] = 2
+	_, err = f.CreateTracesToTraces(ctx, set, cfg,
+		connector.NewTracesRouter(newPipelineMap[consumer.Traces](pipeline.SignalTraces, consumertest.NewNop())))
+	assert.EqualError(t, err, `weight configured for pipeline "traces/unknown" which is not connected to the connector`)
+}
@@ -7,6 +7,7 @@ package roundrobinconnector // import "github.com/open-telemetry/opentelemetry-c
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
@@ -28,35 +29,41 @@ func NewFactory() connector.Factory {
 
 // createDefaultConfig creates the default configuration.
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		Ejection: EjectionConfig{
+			ConsecutiveErrors: 5,
+			InitialBackoff:    5 * time.Second,
+			MaxBackoff:        5 * time.Minute,
+		},
+	}
 }
 
 // createLogsToLogs creates a log receiver based on provided config.
 func createLogsToLogs(
 	_ context.Context,
-	_ connector.Settings,
-	_ component.Config,
+	set connector.Settings,
+	cfg component.Config,
 	nextConsumer consumer.Logs,
 ) (connector.Logs, error) {
-	return newLogs(nextConsumer)
+	return newLogs(set, cfg.(*Config), nextConsumer)
 }
 
 // createMetricsToMetrics creates a metrics receiver based on provided config.
 func createMetricsToMetrics(
 	_ context.Context,
-	_ connector.Settings,
-	_ component.Config,
+	set connector.Settings,
+	cfg component.Config,
 	nextConsumer consumer.Metrics,
 ) (connector.Metrics, error) {
-	return newMetrics(nextConsumer)
+	return newMetrics(set, cfg.(*Config), nextConsumer)
 }
 
 // createTracesToTraces creates a trace receiver based on provided config.
 func createTracesToTraces(
 	_ context.Context,
-	_ connector.Settings,
-	_ component.Config,
+	set connector.Settings,
+	cfg component.Config,
 	nextConsumer consumer.Traces,
 ) (connector.Traces, error) {
-	return newTraces(nextConsumer)
+	return newTraces(set, cfg.(*Config), nextConsumer)
 }
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/ptraceutil"
+)
+
+// traceAffinity remembers the consumer selected for the first spans of each trace, so
+// that the remaining spans of the trace are sent to the same consumer. Decisions are
+// kept in a bounded LRU and expire when no span of the trace was seen for the TTL.
+type traceAffinity struct {
+	mu        sync.Mutex
+	maxTraces int
+	ttl       time.Duration
+	now       func() time.Time
+	// lru is ordered from the most to the least recently seen trace.
+	lru     *list.List
+	entries map[pcommon.TraceID]*list.Element
+}
+
+type affinityEntry struct {
+	traceID  pcommon.TraceID
+	consumer consumer.Traces
+	expires  time.Time
+}
+
+func newTraceAffinity(cfg *TraceAffinityConfig) *traceAffinity {
+	if cfg == nil {
+		return nil
+	}
+	return &traceAffinity{
+		maxTraces: cfg.MaxTraces,
+		ttl:       cfg.TTL,
+		now:       time.Now,
+		lru:       list.New(),
+		entries:   make(map[pcommon.TraceID]*list.Element),
+	}
+}
+
+// group adds the spans of td to the group of cons, except for the spans of traces that
+// were previously routed to another consumer, which are added to the group of that
+// consumer instead. Traces seen for the first time are pinned to cons.
+func (a *traceAffinity) group(
+	groups map[consumer.Traces]ptrace.Traces,
+	cons consumer.Traces,
+	td ptrace.Traces,
+) {
+	a.movePinned(groups, cons, td)
+	groupAllTraces(groups, cons, td)
+}
+
+// movePinned moves the spans of traces that were previously routed to another consumer
+// than cons out of td, to the group of that consumer. Traces seen for the first time
+// are pinned to cons, and their spans are left in td. If cons is nil, the spans of all
+// pinned traces are moved and the traces seen for the first time are not pinned.
+func (a *traceAffinity) movePinned(
+	groups map[consumer.Traces]ptrace.Traces,
+	cons consumer.Traces,
+	td ptrace.Traces,
+) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	a.evictExpired(now)
+
+	var pinned map[pcommon.TraceID]consumer.Traces
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				traceID := spans.At(k).TraceID()
+				if traceID.IsEmpty() {
+					continue
+				}
+				prev, ok := a.touch(traceID, now)
+				if !ok {
+					if cons != nil {
+						a.add(traceID, cons, now)
+					}
+					continue
+				}
+				if prev != cons {
+					if pinned == nil {
+						pinned = make(map[pcommon.TraceID]consumer.Traces)
+					}
+					pinned[traceID] = prev
+				}
+			}
+		}
+	}
+
+	// move the spans of pinned traces, one consumer at a time
+	for len(pinned) > 0 {
+		var target consumer.Traces
+		for _, target = range pinned {
+			break
+		}
+		moved := ptrace.NewTraces()
+		ptraceutil.MoveSpansWithContextIf(td, moved,
+			func(_ ptrace.ResourceSpans, _ ptrace.ScopeSpans, s ptrace.Span) bool {
+				prev, ok := pinned[s.TraceID()]
+				return ok && prev == target
+			},
+		)
+		for traceID, prev := range pinned {
+			if prev == target {
+				delete(pinned, traceID)
+			}
+		}
+		groupAllTraces(groups, target, moved)
+	}
+}
+
+// touch returns the consumer pinned for the trace and extends the lifetime of the decision.
+func (a *traceAffinity) touch(traceID pcommon.TraceID, now time.Time) (consumer.Traces, bool) {
+	elem, ok := a.entries[traceID]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*affinityEntry)
+	entry.expires = now.Add(a.ttl)
+	a.lru.MoveToFront(elem)
+	return entry.consumer, true
+}
+
+func (a *traceAffinity) add(traceID pcommon.TraceID, cons consumer.Traces, now time.Time) {
+	a.entries[traceID] = a.lru.PushFront(&affinityEntry{
+		traceID:  traceID,
+		consumer: cons,
+		expires:  now.Add(a.ttl),
+	})
+	for a.lru.Len() > a.maxTraces {
+		a.remove(a.lru.Back())
+	}
+}
+
+// evictExpired forgets the decisions for traces which were not seen for the TTL.
+func (a *traceAffinity) evictExpired(now time.Time) {
+	for elem := a.lru.Back(); elem != nil; elem = a.lru.Back() {
+		if now.Before(elem.Value.(*affinityEntry).expires) {
+			return
+		}
+		a.remove(elem)
+	}
+}
+
+func (a *traceAffinity) remove(elem *list.Element) {
+	a.lru.Remove(elem)
+	delete(a.entries, elem.Value.(*affinityEntry).traceID)
+}
+
+func (a *traceAffinity) size() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lru.Len()
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func affinityTestTraces(traceIDs ...pcommon.TraceID) ptrace.Traces {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, traceID := range traceIDs {
+		spans.AppendEmpty().SetTraceID(traceID)
+	}
+	return td
+}
+
+func TestTraceAffinityDisabled(t *testing.T) {
+	assert.Nil(t, newTraceAffinity(nil))
+}
+
+func TestTraceAffinityGroup(t *testing.T) {
+	a := newTraceAffinity(&TraceAffinityConfig{MaxTraces: 10, TTL: time.Minute})
+	sink0, sink1 := new(consumertest.TracesSink), new(consumertest.TracesSink)
+	traceA, traceB := pcommon.TraceID([16]byte{1}), pcommon.TraceID([16]byte{2})
+
+	groups := make(map[consumer.Traces]ptrace.Traces)
+	a.group(groups, sink0, affinityTestTraces(traceA))
+	require.Len(t, groups, 1)
+	assert.Equal(t, 1, groups[sink0].SpanCount())
+
+	groups = make(map[consumer.Traces]ptrace.Traces)
+	a.group(groups, sink1, affinityTestTraces(traceA, traceB, pcommon.NewTraceIDEmpty()))
+	require.Len(t, groups, 2)
+	assert.Equal(t, affinityTestTraces(traceA), groups[sink0])
+	assert.Equal(t, affinityTestTraces(traceB, pcommon.NewTraceIDEmpty()), groups[sink1])
+	assert.Equal(t, 2, a.size(), "spans without a trace ID are not pinned")
+}
+
+func TestTraceAffinityMaxTraces(t *testing.T) {
+	a := newTraceAffinity(&TraceAffinityConfig{MaxTraces: 2, TTL: time.Minute})
+	sink0, sink1 := new(consumertest.TracesSink), new(consumertest.TracesSink)
+	traceA, traceB, traceC := pcommon.TraceID([16]byte{1}), pcommon.TraceID([16]byte{2}), pcommon.TraceID([16]byte{3})
+
+	a.group(make(map[consumer.Traces]ptrace.Traces), sink0, affinityTestTraces(traceA, traceB))
+	// seeing trace A again makes trace B the least recently seen one
+	a.group(make(map[consumer.Traces]ptrace.Traces), sink0, affinityTestTraces(traceA))
+	a.group(make(map[consumer.Traces]ptrace.Traces), sink0, affinityTestTraces(traceC))
+	assert.Equal(t, 2, a.size())
+
+	groups := make(map[consumer.Traces]ptrace.Traces)
+	a.group(groups, sink1, affinityTestTraces(traceA, traceB))
+	assert.Equal(t, affinityTestTraces(traceA), groups[sink0])
+	assert.Equal(t, affinityTestTraces(traceB), groups[sink1])
+}
+
+func TestTraceAffinityTTL(t *testing.T) {
+	a := newTraceAffinity(&TraceAffinityConfig{MaxTraces: 10, TTL: time.Minute})
+	now := time.Unix(0, 0)
+	a.now = func() time.Time { return now }
+	sink0, sink1 := new(consumertest.TracesSink), new(consumertest.TracesSink)
+	traceA, traceB := pcommon.TraceID([16]byte{1}), pcommon.TraceID([16]byte{2})
+
+	a.group(make(map[consumer.Traces]ptrace.Traces), sink0, affinityTestTraces(traceA, traceB))
+
+	// seeing a span of trace A extends the lifetime of its decision
+	now = now.Add(45 * time.Second)
+	a.group(make(map[consumer.Traces]ptrace.Traces), sink0, affinityTestTraces(traceA))
+
+	now = now.Add(30 * time.Second)
+	groups := make(map[consumer.Traces]ptrace.Traces)
+	a.group(groups, sink1, affinityTestTraces(traceA, traceB))
+	assert.Equal(t, affinityTestTraces(traceA), groups[sink0])
+	assert.Equal(t, affinityTestTraces(traceB), groups[sink1])
+	assert.Equal(t, 2, a.size())
+}
@@ -6,6 +6,7 @@ package routingconnector // import "github.com/open-telemetry/opentelemetry-coll
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/pipeline"
 
@@ -18,6 +19,8 @@ var (
 	errNoPipelines            = errors.New("invalid route: no pipelines defined")
 	errUnexpectedConsumer     = errors.New("expected consumer to be a connector router")
 	errNoTableItems           = errors.New("invalid routing table: the routing table is empty")
+	errInvalidMaxTraces       = errors.New("invalid trace affinity: 'max_traces' must be positive")
+	errInvalidTTL             = errors.New("invalid trace affinity: 'ttl' must be positive")
 )
 
 // Config defines configuration for the Routing processor.
@@ -39,6 +42,11 @@ type Config struct {
 	// Table contains the routing table for this processor.
 	// Required.
 	Table []RoutingTableItem `mapstructure:"table"`
+	// TraceAffinity, when set, routes all spans of a trace to the pipelines selected for the
+	// first spans of that trace, even if later spans would match a different route.
+	// Only applies to traces.
+	// Optional.
+	TraceAffinity *TraceAffinityConfig `mapstructure:"trace_affinity"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -76,9 +84,31 @@ func (c *Config) Validate() error {
 			return errors.New("invalid context: " + item.Context)
 		}
 	}
+
+	if c.TraceAffinity != nil {
+		if c.TraceAffinity.MaxTraces <= 0 {
+			return errInvalidMaxTraces
+		}
+		if c.TraceAffinity.TTL <= 0 {
+			return errInvalidTTL
+		}
+	}
 	return nil
 }
 
+// TraceAffinityConfig defines how long the routing decisions made for traces are remembered.
+type TraceAffinityConfig struct {
+	// MaxTraces is the maximum number of traces for which a routing decision is remembered.
+	// When the limit is reached, the decision for the least recently seen trace is forgotten.
+	// Required.
+	MaxTraces int `mapstructure:"max_traces"`
+	// TTL is how long a routing decision is remembered after the last span of the trace was seen.
+	// Required.
+	TTL time.Duration `mapstructure:"ttl"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
 // RoutingTableItem specifies how data should be routed to the different pipelines
 type RoutingTableItem struct {
 	// One of "request", "resource", "log", "span", "metric", "datapoint".
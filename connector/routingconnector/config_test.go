@@ -6,6 +6,7 @@ package routingconnector // import "github.com/open-telemetry/opentelemetry-coll
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,6 +50,29 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			configPath: filepath.Join("testdata", "config", "traces_affinity.yaml"),
+			id:         component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				DefaultPipelines: []pipeline.ID{
+					pipeline.NewIDWithName(pipeline.SignalTraces, "otlp-all"),
+				},
+				ErrorMode: ottl.PropagateError,
+				Table: []RoutingTableItem{
+					{
+						Context:   "span",
+						Condition: `attributes["http.response.status_code"] >= 500`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "errors"),
+						},
+					},
+				},
+				TraceAffinity: &TraceAffinityConfig{
+					MaxTraces: 50000,
+					TTL:       2 * time.Minute,
+				},
+			},
+		},
 		{
 			configPath: filepath.Join("testdata", "config", "metrics.yaml"),
 			id:         component.NewIDWithName(metadata.Type, ""),
@@ -375,6 +399,36 @@ func TestValidateConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "trace affinity without max traces",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+				TraceAffinity: &TraceAffinityConfig{TTL: time.Minute},
+			},
+			error: "invalid trace affinity: 'max_traces' must be positive",
+		},
+		{
+			name: "trace affinity without ttl",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+				TraceAffinity: &TraceAffinityConfig{MaxTraces: 1000},
+			},
+			error: "invalid trace affinity: 'ttl' must be positive",
+		},
 	}
 
 	for _, tt := range tests {
@@ -23,9 +23,10 @@ type tracesConnector struct {
 	component.StartFunc
 	component.ShutdownFunc
 
-	logger *zap.Logger
-	config *Config
-	router *router[consumer.Traces]
+	logger   *zap.Logger
+	config   *Config
+	router   *router[consumer.Traces]
+	affinity *traceAffinity
 }
 
 func newTracesConnector(
@@ -49,9 +50,10 @@ func newTracesConnector(
 	}
 
 	return &tracesConnector{
-		logger: set.Logger,
-		config: cfg,
-		router: r,
+		logger:   set.Logger,
+		config:   cfg,
+		router:   r,
+		affinity: newTraceAffinity(cfg.TraceAffinity),
 	}, nil
 }
 
@@ -68,7 +70,7 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) e
 		switch route.statementContext {
 		case "request":
 			if route.requestCondition.matchRequest(ctx) {
-				groupAllTraces(groups, route.consumer, td)
+				c.groupAllTraces(groups, route.consumer, td)
 				td = ptrace.NewTraces() // all traces have been routed
 			}
 		case "", "resource":
@@ -94,18 +96,36 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) e
 			if c.config.ErrorMode == ottl.PropagateError {
 				return errs
 			}
+			if c.affinity != nil {
+				// the spans of pinned traces only go to their consumer
+				c.affinity.movePinned(groups, nil, matchedSpans)
+			}
 			groupAllTraces(groups, c.router.defaultConsumer, matchedSpans)
 		}
-		groupAllTraces(groups, route.consumer, matchedSpans)
+		c.groupAllTraces(groups, route.consumer, matchedSpans)
 	}
 	// anything left wasn't matched by any route. Send to default consumer
-	groupAllTraces(groups, c.router.defaultConsumer, td)
+	c.groupAllTraces(groups, c.router.defaultConsumer, td)
 	for consumer, group := range groups {
 		errs = errors.Join(errs, consumer.ConsumeTraces(ctx, group))
 	}
 	return errs
 }
 
+// groupAllTraces groups the traces with the consumer, unless trace affinity
+// is enabled and a trace was already routed to another consumer.
+func (c *tracesConnector) groupAllTraces(
+	groups map[consumer.Traces]ptrace.Traces,
+	cons consumer.Traces,
+	traces ptrace.Traces,
+) {
+	if c.affinity != nil {
+		c.affinity.group(groups, cons, traces)
+		return
+	}
+	groupAllTraces(groups, cons, traces)
+}
+
 func groupAllTraces(
 	groups map[consumer.Traces]ptrace.Traces,
 	cons consumer.Traces,
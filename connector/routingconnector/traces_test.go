@@ -6,6 +6,7 @@ package routingconnector // import "github.com/open-telemetry/opentelemetry-coll
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -14,12 +15,14 @@ import (
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pipeline"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/ptraceutiltest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 func TestTracesRegisterConsumersForValidRoute(t *testing.T) {
@@ -368,6 +371,150 @@ func TestTracesResourceAttributeDroppedByOTTL(t *testing.T) {
 	)
 }
 
+func TestTracesTraceAffinity(t *testing.T) {
+	tracesDefault := pipeline.NewIDWithName(pipeline.SignalTraces, "default")
+	traces0 := pipeline.NewIDWithName(pipeline.SignalTraces, "0")
+
+	cfg := testConfig(
+		withRoute("span", `attributes["http.status_code"] >= 500`, traces0),
+		withDefault(tracesDefault),
+	)
+	cfg.TraceAffinity = &TraceAffinityConfig{MaxTraces: 10, TTL: time.Minute}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0 consumertest.TracesSink
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesDefault: &defaultSink,
+		traces0:       &sink0,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(
+		t.Context(),
+		connectortest.NewNopSettings(metadata.Type),
+		cfg,
+		router.(consumer.Traces),
+	)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, conn.Shutdown(t.Context()))
+	}()
+
+	traceA := pcommon.TraceID([16]byte{1})
+	traceB := pcommon.TraceID([16]byte{2})
+	newSpan := func(tr ptrace.Traces, traceID pcommon.TraceID, statusCode int64) {
+		span := tr.ResourceSpans().At(0).ScopeSpans().At(0).Spans().AppendEmpty()
+		span.SetTraceID(traceID)
+		span.Attributes().PutInt("http.status_code", statusCode)
+	}
+	newTraces := func() ptrace.Traces {
+		tr := ptrace.NewTraces()
+		tr.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+		return tr
+	}
+
+	// the first span of trace A matches the route, the first span of trace B does not
+	tr := newTraces()
+	newSpan(tr, traceA, 500)
+	newSpan(tr, traceB, 200)
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	require.Len(t, sink0.AllTraces(), 1)
+	assert.Equal(t, 1, sink0.AllTraces()[0].SpanCount())
+	require.Len(t, defaultSink.AllTraces(), 1)
+	assert.Equal(t, 1, defaultSink.AllTraces()[0].SpanCount())
+
+	// later spans follow the decision made for their trace, regardless of the route they match
+	sink0.Reset()
+	defaultSink.Reset()
+	tr = newTraces()
+	newSpan(tr, traceA, 200)
+	newSpan(tr, traceB, 503)
+	newSpan(tr, traceA, 200)
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+
+	require.Len(t, sink0.AllTraces(), 1)
+	spans := sink0.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 2, spans.Len())
+	assert.Equal(t, traceA, spans.At(0).TraceID())
+	assert.Equal(t, traceA, spans.At(1).TraceID())
+
+	require.Len(t, defaultSink.AllTraces(), 1)
+	spans = defaultSink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 1, spans.Len())
+	assert.Equal(t, traceB, spans.At(0).TraceID())
+}
+
+func TestTracesTraceAffinityIgnoredError(t *testing.T) {
+	tracesDefault := pipeline.NewIDWithName(pipeline.SignalTraces, "default")
+	traces0 := pipeline.NewIDWithName(pipeline.SignalTraces, "0")
+
+	cfg := testConfig(
+		withRoute("span", `attributes["http.status_code"] >= 500 or ParseJSON(attributes["payload"]) != nil`, traces0),
+		withDefault(tracesDefault),
+	)
+	cfg.ErrorMode = ottl.IgnoreError
+	cfg.TraceAffinity = &TraceAffinityConfig{MaxTraces: 10, TTL: time.Minute}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0 consumertest.TracesSink
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesDefault: &defaultSink,
+		traces0:       &sink0,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(
+		t.Context(),
+		connectortest.NewNopSettings(metadata.Type),
+		cfg,
+		router.(consumer.Traces),
+	)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, conn.Shutdown(t.Context()))
+	}()
+
+	traceA := pcommon.TraceID([16]byte{1})
+	traceB := pcommon.TraceID([16]byte{2})
+	newTraces := func() ptrace.Traces {
+		tr := ptrace.NewTraces()
+		tr.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+		return tr
+	}
+	newSpan := func(tr ptrace.Traces, traceID pcommon.TraceID, statusCode int64) ptrace.Span {
+		span := tr.ResourceSpans().At(0).ScopeSpans().At(0).Spans().AppendEmpty()
+		span.SetTraceID(traceID)
+		span.Attributes().PutInt("http.status_code", statusCode)
+		return span
+	}
+
+	// trace A is pinned to the route
+	tr := newTraces()
+	newSpan(tr, traceA, 500)
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	require.Len(t, sink0.AllTraces(), 1)
+	assert.Empty(t, defaultSink.AllTraces())
+
+	// the condition fails on the span of trace B, the span of trace A still only goes to the route
+	sink0.Reset()
+	tr = newTraces()
+	newSpan(tr, traceA, 500)
+	newSpan(tr, traceB, 200).Attributes().PutStr("payload", "{")
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+
+	require.Len(t, sink0.AllTraces(), 1)
+	spans := sink0.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 1, spans.Len())
+	assert.Equal(t, traceA, spans.At(0).TraceID())
+
+	require.Len(t, defaultSink.AllTraces(), 1)
+	spans = defaultSink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 1, spans.Len())
+	assert.Equal(t, traceB, spans.At(0).TraceID())
+}
+
 func TestTraceConnectorCapabilities(t *testing.T) {
 	tracesDefault := pipeline.NewIDWithName(pipeline.SignalTraces, "default")
 	tracesOther := pipeline.NewIDWithName(pipeline.SignalTraces, "0")
@@ -6,6 +6,8 @@ package servicegraphconnector // import "github.com/open-telemetry/opentelemetry
 import (
 	"errors"
 	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
 // Config defines the configuration options for servicegraphprocessor.
@@ -68,6 +70,9 @@ type StoreConfig struct {
 	MaxItems int `mapstructure:"max_items"`
 	// TTL is the time to live for items in the store.
 	TTL time.Duration `mapstructure:"ttl"`
+	// StorageID is the ID of the storage extension persisting the edges which are not complete yet,
+	// so that they survive restarts and can be paired by replicas sharing the storage.
+	StorageID *component.ID `mapstructure:"storage"`
 
 	// prevent unkeyed literal initialization
 	_ struct{}
@@ -16,10 +16,13 @@ import (
 	"github.com/lightstep/go-expohisto/structure"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 	"go.uber.org/zap"
 
@@ -52,6 +55,10 @@ var (
 	defaultDatabaseNameAttributes = []string{string(semconv.DBNameKey)}
 
 	defaultMetricsFlushInterval = 60 * time.Second // 1 DPM
+
+	missingClientAttrs = metric.WithAttributeSet(attribute.NewSet(attribute.String("reason", "missing_client")))
+	missingServerAttrs = metric.WithAttributeSet(attribute.NewSet(attribute.String("reason", "missing_server")))
+	storeFullAttrs     = metric.WithAttributeSet(attribute.NewSet(attribute.String("reason", "store_full")))
 )
 
 type metricSeries struct {
@@ -62,6 +69,8 @@ type metricSeries struct {
 var _ processor.Traces = (*serviceGraphConnector)(nil)
 
 type serviceGraphConnector struct {
+	// id is used as the owner of the storage client, if the store is persisted
+	id              component.ID
 	config          *Config
 	logger          *zap.Logger
 	metricsConsumer consumer.Metrics
@@ -159,9 +168,19 @@ func newConnector(set component.TelemetrySettings, config component.Config, next
 	}, nil
 }
 
-func (p *serviceGraphConnector) Start(context.Context, component.Host) error {
+func (p *serviceGraphConnector) Start(ctx context.Context, host component.Host) error {
 	p.store = store.NewStore(p.config.Store.TTL, p.config.Store.MaxItems, p.onComplete, p.onExpire)
 
+	if p.config.Store.StorageID != nil {
+		client, err := getStorageClient(ctx, host, *p.config.Store.StorageID, p.id)
+		if err != nil {
+			return err
+		}
+		if err := p.store.EnableStorage(ctx, client, p.logger); err != nil {
+			return errors.Join(err, client.Close(ctx))
+		}
+	}
+
 	go p.metricFlushLoop(*p.config.MetricsFlushInterval)
 
 	go p.cacheLoop(p.config.CacheLoop)
@@ -207,12 +226,27 @@ func (p *serviceGraphConnector) flushMetrics(ctx context.Context) error {
 	return p.metricsConsumer.ConsumeMetrics(ctx, md)
 }
 
-func (p *serviceGraphConnector) Shutdown(context.Context) error {
+func (p *serviceGraphConnector) Shutdown(ctx context.Context) error {
 	p.logger.Info("Shutting down servicegraphconnector")
 	close(p.shutdownCh)
+	if p.store != nil {
+		return p.store.Close(ctx)
+	}
 	return nil
 }
 
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID) (storage.Client, error) {
+	ext, found := host.GetExtensions()[storageID]
+	if !found {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+	}
+	return storageExt.GetClient(ctx, component.KindConnector, componentID, "")
+}
+
 func (*serviceGraphConnector) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
@@ -237,8 +271,14 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 	var (
 		isNew             bool
 		totalDroppedSpans int
+		persisted         map[store.Key]*store.Edge
 	)
 
+	// the persisted halves of the edges are read from the storage once per batch of spans
+	if p.config.Store.StorageID != nil {
+		persisted = p.store.LoadPersisted(ctx, edgeKeys(td))
+	}
+
 	rss := td.ResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
 		rSpans := rss.At(i)
@@ -267,7 +307,7 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 				case ptrace.SpanKindClient:
 					traceID := span.TraceID()
 					key := store.NewKey(traceID, span.SpanID())
-					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
+					isNew, err = p.store.UpsertEdge(key, persisted, func(e *store.Edge) {
 						e.TraceID = traceID
 						e.ConnectionType = connectionType
 						e.ClientService = serviceName
@@ -294,7 +334,7 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 				case ptrace.SpanKindServer:
 					traceID := span.TraceID()
 					key := store.NewKey(traceID, span.ParentSpanID())
-					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
+					isNew, err = p.store.UpsertEdge(key, persisted, func(e *store.Edge) {
 						e.TraceID = traceID
 						e.ConnectionType = connectionType
 						e.ServerService = serviceName
@@ -310,6 +350,7 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 				if errors.Is(err, store.ErrTooManyItems) {
 					totalDroppedSpans++
 					p.telemetryBuilder.ConnectorServicegraphDroppedSpans.Add(ctx, 1)
+					p.telemetryBuilder.ConnectorServicegraphUnpairedEdges.Add(ctx, 1, storeFullAttrs)
 					continue
 				}
 
@@ -324,9 +365,40 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 			}
 		}
 	}
+
+	// the changes of the edges are written to the storage once per batch of spans
+	if err := p.store.Flush(ctx); err != nil {
+		p.logger.Warn("failed to persist the edges", zap.Error(err))
+	}
 	return nil
 }
 
+// edgeKeys returns the keys of the edges the spans are part of.
+func edgeKeys(td ptrace.Traces) []store.Key {
+	var keys []store.Key
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rSpans := rss.At(i)
+		if _, ok := findServiceName(rSpans.Resource().Attributes()); !ok {
+			continue
+		}
+		scopeSpans := rSpans.ScopeSpans()
+		for j := 0; j < scopeSpans.Len(); j++ {
+			spans := scopeSpans.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				switch span.Kind() {
+				case ptrace.SpanKindProducer, ptrace.SpanKindClient:
+					keys = append(keys, store.NewKey(span.TraceID(), span.SpanID()))
+				case ptrace.SpanKindConsumer, ptrace.SpanKindServer:
+					keys = append(keys, store.NewKey(span.TraceID(), span.ParentSpanID()))
+				}
+			}
+		}
+	}
+	return keys
+}
+
 func (p *serviceGraphConnector) upsertDimensions(kind string, m map[string]string, resourceAttr, spanAttr pcommon.Map) {
 	for _, dim := range p.config.Dimensions {
 		if v, ok := pdatautil.GetAttributeValue(dim, resourceAttr, spanAttr); ok {
@@ -351,6 +423,7 @@ func (p *serviceGraphConnector) onComplete(e *store.Edge) {
 		zap.String("server_service", e.ServerService),
 		zap.String("connection_type", string(e.ConnectionType)),
 		zap.Stringer("trace_id", e.TraceID),
+		zap.Stringer("edge_key", e.Key),
 	)
 	p.aggregateMetricsForEdge(e)
 }
@@ -362,9 +435,15 @@ func (p *serviceGraphConnector) onExpire(e *store.Edge) {
 		zap.String("server_service", e.ServerService),
 		zap.String("connection_type", string(e.ConnectionType)),
 		zap.Stringer("trace_id", e.TraceID),
+		zap.Stringer("edge_key", e.Key),
 	)
 
 	p.telemetryBuilder.ConnectorServicegraphExpiredEdges.Add(context.Background(), 1)
+	if e.ClientService == "" {
+		p.telemetryBuilder.ConnectorServicegraphUnpairedEdges.Add(context.Background(), 1, missingClientAttrs)
+	} else {
+		p.telemetryBuilder.ConnectorServicegraphUnpairedEdges.Add(context.Background(), 1, missingServerAttrs)
+	}
 
 	if virtualNodeFeatureGate.IsEnabled() && len(p.config.VirtualNodePeerAttributes) > 0 {
 		e.ConnectionType = store.VirtualNode
@@ -713,7 +792,7 @@ func (p *serviceGraphConnector) storeExpirationLoop(d time.Duration) {
 	for {
 		select {
 		case <-t.C:
-			p.store.Expire()
+			p.store.Expire(context.Background())
 		case <-p.shutdownCh:
 			return
 		}
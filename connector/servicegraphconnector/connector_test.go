@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/metadatatest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
 )
@@ -190,7 +192,7 @@ func TestConnectorConsume(t *testing.T) {
 				// On Windows timing doesn't tick forward quickly for the store data to expire, force a wait before expiring.
 				time.Sleep(time.Second)
 			}
-			conn.store.Expire()
+			conn.store.Expire(t.Context())
 			md, err := conn.buildMetrics()
 			assert.NoError(t, err)
 			tc.verifyMetrics(t, md)
@@ -634,6 +636,88 @@ func TestValidateOwnTelemetry(t *testing.T) {
 	require.NoError(t, tel.Shutdown(t.Context()))
 }
 
+func TestUnpairedEdgesTelemetry(t *testing.T) {
+	cfg := &Config{
+		Store: StoreConfig{
+			MaxItems: 1,
+			TTL:      -time.Second,
+		},
+	}
+
+	tel := componenttest.NewTelemetry()
+	p, err := newConnector(tel.NewTelemetrySettings(), cfg, newMockMetricsExporter())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+
+	// the server span can't be stored while the client span is pending
+	require.NoError(t, p.ConsumeTraces(t.Context(), incompleteClientTraces()))
+	require.NoError(t, p.ConsumeTraces(t.Context(), incompleteServerTraces(true)))
+	p.store.Expire(t.Context())
+
+	require.NoError(t, p.ConsumeTraces(t.Context(), incompleteServerTraces(true)))
+	p.store.Expire(t.Context())
+
+	require.NoError(t, p.Shutdown(t.Context()))
+	metadatatest.AssertEqualConnectorServicegraphUnpairedEdges(t, tel, []metricdata.DataPoint[int64]{
+		{Value: 1, Attributes: attribute.NewSet(attribute.String("reason", "missing_server"))},
+		{Value: 1, Attributes: attribute.NewSet(attribute.String("reason", "missing_client"))},
+		{Value: 1, Attributes: attribute.NewSet(attribute.String("reason", "store_full"))},
+	}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, tel.Shutdown(t.Context()))
+}
+
+func TestConnectorStorage(t *testing.T) {
+	dir := t.TempDir()
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Store.TTL = time.Hour
+	cfg.Store.StorageID = ptr(storagetest.NewStorageID("test"))
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir)
+
+	// the client span is received before a restart
+	exporter := newMockMetricsExporter()
+	conn, err := factory.CreateTracesToMetrics(t.Context(), connectortest.NewNopSettings(metadata.Type), cfg, exporter)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(t.Context(), host))
+	require.NoError(t, conn.ConsumeTraces(t.Context(), incompleteClientTraces()))
+	require.NoError(t, conn.Shutdown(t.Context()))
+
+	// the edge is completed by the server span received after the restart
+	conn, err = factory.CreateTracesToMetrics(t.Context(), connectortest.NewNopSettings(metadata.Type), cfg, exporter)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(t.Context(), host))
+	assert.Equal(t, 1, conn.(*serviceGraphConnector).store.Len())
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(string(semconv.ServiceNameKey), "some-server-service")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pcommon.SpanID([8]byte{9, 9, 9, 9, 9, 9, 9, 9}))
+	span.SetParentSpanID(pcommon.SpanID([8]byte{1, 2, 3, 4, 4, 3, 2, 1}))
+	span.SetKind(ptrace.SpanKindServer)
+	require.NoError(t, conn.ConsumeTraces(t.Context(), td))
+	assert.Equal(t, 0, conn.(*serviceGraphConnector).store.Len())
+	require.NoError(t, conn.Shutdown(t.Context()))
+}
+
+func TestConnectorStorageInvalidExtension(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+
+	cfg.Store.StorageID = ptr(storagetest.NewStorageID("missing"))
+	conn, err := factory.CreateTracesToMetrics(t.Context(), connectortest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.ErrorContains(t, conn.Start(t.Context(), storagetest.NewStorageHost()), "not found")
+	require.NoError(t, conn.Shutdown(t.Context()))
+
+	cfg.Store.StorageID = ptr(storagetest.NewNonStorageID("test"))
+	conn, err = factory.CreateTracesToMetrics(t.Context(), connectortest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.ErrorContains(t, conn.Start(t.Context(), storagetest.NewStorageHost().WithNonStorageExtension("test")), "is not a storage extension")
+	require.NoError(t, conn.Shutdown(t.Context()))
+}
+
 func TestExtraDimensionsLabels(t *testing.T) {
 	t.Skip("https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/39210")
 	extraDimensions := []string{"db.system", "messaging.system"}
@@ -656,7 +740,7 @@ func TestExtraDimensionsLabels(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, conn.ConsumeTraces(t.Context(), td))
 
-	conn.store.Expire()
+	conn.store.Expire(t.Context())
 
 	metrics := conn.metricsConsumer.(*mockMetricsExporter).GetMetrics()
 	require.Len(t, metrics, 1)
@@ -696,7 +780,7 @@ func TestVirtualNodeServerLabels(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, conn.ConsumeTraces(t.Context(), td))
 
-	conn.store.Expire()
+	conn.store.Expire(t.Context())
 	// Wait for metrics to be generated with timeout
 	var metrics []pmetric.Metrics
 	assert.Eventually(t, func() bool {
@@ -745,7 +829,7 @@ func TestVirtualNodeClientLabels(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, conn.ConsumeTraces(t.Context(), td))
 
-	conn.store.Expire()
+	conn.store.Expire(t.Context())
 	// Wait for metrics to be generated with timeout
 	var metrics []pmetric.Metrics
 	assert.Eventually(t, func() bool {
@@ -791,7 +875,7 @@ func TestExponentialHistogram(t *testing.T) {
 		// On Windows timing doesn't tick forward quickly for the store data to expire, force a wait before expiring.
 		time.Sleep(time.Second)
 	}
-	conn.store.Expire()
+	conn.store.Expire(t.Context())
 	md, err := conn.buildMetrics()
 	assert.NoError(t, err)
 
@@ -71,5 +71,10 @@ func createDefaultConfig() component.Config {
 }
 
 func createTracesToMetricsConnector(_ context.Context, params connector.Settings, cfg component.Config, nextConsumer consumer.Metrics) (connector.Traces, error) {
-	return newConnector(params.TelemetrySettings, cfg, nextConsumer)
+	c, err := newConnector(params.TelemetrySettings, cfg, nextConsumer)
+	if err != nil {
+		return nil, err
+	}
+	c.id = params.ID
+	return c, nil
 }
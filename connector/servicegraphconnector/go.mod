@@ -4,6 +4,7 @@ go 1.24.0
 
 require (
 	github.com/lightstep/go-expohisto v1.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.135.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/pdatautil v0.135.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden v0.135.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest v0.135.0
@@ -16,6 +17,7 @@ require (
 	go.opentelemetry.io/collector/consumer v1.41.1-0.20250911155607-37a3ace6274c
 	go.opentelemetry.io/collector/consumer/consumertest v0.135.1-0.20250911155607-37a3ace6274c
 	go.opentelemetry.io/collector/exporter v0.135.1-0.20250911155607-37a3ace6274c
+	go.opentelemetry.io/collector/extension/xextension v0.135.1-0.20250911155607-37a3ace6274c
 	go.opentelemetry.io/collector/featuregate v1.41.1-0.20250911155607-37a3ace6274c
 	go.opentelemetry.io/collector/otelcol/otelcoltest v0.135.1-0.20250911155607-37a3ace6274c
 	go.opentelemetry.io/collector/pdata v1.41.1-0.20250911155607-37a3ace6274c
@@ -145,3 +147,5 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/pdatautil => ../../internal/pdatautil
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil => ../../pkg/pdatautil
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage => ../../extension/storage
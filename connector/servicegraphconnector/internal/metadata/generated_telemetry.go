@@ -23,12 +23,13 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // TelemetryBuilder provides an interface for components to report telemetry
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
-	meter                             metric.Meter
-	mu                                sync.Mutex
-	registrations                     []metric.Registration
-	ConnectorServicegraphDroppedSpans metric.Int64Counter
-	ConnectorServicegraphExpiredEdges metric.Int64Counter
-	ConnectorServicegraphTotalEdges   metric.Int64Counter
+	meter                              metric.Meter
+	mu                                 sync.Mutex
+	registrations                      []metric.Registration
+	ConnectorServicegraphDroppedSpans  metric.Int64Counter
+	ConnectorServicegraphExpiredEdges  metric.Int64Counter
+	ConnectorServicegraphTotalEdges    metric.Int64Counter
+	ConnectorServicegraphUnpairedEdges metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -78,5 +79,11 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ConnectorServicegraphUnpairedEdges, err = builder.meter.Int64Counter(
+		"otelcol_connector_servicegraph_unpaired_edges",
+		metric.WithDescription("Number of edges that could not be paired, by reason"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
@@ -23,6 +23,7 @@ func TestSetupTelemetry(t *testing.T) {
 	tb.ConnectorServicegraphDroppedSpans.Add(context.Background(), 1)
 	tb.ConnectorServicegraphExpiredEdges.Add(context.Background(), 1)
 	tb.ConnectorServicegraphTotalEdges.Add(context.Background(), 1)
+	tb.ConnectorServicegraphUnpairedEdges.Add(context.Background(), 1)
 	AssertEqualConnectorServicegraphDroppedSpans(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
@@ -32,6 +33,9 @@ func TestSetupTelemetry(t *testing.T) {
 	AssertEqualConnectorServicegraphTotalEdges(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualConnectorServicegraphUnpairedEdges(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 
 	require.NoError(t, testTel.Shutdown(context.Background()))
 }
@@ -0,0 +1,370 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package store // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/store"
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+const (
+	// edgeStorageKeyPrefix is followed by the edge key.
+	edgeStorageKeyPrefix = "edge/"
+	// pendingStorageKey holds the keys of the edges held by the instance when it was shut down.
+	pendingStorageKey = "pending"
+)
+
+// persistedEdge is the representation of an Edge in the storage.
+type persistedEdge struct {
+	TraceID          string            `json:"trace_id"`
+	SpanID           string            `json:"span_id"`
+	ConnectionType   ConnectionType    `json:"connection_type,omitempty"`
+	ServerService    string            `json:"server_service,omitempty"`
+	ClientService    string            `json:"client_service,omitempty"`
+	ServerLatencySec float64           `json:"server_latency_sec,omitempty"`
+	ClientLatencySec float64           `json:"client_latency_sec,omitempty"`
+	Failed           bool              `json:"failed,omitempty"`
+	Dimensions       map[string]string `json:"dimensions,omitempty"`
+	Peer             map[string]string `json:"peer,omitempty"`
+	VirtualNodeLabel VirtualNodeLabel  `json:"virtual_node_label,omitempty"`
+	// Expiration is the expiration of the edge, in Unix nanoseconds
+	Expiration int64 `json:"expiration"`
+}
+
+// EnableStorage persists the edges which are not complete yet with the given storage client,
+// and restores the edges held when the store was last closed. The edges which expired in the
+// meantime are expired on the next call to Expire. The store doesn't use the client if an
+// error is returned.
+func (s *Store) EnableStorage(ctx context.Context, client storage.Client, logger *zap.Logger) error {
+	s.flushMtx.Lock()
+	defer s.flushMtx.Unlock()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	pending, err := client.Get(ctx, pendingStorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read the pending edges from the storage: %w", err)
+	}
+	var keys []string
+	if pending != nil {
+		if err := json.Unmarshal(pending, &keys); err != nil {
+			return fmt.Errorf("failed to decode the pending edges: %w", err)
+		}
+	}
+
+	ops := make([]*storage.Operation, 0, len(keys))
+	for _, key := range keys {
+		ops = append(ops, storage.GetOperation(edgeStorageKeyPrefix+key))
+	}
+	if err := client.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("failed to read the pending edges from the storage: %w", err)
+	}
+
+	edges := make([]*Edge, 0, len(ops))
+	for _, op := range ops {
+		if op.Value == nil {
+			// the edge was completed by another instance sharing the storage
+			continue
+		}
+		edge, err := decodeEdge(op.Value)
+		if err != nil {
+			return err
+		}
+		edges = append(edges, edge)
+	}
+	// the list is ordered by expiration, so that the expired edges are at its head
+	slices.SortFunc(edges, func(a, b *Edge) int {
+		return a.expiration.Compare(b.expiration)
+	})
+	// the edges which are not restored are deleted, nothing else would ever delete them
+	deletes := []*storage.Operation{storage.DeleteOperation(pendingStorageKey)}
+	var restored int
+	for _, edge := range edges {
+		if _, ok := s.m[edge.Key]; ok || s.l.Len() >= s.maxItems {
+			deletes = append(deletes, storage.DeleteOperation(edgeStorageKeyPrefix+edge.Key.String()))
+			continue
+		}
+		s.m[edge.Key] = s.l.PushBack(edge)
+		restored++
+	}
+	if err := client.Batch(ctx, deletes...); err != nil {
+		return fmt.Errorf("failed to delete the restored edges from the storage: %w", err)
+	}
+
+	s.client = client
+	s.logger = logger
+	s.pending = make(map[Key]*Edge)
+	logger.Debug("restored edges from the storage", zap.Int("edges", restored), zap.Int("deleted", len(deletes)-1))
+	return nil
+}
+
+// Flush writes the changes of the edges since the last flush to the storage, if any, in a
+// single batch.
+func (s *Store) Flush(ctx context.Context) error {
+	s.flushMtx.Lock()
+	defer s.flushMtx.Unlock()
+
+	s.mtx.Lock()
+	client := s.client
+	var ops []*storage.Operation
+	if client != nil {
+		ops = s.takePendingOperations()
+	}
+	s.mtx.Unlock()
+
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := client.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("failed to persist the edges: %w", err)
+	}
+	return nil
+}
+
+// Close writes the changes of the edges to the storage, and records the keys of the edges held
+// by the store, so that they are restored on the next call to EnableStorage. It then closes the
+// storage client.
+func (s *Store) Close(ctx context.Context) error {
+	s.flushMtx.Lock()
+	defer s.flushMtx.Unlock()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.client == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, s.l.Len())
+	for e := s.l.Front(); e != nil; e = e.Next() {
+		keys = append(keys, e.Value.(*Edge).Key.String())
+	}
+	pending, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	ops := append(s.takePendingOperations(), storage.SetOperation(pendingStorageKey, pending))
+	// the store keeps working in memory, should it be used after being closed
+	client := s.client
+	s.client = nil
+	if err := client.Batch(ctx, ops...); err != nil {
+		return errors.Join(fmt.Errorf("failed to persist the pending edges: %w", err), client.Close(ctx))
+	}
+	return client.Close(ctx)
+}
+
+// setPending records the change of the edge, to write it to the storage on the next flush.
+// A nil edge deletes it from the storage.
+//
+// Must be called holding lock.
+func (s *Store) setPending(key Key, e *Edge) {
+	if s.client == nil {
+		return
+	}
+	s.pending[key] = e
+}
+
+// takePendingOperations returns the operations writing the changes of the edges since the last
+// flush, and forgets them.
+//
+// Must be called holding lock.
+func (s *Store) takePendingOperations() []*storage.Operation {
+	ops := make([]*storage.Operation, 0, len(s.pending))
+	for key, e := range s.pending {
+		if e == nil {
+			ops = append(ops, storage.DeleteOperation(edgeStorageKeyPrefix+key.String()))
+			continue
+		}
+		value, err := encodeEdge(e)
+		if err != nil {
+			s.logger.Warn("failed to persist edge", zap.Stringer("edge_key", key), zap.Error(err))
+			continue
+		}
+		ops = append(ops, storage.SetOperation(edgeStorageKeyPrefix+key.String(), value))
+	}
+	clear(s.pending)
+	return ops
+}
+
+// expirePersisted writes the given operations to the storage, and deletes the expired edges from
+// it in the same batch. It returns the edges to expire: the edges completed by another instance
+// sharing the storage are not expired, and the persisted edges replace the expired ones, since
+// they may have been updated by another instance.
+//
+// Must be called holding flushMtx, without holding lock.
+func (s *Store) expirePersisted(ctx context.Context, client storage.Client, ops []*storage.Operation, expired []*Edge) []*Edge {
+	gets := make([]*storage.Operation, len(expired))
+	for i, edge := range expired {
+		key := edgeStorageKeyPrefix + edge.Key.String()
+		gets[i] = storage.GetOperation(key)
+		ops = append(ops, gets[i], storage.DeleteOperation(key))
+	}
+	if len(ops) == 0 {
+		return expired
+	}
+	if err := client.Batch(ctx, ops...); err != nil {
+		s.logger.Warn("failed to expire the persisted edges", zap.Error(err))
+		return expired
+	}
+
+	result := expired[:0]
+	for i, edge := range expired {
+		if gets[i].Value == nil {
+			// the edge was completed by another instance sharing the storage
+			continue
+		}
+		persisted, err := decodeEdge(gets[i].Value)
+		if err != nil {
+			s.logger.Warn("failed to read the expired edge from the storage", zap.Stringer("edge_key", edge.Key), zap.Error(err))
+			persisted = edge
+		}
+		result = append(result, persisted)
+	}
+	return result
+}
+
+// LoadPersisted reads the edges with the given keys from the storage in a single batch, to be
+// passed to UpsertEdge. Only the edges which are not held by the store, nor changed since the
+// last flush, are read. Errors are logged and handled as if the edges were not found, so that
+// the store keeps working in memory. It returns nil if the store isn't backed by a storage.
+func (s *Store) LoadPersisted(ctx context.Context, keys []Key) map[Key]*Edge {
+	s.mtx.Lock()
+	client := s.client
+	var (
+		gets    []*storage.Operation
+		getKeys []Key
+	)
+	if client != nil {
+		seen := make(map[Key]struct{}, len(keys))
+		for _, key := range keys {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			if _, ok := s.m[key]; ok {
+				continue
+			}
+			// the changes not flushed yet are more recent than the storage
+			if _, ok := s.pending[key]; ok {
+				continue
+			}
+			gets = append(gets, storage.GetOperation(edgeStorageKeyPrefix+key.String()))
+			getKeys = append(getKeys, key)
+		}
+	}
+	s.mtx.Unlock()
+
+	if len(gets) == 0 {
+		return nil
+	}
+	if err := client.Batch(ctx, gets...); err != nil {
+		s.logger.Warn("failed to read the persisted edges", zap.Error(err))
+		return nil
+	}
+	persisted := make(map[Key]*Edge)
+	for i, get := range gets {
+		if get.Value == nil {
+			continue
+		}
+		edge, err := decodeEdge(get.Value)
+		if err != nil {
+			s.logger.Warn("failed to read persisted edge", zap.Stringer("edge_key", getKeys[i]), zap.Error(err))
+			continue
+		}
+		persisted[getKeys[i]] = edge
+	}
+	return persisted
+}
+
+// persistedEdge returns the edge to update when it isn't held by the store: its change not
+// flushed yet, if any, or else the edge read from the storage. The edge read from the storage
+// is removed from persisted, its changes are tracked as pending from then on.
+//
+// Must be called holding lock.
+func (s *Store) persistedEdge(key Key, persisted map[Key]*Edge) (*Edge, bool) {
+	if s.client == nil {
+		return nil, false
+	}
+	if edge, ok := s.pending[key]; ok {
+		return edge, edge != nil
+	}
+	edge, ok := persisted[key]
+	delete(persisted, key)
+	return edge, ok
+}
+
+func encodeEdge(e *Edge) ([]byte, error) {
+	return json.Marshal(persistedEdge{
+		TraceID:          e.Key.tid.String(),
+		SpanID:           e.Key.sid.String(),
+		ConnectionType:   e.ConnectionType,
+		ServerService:    e.ServerService,
+		ClientService:    e.ClientService,
+		ServerLatencySec: e.ServerLatencySec,
+		ClientLatencySec: e.ClientLatencySec,
+		Failed:           e.Failed,
+		Dimensions:       e.Dimensions,
+		Peer:             e.Peer,
+		VirtualNodeLabel: e.VirtualNodeLabel,
+		Expiration:       e.expiration.UnixNano(),
+	})
+}
+
+func decodeEdge(value []byte) (*Edge, error) {
+	var pe persistedEdge
+	if err := json.Unmarshal(value, &pe); err != nil {
+		return nil, fmt.Errorf("failed to decode persisted edge: %w", err)
+	}
+
+	var tid pcommon.TraceID
+	var sid pcommon.SpanID
+	if err := decodeID(pe.TraceID, tid[:]); err != nil {
+		return nil, fmt.Errorf("failed to decode persisted edge: invalid trace ID: %w", err)
+	}
+	if err := decodeID(pe.SpanID, sid[:]); err != nil {
+		return nil, fmt.Errorf("failed to decode persisted edge: invalid span ID: %w", err)
+	}
+
+	e := &Edge{
+		Key:              NewKey(tid, sid),
+		TraceID:          tid,
+		ConnectionType:   pe.ConnectionType,
+		ServerService:    pe.ServerService,
+		ClientService:    pe.ClientService,
+		ServerLatencySec: pe.ServerLatencySec,
+		ClientLatencySec: pe.ClientLatencySec,
+		Failed:           pe.Failed,
+		Dimensions:       pe.Dimensions,
+		Peer:             pe.Peer,
+		VirtualNodeLabel: pe.VirtualNodeLabel,
+		expiration:       time.Unix(0, pe.Expiration),
+	}
+	if e.Dimensions == nil {
+		e.Dimensions = make(map[string]string)
+	}
+	if e.Peer == nil {
+		e.Peer = make(map[string]string)
+	}
+	return e, nil
+}
+
+// decodeID decodes the hex encoded ID into dst. An empty string decodes to an empty ID.
+func decodeID(s string, dst []byte) error {
+	if s == "" {
+		return nil
+	}
+	if hex.DecodedLen(len(s)) != len(dst) {
+		return fmt.Errorf("unexpected length %d", len(s))
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err
+}
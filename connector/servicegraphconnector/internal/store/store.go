@@ -5,11 +5,14 @@ package store // import "github.com/open-telemetry/opentelemetry-collector-contr
 
 import (
 	"container/list"
+	"context"
 	"errors"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
 )
 
 var ErrTooManyItems = errors.New("too many items")
@@ -25,6 +28,18 @@ func (k *Key) SpanIDIsEmpty() bool {
 	return k.sid.IsEmpty()
 }
 
+// TraceID returns the ID of the trace the edge belongs to. Both spans of an edge must be
+// sent to the same collector instance, e.g. by load balancing on the trace ID, unless
+// the store is backed by a storage extension shared by the instances.
+func (k Key) TraceID() pcommon.TraceID {
+	return k.tid
+}
+
+// String returns the key as the hex encoded trace ID and span ID, separated by a colon.
+func (k Key) String() string {
+	return k.tid.String() + ":" + k.sid.String()
+}
+
 func NewKey(tid pcommon.TraceID, sid pcommon.SpanID) Key {
 	return Key{tid: tid, sid: sid}
 }
@@ -39,6 +54,15 @@ type Store struct {
 
 	ttl      time.Duration
 	maxItems int
+
+	// client persists the edges which are not complete yet, nil if the store is in memory only
+	client storage.Client
+	logger *zap.Logger
+	// pending holds the changes of the edges not written to the storage yet, by edge key:
+	// the edge to persist, or nil to delete it
+	pending map[Key]*Edge
+	// flushMtx orders the writes to the storage, it must be acquired before mtx
+	flushMtx sync.Mutex
 }
 
 // NewStore creates a Store to build service graphs. The store caches edges, each representing a
@@ -67,20 +91,31 @@ func (s *Store) Len() int {
 // UpsertEdge fetches an Edge from the store and updates it using the given callback. If the Edge
 // doesn't exist yet, it creates a new one with the default TTL.
 // If the Edge is complete after applying the callback, it's completed and removed.
-func (s *Store) UpsertEdge(key Key, update Callback) (isNew bool, err error) {
+//
+// When the store is backed by a storage, persisted holds the edges read by LoadPersisted, and
+// the changes of the edges are written on the next call to Flush, Expire or Close.
+func (s *Store) UpsertEdge(key Key, persisted map[Key]*Edge, update Callback) (isNew bool, err error) {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
 	if storedEdge, ok := s.m[key]; ok {
-		edge := storedEdge.Value.(*Edge)
+		s.updateStoredEdge(storedEdge, update)
+		return false, nil
+	}
+
+	// The other half of the edge may have been received before a restart, or by another
+	// instance sharing the storage.
+	if edge, found := s.persistedEdge(key, persisted); found {
 		update(edge)
 
 		if edge.isComplete() {
 			s.onComplete(edge)
-			delete(s.m, key)
-			s.l.Remove(storedEdge)
+			s.setPending(key, nil)
+			return false, nil
 		}
 
+		// the edge expires on the instance which received its first half
+		s.setPending(key, edge)
 		return false, nil
 	}
 
@@ -100,38 +135,71 @@ func (s *Store) UpsertEdge(key Key, update Callback) (isNew bool, err error) {
 
 	ele := s.l.PushBack(edge)
 	s.m[key] = ele
+	s.setPending(key, edge)
 
 	return true, nil
 }
 
+// updateStoredEdge updates the edge held by the store, completing and removing it if it's complete.
+//
+// Must be called holding lock.
+func (s *Store) updateStoredEdge(storedEdge *list.Element, update Callback) {
+	edge := storedEdge.Value.(*Edge)
+	update(edge)
+
+	if edge.isComplete() {
+		s.onComplete(edge)
+		delete(s.m, edge.Key)
+		s.l.Remove(storedEdge)
+		s.setPending(edge.Key, nil)
+		return
+	}
+
+	s.setPending(edge.Key, edge)
+}
+
 // Expire evicts all expired items in the store.
-func (s *Store) Expire() {
-	s.mtx.Lock()
-	defer s.mtx.Unlock()
+func (s *Store) Expire(ctx context.Context) {
+	s.flushMtx.Lock()
+	defer s.flushMtx.Unlock()
 
+	s.mtx.Lock()
+	var expired []*Edge
 	// Iterates until no more items can be evicted
-	for s.tryEvictHead() {
+	for edge := s.evictExpiredHead(); edge != nil; edge = s.evictExpiredHead() {
+		expired = append(expired, edge)
+	}
+	client := s.client
+	var ops []*storage.Operation
+	if client != nil {
+		ops = s.takePendingOperations()
+	}
+	s.mtx.Unlock()
+
+	if client != nil {
+		expired = s.expirePersisted(ctx, client, ops, expired)
+	}
+	for _, edge := range expired {
+		s.onExpire(edge)
 	}
 }
 
-// tryEvictHead checks if the oldest item (head of list) can be evicted and will delete it if so.
-// Returns true if the head was evicted.
+// evictExpiredHead checks if the oldest item (head of list) can be evicted and will delete it if so.
+// Returns the evicted edge, or nil if the head was not evicted.
 //
 // Must be called holding lock.
-func (s *Store) tryEvictHead() bool {
+func (s *Store) evictExpiredHead() *Edge {
 	head := s.l.Front()
 	if head == nil {
-		return false // list is empty
+		return nil // list is empty
 	}
 
 	headEdge := head.Value.(*Edge)
 	if !headEdge.isExpired() {
-		return false
+		return nil
 	}
 
-	s.onExpire(headEdge)
 	delete(s.m, headEdge.Key)
 	s.l.Remove(head)
-
-	return true
+	return headEdge
 }
@@ -11,7 +11,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
 const clientService = "client"
@@ -26,7 +30,7 @@ func TestStoreUpsertEdge(t *testing.T) {
 	assert.Equal(t, 0, s.Len())
 
 	// Insert first half of an edge
-	isNew, err := s.UpsertEdge(key, func(e *Edge) {
+	isNew, err := s.UpsertEdge(key, nil, func(e *Edge) {
 		e.ClientService = clientService
 	})
 	require.NoError(t, err)
@@ -39,7 +43,7 @@ func TestStoreUpsertEdge(t *testing.T) {
 	assert.Equal(t, 0, onExpireCount)
 
 	// Insert the second half of an edge
-	isNew, err = s.UpsertEdge(key, func(e *Edge) {
+	isNew, err = s.UpsertEdge(key, nil, func(e *Edge) {
 		assert.Equal(t, clientService, e.ClientService)
 		e.ServerService = "server"
 	})
@@ -52,7 +56,7 @@ func TestStoreUpsertEdge(t *testing.T) {
 	assert.Equal(t, 0, onExpireCount)
 
 	// Insert an edge that will immediately expire
-	isNew, err = s.UpsertEdge(key, func(e *Edge) {
+	isNew, err = s.UpsertEdge(key, nil, func(e *Edge) {
 		e.ClientService = clientService
 		e.expiration = time.UnixMicro(0)
 	})
@@ -76,20 +80,20 @@ func TestStoreUpsertEdge_errTooManyItems(t *testing.T) {
 	s := NewStore(time.Hour, 1, countingCallback(&onCallbackCounter), countingCallback(&onCallbackCounter))
 	assert.Equal(t, 0, s.Len())
 
-	isNew, err := s.UpsertEdge(key1, func(e *Edge) {
+	isNew, err := s.UpsertEdge(key1, nil, func(e *Edge) {
 		e.ClientService = clientService
 	})
 	require.NoError(t, err)
 	require.True(t, isNew)
 	assert.Equal(t, 1, s.Len())
 
-	_, err = s.UpsertEdge(key2, func(e *Edge) {
+	_, err = s.UpsertEdge(key2, nil, func(e *Edge) {
 		e.ClientService = clientService
 	})
 	require.ErrorIs(t, err, ErrTooManyItems)
 	assert.Equal(t, 1, s.Len())
 
-	isNew, err = s.UpsertEdge(key1, func(e *Edge) {
+	isNew, err = s.UpsertEdge(key1, nil, func(e *Edge) {
 		e.ClientService = clientService
 	})
 	require.NoError(t, err)
@@ -118,12 +122,12 @@ func TestStoreExpire(t *testing.T) {
 	s := NewStore(-time.Second, testSize, onComplete, countingCallback(&onExpireCount))
 
 	for key := range keys {
-		isNew, err := s.UpsertEdge(key, noopCallback)
+		isNew, err := s.UpsertEdge(key, nil, noopCallback)
 		require.NoError(t, err)
 		require.True(t, isNew)
 	}
 
-	s.Expire()
+	s.Expire(t.Context())
 	assert.Equal(t, 0, s.Len())
 	assert.Equal(t, 0, onCompletedCount)
 	assert.Equal(t, testSize, onExpireCount)
@@ -148,20 +152,182 @@ func TestStoreConcurrency(t *testing.T) {
 	go accessor(func() {
 		key := NewKey(pcommon.TraceID([16]byte{byte(rand.IntN(32))}), pcommon.SpanID([8]byte{1, 2, 3}))
 
-		_, err := s.UpsertEdge(key, func(e *Edge) {
+		_, err := s.UpsertEdge(key, nil, func(e *Edge) {
 			e.ClientService = hex.EncodeToString(key.tid[:])
 		})
 		assert.NoError(t, err)
 	})
 
 	go accessor(func() {
-		s.Expire()
+		s.Expire(t.Context())
 	})
 
 	time.Sleep(100 * time.Millisecond)
 	close(end)
 }
 
+func TestKey(t *testing.T) {
+	key := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{4, 5, 6}))
+	assert.Equal(t, pcommon.TraceID([16]byte{1, 2, 3}), key.TraceID())
+	assert.Equal(t, "01020300000000000000000000000000:0405060000000000", key.String())
+}
+
+func TestStoreStorageSharedByReplicas(t *testing.T) {
+	key := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{1, 2, 3}))
+	client := storagetest.NewInMemoryClient(component.KindConnector, component.MustNewID("servicegraph"), "")
+
+	var completed []*Edge
+	onComplete := func(e *Edge) { completed = append(completed, e) }
+	var onExpireCount int
+
+	first := NewStore(time.Hour, 10, onComplete, countingCallback(&onExpireCount))
+	require.NoError(t, first.EnableStorage(t.Context(), client, zap.NewNop()))
+	second := NewStore(time.Hour, 10, onComplete, countingCallback(&onExpireCount))
+	require.NoError(t, second.EnableStorage(t.Context(), client, zap.NewNop()))
+
+	// the client span is received by the first replica
+	isNew, err := first.UpsertEdge(key, nil, func(e *Edge) {
+		e.ClientService = clientService
+		e.ClientLatencySec = 1
+		e.Dimensions["client_region"] = "eu"
+	})
+	require.NoError(t, err)
+	assert.True(t, isNew)
+	// the edge is only written to the storage once flushed
+	value, err := client.Get(t.Context(), edgeStorageKeyPrefix+key.String())
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	require.NoError(t, first.Flush(t.Context()))
+	assert.Nil(t, first.LoadPersisted(t.Context(), []Key{key}), "the edges held by the store are not read")
+
+	// the server span is received by the second replica, which pairs it with the persisted half
+	persisted := second.LoadPersisted(t.Context(), []Key{key, NewKey(pcommon.TraceID([16]byte{4}), pcommon.SpanID([8]byte{4}))})
+	require.Len(t, persisted, 1, "only the persisted edges are returned")
+	isNew, err = second.UpsertEdge(key, persisted, func(e *Edge) {
+		assert.Equal(t, clientService, e.ClientService)
+		e.ServerService = "server"
+	})
+	require.NoError(t, err)
+	assert.False(t, isNew)
+	assert.Equal(t, 0, second.Len())
+	assert.Empty(t, persisted, "the persisted edge is only used once")
+
+	require.Len(t, completed, 1)
+	assert.Equal(t, key, completed[0].Key)
+	assert.Equal(t, clientService, completed[0].ClientService)
+	assert.Equal(t, "server", completed[0].ServerService)
+	assert.Equal(t, 1.0, completed[0].ClientLatencySec)
+	assert.Equal(t, map[string]string{"client_region": "eu"}, completed[0].Dimensions)
+
+	require.NoError(t, second.Flush(t.Context()))
+
+	// the edge was completed by the second replica, so it does not expire on the first one
+	first.l.Front().Value.(*Edge).expiration = time.UnixMicro(0)
+	first.Expire(t.Context())
+	assert.Equal(t, 0, first.Len())
+	assert.Equal(t, 0, onExpireCount)
+}
+
+func TestStoreStorageExpireUpdatedByReplica(t *testing.T) {
+	key := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{1, 2, 3}))
+	client := storagetest.NewInMemoryClient(component.KindConnector, component.MustNewID("servicegraph"), "")
+
+	var expired []*Edge
+	onExpire := func(e *Edge) { expired = append(expired, e) }
+
+	first := NewStore(time.Hour, 10, noopCallback, onExpire)
+	require.NoError(t, first.EnableStorage(t.Context(), client, zap.NewNop()))
+	second := NewStore(time.Hour, 10, noopCallback, onExpire)
+	require.NoError(t, second.EnableStorage(t.Context(), client, zap.NewNop()))
+
+	_, err := first.UpsertEdge(key, nil, func(e *Edge) {
+		e.Failed = false
+	})
+	require.NoError(t, err)
+	require.NoError(t, first.Flush(t.Context()))
+	isNew, err := second.UpsertEdge(key, second.LoadPersisted(t.Context(), []Key{key}), func(e *Edge) {
+		e.Failed = true
+		e.Peer["peer.service"] = "db"
+	})
+	require.NoError(t, err)
+	assert.False(t, isNew)
+	assert.Equal(t, 0, second.Len(), "the edge expires on the replica which received it first")
+	require.NoError(t, second.Flush(t.Context()))
+
+	first.l.Front().Value.(*Edge).expiration = time.UnixMicro(0)
+	first.Expire(t.Context())
+	require.Len(t, expired, 1)
+	assert.True(t, expired[0].Failed)
+	assert.Equal(t, map[string]string{"peer.service": "db"}, expired[0].Peer)
+
+	value, err := client.Get(t.Context(), edgeStorageKeyPrefix+key.String())
+	require.NoError(t, err)
+	assert.Nil(t, value, "the expired edge is deleted from the storage")
+}
+
+func TestStoreStorageRestart(t *testing.T) {
+	dir := t.TempDir()
+	id := component.MustNewID("servicegraph")
+	keys := []Key{
+		NewKey(pcommon.TraceID([16]byte{1}), pcommon.SpanID([8]byte{1})),
+		NewKey(pcommon.TraceID([16]byte{2}), pcommon.SpanID([8]byte{2})),
+		NewKey(pcommon.TraceID([16]byte{3}), pcommon.SpanID([8]byte{3})),
+	}
+
+	s := NewStore(time.Hour, 10, noopCallback, noopCallback)
+	require.NoError(t, s.EnableStorage(t.Context(), storagetest.NewFileBackedClient(component.KindConnector, id, "", dir), zap.NewNop()))
+	for _, key := range keys {
+		_, err := s.UpsertEdge(key, nil, func(e *Edge) {
+			e.ClientService = clientService
+		})
+		require.NoError(t, err)
+	}
+	// the first edge expires before the others
+	s.l.Front().Value.(*Edge).expiration = time.Now().Add(-time.Second)
+	require.NoError(t, s.Close(t.Context()))
+
+	var completedCount int
+	var expired []*Edge
+	onExpire := func(e *Edge) { expired = append(expired, e) }
+	s = NewStore(time.Hour, 2, countingCallback(&completedCount), onExpire)
+	require.NoError(t, s.EnableStorage(t.Context(), storagetest.NewFileBackedClient(component.KindConnector, id, "", dir), zap.NewNop()))
+	assert.Equal(t, 2, s.Len(), "the restored edges are limited by max_items")
+	value, err := s.client.Get(t.Context(), edgeStorageKeyPrefix+keys[2].String())
+	require.NoError(t, err)
+	assert.Nil(t, value, "the edges which are not restored are deleted from the storage")
+
+	s.Expire(t.Context())
+	require.Len(t, expired, 1)
+	assert.Equal(t, keys[0], expired[0].Key)
+	assert.Equal(t, clientService, expired[0].ClientService)
+
+	_, err = s.UpsertEdge(keys[1], nil, func(e *Edge) {
+		e.ServerService = "server"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, completedCount)
+	assert.Equal(t, 0, s.Len())
+	require.NoError(t, s.Close(t.Context()))
+}
+
+func TestStoreStorageEnableFailure(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindConnector, component.MustNewID("servicegraph"), "")
+	require.NoError(t, client.Set(t.Context(), pendingStorageKey, []byte("invalid")))
+
+	s := NewStore(time.Hour, 10, noopCallback, noopCallback)
+	require.ErrorContains(t, s.EnableStorage(t.Context(), client, zap.NewNop()), "failed to decode the pending edges")
+
+	// the store keeps working in memory without using the client
+	key := NewKey(pcommon.TraceID([16]byte{1}), pcommon.SpanID([8]byte{1}))
+	_, err := s.UpsertEdge(key, nil, noopCallback)
+	require.NoError(t, err)
+	require.NoError(t, s.Flush(t.Context()))
+	value, err := client.Get(t.Context(), edgeStorageKeyPrefix+key.String())
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	require.NoError(t, s.Close(t.Context()))
+}
+
 func noopCallback(*Edge) {}
 
 func countingCallback(counter *int) func(*Edge) {
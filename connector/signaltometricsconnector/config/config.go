@@ -21,6 +21,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofilesample"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
@@ -50,12 +51,15 @@ type Config struct {
 	Datapoints []MetricInfo `mapstructure:"datapoints"`
 	Logs       []MetricInfo `mapstructure:"logs"`
 	Profiles   []MetricInfo `mapstructure:"profiles"`
+	// ProfileSamples describes the metrics to produce from the samples of
+	// the profiles, using the OTTL profile sample context.
+	ProfileSamples []MetricInfo `mapstructure:"profile_samples"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
 
 func (c *Config) Validate() error {
-	if len(c.Spans) == 0 && len(c.Datapoints) == 0 && len(c.Logs) == 0 && len(c.Profiles) == 0 {
+	if len(c.Spans) == 0 && len(c.Datapoints) == 0 && len(c.Logs) == 0 && len(c.Profiles) == 0 && len(c.ProfileSamples) == 0 {
 		return errors.New("no configuration provided, at least one should be specified")
 	}
 	var multiError error // collect all errors at once
@@ -115,6 +119,20 @@ func (c *Config) Validate() error {
 			}
 		}
 	}
+	if len(c.ProfileSamples) > 0 {
+		parser, err := ottlprofilesample.NewParser(
+			customottl.ProfileSampleFuncs(),
+			component.TelemetrySettings{Logger: zap.NewNop()},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create parser for OTTL profile samples: %w", err)
+		}
+		for _, sample := range c.ProfileSamples {
+			if err := validateMetricInfo(sample, parser); err != nil {
+				multiError = errors.Join(multiError, fmt.Errorf("failed to validate profile_samples configuration: %w", err))
+			}
+		}
+	}
 	return multiError
 }
 
@@ -144,6 +162,10 @@ func (c *Config) Unmarshal(collectorCfg *confmap.Conf) error {
 		info.ensureDefaults()
 		c.Profiles[i] = info
 	}
+	for i, info := range c.ProfileSamples {
+		info.ensureDefaults()
+		c.ProfileSamples[i] = info
+	}
 	return nil
 }
 
@@ -151,6 +173,10 @@ type Attribute struct {
 	Key          string `mapstructure:"key"`
 	Optional     bool   `mapstructure:"optional"`
 	DefaultValue any    `mapstructure:"default_value"`
+	// Value, if not-empty, is an OTTL value expression computing the value
+	// of the attribute instead of looking it up in the incoming data. Only
+	// supported for `attributes`.
+	Value string `mapstructure:"value"`
 }
 
 type Histogram struct {
@@ -228,6 +254,11 @@ func (mi *MetricInfo) validateAttributes() error {
 		}
 		duplicate[attr.Key] = struct{}{}
 	}
+	for _, attr := range mi.IncludeResourceAttributes {
+		if attr.Value != "" {
+			return fmt.Errorf("value is not supported for resource attribute %s", attr.Key)
+		}
+	}
 	return nil
 }
 
@@ -344,6 +375,15 @@ func validateMetricInfo[K any](mi MetricInfo, parser ottl.Parser[K]) error {
 		return fmt.Errorf("exactly one of the metrics must be defined, %d found", metricsDefinedCount)
 	}
 
+	for _, attr := range mi.Attributes {
+		if attr.Value == "" {
+			continue
+		}
+		if _, err := parser.ParseValueExpression(attr.Value); err != nil {
+			return fmt.Errorf("failed to parse value OTTL expression for attribute %s: %w", attr.Key, err)
+		}
+	}
+
 	// validate OTTL conditions
 	if _, err := parser.ParseConditions(mi.Conditions); err != nil {
 		return fmt.Errorf("failed to parse OTTL conditions: %w", err)
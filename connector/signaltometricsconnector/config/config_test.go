@@ -35,6 +35,7 @@ func TestConfig(t *testing.T) {
 				fullErrorForSignal(t, "datapoints", "missing required metric name"),
 				fullErrorForSignal(t, "logs", "missing required metric name"),
 				fullErrorForSignal(t, "profiles", "missing required metric name"),
+				fullErrorForSignal(t, "profile_samples", "missing required metric name"),
 			},
 		},
 		{
@@ -113,6 +114,14 @@ func TestConfig(t *testing.T) {
 				fullErrorForSignal(t, "datapoints", "failed to parse value OTTL expression"),
 				fullErrorForSignal(t, "logs", "failed to parse value OTTL expression"),
 				fullErrorForSignal(t, "profiles", "failed to parse value OTTL expression"),
+				fullErrorForSignal(t, "profile_samples", "failed to parse value OTTL expression"),
+			},
+		},
+		{
+			path: "invalid_ottl_attribute_value",
+			errorMsgs: []string{
+				fullErrorForSignal(t, "spans", "failed to parse value OTTL expression for attribute key.1"),
+				fullErrorForSignal(t, "profile_samples", "failed to parse value OTTL expression for attribute key.1"),
 			},
 		},
 		{
@@ -122,6 +131,7 @@ func TestConfig(t *testing.T) {
 				fullErrorForSignal(t, "datapoints", "failed to parse OTTL conditions"),
 				fullErrorForSignal(t, "logs", "failed to parse OTTL conditions"),
 				fullErrorForSignal(t, "profiles", "failed to parse OTTL conditions"),
+				fullErrorForSignal(t, "profile_samples", "failed to parse OTTL conditions"),
 			},
 		},
 		{
@@ -222,6 +232,25 @@ func TestConfig(t *testing.T) {
 						}),
 					},
 				},
+				ProfileSamples: []MetricInfo{
+					{
+						Name:                      "profile_sample.cpu",
+						Description:               "CPU time",
+						Unit:                      "ns",
+						IncludeResourceAttributes: []Attribute{{Key: "key.1", DefaultValue: "foo"}},
+						Attributes: []Attribute{
+							{Key: "key.2", DefaultValue: "bar"},
+							{Key: "key.3", Optional: true},
+						},
+						Conditions: []string{
+							`Len(values) > 0`,
+						},
+						Histogram: configoptional.Some(Histogram{
+							Buckets: []float64{1_000, 10_000, 100_000},
+							Value:   "SampleValue()",
+						}),
+					},
+				},
 			},
 		},
 	} {
@@ -255,7 +284,7 @@ func fullErrorForSignal(t *testing.T, signal, errMsg string) string {
 	t.Helper()
 
 	switch signal {
-	case "spans", "datapoints", "logs", "profiles":
+	case "spans", "datapoints", "logs", "profiles", "profile_samples":
 		return fmt.Sprintf(validationMsgFormat, signal, errMsg)
 	default:
 		panic("unhandled signal type")
@@ -21,6 +21,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofilesample"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
@@ -29,10 +30,11 @@ type signalToMetrics struct {
 	collectorInstanceInfo model.CollectorInstanceInfo
 	logger                *zap.Logger
 
-	spanMetricDefs    []model.MetricDef[ottlspan.TransformContext]
-	dpMetricDefs      []model.MetricDef[ottldatapoint.TransformContext]
-	logMetricDefs     []model.MetricDef[ottllog.TransformContext]
-	profileMetricDefs []model.MetricDef[ottlprofile.TransformContext]
+	spanMetricDefs          []model.MetricDef[ottlspan.TransformContext]
+	dpMetricDefs            []model.MetricDef[ottldatapoint.TransformContext]
+	logMetricDefs           []model.MetricDef[ottllog.TransformContext]
+	profileMetricDefs       []model.MetricDef[ottlprofile.TransformContext]
+	profileSampleMetricDefs []model.MetricDef[ottlprofilesample.TransformContext]
 
 	component.StartFunc
 	component.ShutdownFunc
@@ -247,12 +249,27 @@ func (sm *signalToMetrics) ConsumeLogs(ctx context.Context, logs plog.Logs) erro
 }
 
 func (sm *signalToMetrics) ConsumeProfiles(ctx context.Context, profiles pprofile.Profiles) error {
-	if len(sm.profileMetricDefs) == 0 {
+	if len(sm.profileMetricDefs) == 0 && len(sm.profileSampleMetricDefs) == 0 {
 		return nil
 	}
 
 	processedMetrics := pmetric.NewMetrics()
 	processedMetrics.ResourceMetrics().EnsureCapacity(profiles.ResourceProfiles().Len())
+	if err := sm.aggregateProfiles(ctx, profiles, processedMetrics); err != nil {
+		return err
+	}
+	if err := sm.aggregateProfileSamples(ctx, profiles, processedMetrics); err != nil {
+		return err
+	}
+	return sm.next.ConsumeMetrics(ctx, processedMetrics)
+}
+
+// aggregateProfiles aggregates the metrics defined for profiles into the processed metrics.
+func (sm *signalToMetrics) aggregateProfiles(ctx context.Context, profiles pprofile.Profiles, processedMetrics pmetric.Metrics) error {
+	if len(sm.profileMetricDefs) == 0 {
+		return nil
+	}
+
 	aggregator := aggregator.NewAggregator[ottlprofile.TransformContext](processedMetrics)
 
 	for i := 0; i < profiles.ResourceProfiles().Len(); i++ {
@@ -294,5 +311,61 @@ func (sm *signalToMetrics) ConsumeProfiles(ctx context.Context, profiles pprofil
 		}
 	}
 	aggregator.Finalize(sm.profileMetricDefs)
-	return sm.next.ConsumeMetrics(ctx, processedMetrics)
+	return nil
+}
+
+// aggregateProfileSamples aggregates the metrics defined for profile samples into the
+// processed metrics.
+func (sm *signalToMetrics) aggregateProfileSamples(ctx context.Context, profiles pprofile.Profiles, processedMetrics pmetric.Metrics) error {
+	if len(sm.profileSampleMetricDefs) == 0 {
+		return nil
+	}
+
+	dictionary := profiles.Dictionary()
+	aggregator := aggregator.NewAggregator[ottlprofilesample.TransformContext](processedMetrics)
+
+	for i := 0; i < profiles.ResourceProfiles().Len(); i++ {
+		resourceProfile := profiles.ResourceProfiles().At(i)
+		resourceAttrs := resourceProfile.Resource().Attributes()
+
+		for j := 0; j < resourceProfile.ScopeProfiles().Len(); j++ {
+			scopeProfile := resourceProfile.ScopeProfiles().At(j)
+
+			for k := 0; k < scopeProfile.Profiles().Len(); k++ {
+				profile := scopeProfile.Profiles().At(k)
+
+				for l := 0; l < profile.Sample().Len(); l++ {
+					sample := profile.Sample().At(l)
+					sampleAttrs := pprofile.FromAttributeIndices(dictionary.AttributeTable(), sample, dictionary)
+
+					for _, md := range sm.profileSampleMetricDefs {
+						filteredSampleAttrs, ok := md.FilterAttributes(sampleAttrs)
+						if !ok {
+							continue
+						}
+
+						// The transform context is created from original attributes so that the
+						// OTTL expressions are also applied on the original attributes.
+						tCtx := ottlprofilesample.NewTransformContext(sample, profile, dictionary, scopeProfile.Scope(), resourceProfile.Resource(), scopeProfile, resourceProfile)
+						if md.Conditions != nil {
+							match, err := md.Conditions.Eval(ctx, tCtx)
+							if err != nil {
+								return fmt.Errorf("failed to evaluate conditions: %w", err)
+							}
+							if !match {
+								sm.logger.Debug("condition not matched, skipping", zap.String("name", md.Key.Name))
+								continue
+							}
+						}
+						filteredResAttrs := md.FilterResourceAttributes(resourceAttrs, sm.collectorInstanceInfo)
+						if err := aggregator.Aggregate(ctx, tCtx, md, filteredResAttrs, filteredSampleAttrs, 1); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+	}
+	aggregator.Finalize(sm.profileSampleMetricDefs)
+	return nil
 }
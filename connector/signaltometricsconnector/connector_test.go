@@ -169,6 +169,39 @@ func TestConnectorWithProfiles(t *testing.T) {
 	}
 }
 
+func TestConnectorWithProfileSamples(t *testing.T) {
+	testCases := []string{
+		"sum",
+		"function",
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	for _, tc := range testCases {
+		t.Run(tc, func(t *testing.T) {
+			sampleTestDataDir := filepath.Join(testDataDir, "profile_samples")
+			inputProfiles, err := golden.ReadProfiles(filepath.Join(sampleTestDataDir, "profiles.yaml"))
+			require.NoError(t, err)
+
+			next := &consumertest.MetricsSink{}
+			tcTestDataDir := filepath.Join(sampleTestDataDir, tc)
+			factory, settings, cfg := setupConnector(t, tcTestDataDir)
+			connector, err := factory.CreateProfilesToMetrics(ctx, settings, cfg, next)
+			require.NoError(t, err)
+			require.IsType(t, &signalToMetrics{}, connector)
+
+			require.NoError(t, connector.ConsumeProfiles(ctx, inputProfiles))
+			require.Len(t, next.AllMetrics(), 1)
+
+			expectedMetrics, err := golden.ReadMetrics(filepath.Join(tcTestDataDir, "output.yaml"))
+			require.NoError(t, err)
+
+			assertAggregatedMetrics(t, expectedMetrics, next.AllMetrics()[0])
+		})
+	}
+}
+
 func BenchmarkConnectorWithTraces(b *testing.B) {
 	factory := NewFactory()
 	settings := connectortest.NewNopSettings(metadata.Type)
@@ -19,6 +19,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofilesample"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
@@ -152,12 +153,27 @@ func createProfilesToMetrics(
 		metricDefs = append(metricDefs, md)
 	}
 
+	sampleParser, err := ottlprofilesample.NewParser(customottl.ProfileSampleFuncs(), set.TelemetrySettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTTL statement parser for profile samples: %w", err)
+	}
+
+	sampleMetricDefs := make([]model.MetricDef[ottlprofilesample.TransformContext], 0, len(c.ProfileSamples))
+	for _, info := range c.ProfileSamples {
+		var md model.MetricDef[ottlprofilesample.TransformContext]
+		if err := md.FromMetricInfo(info, sampleParser, set.TelemetrySettings); err != nil {
+			return nil, fmt.Errorf("failed to parse provided metric information; %w", err)
+		}
+		sampleMetricDefs = append(sampleMetricDefs, md)
+	}
+
 	return &signalToMetrics{
 		logger: set.Logger,
 		collectorInstanceInfo: model.NewCollectorInstanceInfo(
 			set.TelemetrySettings,
 		),
-		next:              nextConsumer,
-		profileMetricDefs: metricDefs,
+		next:                    nextConsumer,
+		profileMetricDefs:       metricDefs,
+		profileSampleMetricDefs: sampleMetricDefs,
 	}, nil
 }
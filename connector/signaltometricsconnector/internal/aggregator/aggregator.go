@@ -51,6 +51,11 @@ func (a *Aggregator[K]) Aggregate(
 	resAttrs, srcAttrs pcommon.Map,
 	defaultCount int64,
 ) error {
+	// Computed attributes are evaluated with the same transform context as
+	// the metric value, after the conditions of the metric definition.
+	if ok, err := md.ComputeAttributes(ctx, tCtx, srcAttrs); err != nil || !ok {
+		return err
+	}
 	switch md.Key.Type {
 	case pmetric.MetricTypeExponentialHistogram:
 		val, count, err := getValueCount(
@@ -8,6 +8,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofilesample"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
 )
@@ -31,6 +32,15 @@ func ProfileFuncs() map[string]ottl.Factory[ottlprofile.TransformContext] {
 	return commonFuncs[ottlprofile.TransformContext]()
 }
 
+func ProfileSampleFuncs() map[string]ottl.Factory[ottlprofilesample.TransformContext] {
+	common := commonFuncs[ottlprofilesample.TransformContext]()
+	sampleValueFactory := NewSampleValueFactory()
+	common[sampleValueFactory.Name()] = sampleValueFactory
+	sampleLeafFunctionFactory := NewSampleLeafFunctionFactory()
+	common[sampleLeafFunctionFactory.Name()] = sampleLeafFunctionFactory
+	return common
+}
+
 func commonFuncs[K any]() map[string]ottl.Factory[K] {
 	return ottlfuncs.StandardFuncs[K]()
 }
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package customottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/customottl"
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofilesample"
+)
+
+func NewSampleLeafFunctionFactory() ottl.Factory[ottlprofilesample.TransformContext] {
+	return ottl.NewFactory("SampleLeafFunction", nil, createSampleLeafFunctionFunction)
+}
+
+func createSampleLeafFunctionFunction(ottl.FunctionContext, ottl.Arguments) (ottl.ExprFunc[ottlprofilesample.TransformContext], error) {
+	return sampleLeafFunction()
+}
+
+// sampleLeafFunction returns the name of the function the sample was recorded
+// in, i.e. the innermost function of the leaf location of its stack, resolved
+// through the dictionary of the profiles. It returns nil if the stack of the
+// sample doesn't resolve to a function.
+func sampleLeafFunction() (ottl.ExprFunc[ottlprofilesample.TransformContext], error) {
+	return func(_ context.Context, tCtx ottlprofilesample.TransformContext) (any, error) {
+		dictionary := tCtx.GetProfilesDictionary()
+		stackIndex := int(tCtx.GetProfileSample().StackIndex())
+		if stackIndex < 0 || stackIndex >= dictionary.StackTable().Len() {
+			return nil, nil
+		}
+		locationIndices := dictionary.StackTable().At(stackIndex).LocationIndices()
+		if locationIndices.Len() == 0 {
+			return nil, nil
+		}
+		// The first location of a stack is the leaf
+		locationIndex := int(locationIndices.At(0))
+		if locationIndex < 0 || locationIndex >= dictionary.LocationTable().Len() {
+			return nil, nil
+		}
+		lines := dictionary.LocationTable().At(locationIndex).Line()
+		if lines.Len() == 0 {
+			return nil, nil
+		}
+		// The first line of a location is the innermost inlined function
+		functionIndex := int(lines.At(0).FunctionIndex())
+		if functionIndex < 0 || functionIndex >= dictionary.FunctionTable().Len() {
+			return nil, nil
+		}
+		nameIndex := int(dictionary.FunctionTable().At(functionIndex).NameStrindex())
+		if nameIndex < 0 || nameIndex >= dictionary.StringTable().Len() {
+			return nil, nil
+		}
+		return dictionary.StringTable().At(nameIndex), nil
+	}, nil
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package customottl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofilesample"
+)
+
+func Test_SampleLeafFunction(t *testing.T) {
+	// newDictionary creates a dictionary with a single stack made of a leaf
+	// location with an inlined function, `inlined` within `leaf`, called by
+	// `main`.
+	newDictionary := func() pprofile.ProfilesDictionary {
+		dictionary := pprofile.NewProfilesDictionary()
+		dictionary.StringTable().Append("", "inlined", "leaf", "main")
+		for _, nameIndex := range []int32{0, 1, 2, 3} {
+			dictionary.FunctionTable().AppendEmpty().SetNameStrindex(nameIndex)
+		}
+		dictionary.LocationTable().AppendEmpty()
+		leaf := dictionary.LocationTable().AppendEmpty()
+		leaf.Line().AppendEmpty().SetFunctionIndex(1)
+		leaf.Line().AppendEmpty().SetFunctionIndex(2)
+		dictionary.LocationTable().AppendEmpty().Line().AppendEmpty().SetFunctionIndex(3)
+		dictionary.StackTable().AppendEmpty()
+		dictionary.StackTable().AppendEmpty().LocationIndices().Append(1, 2)
+		return dictionary
+	}
+
+	for _, tc := range []struct {
+		name       string
+		stackIndex int32
+		modify     func(pprofile.ProfilesDictionary)
+		want       any
+	}{
+		{name: "leaf_function", stackIndex: 1, want: "inlined"},
+		{name: "empty_stack", stackIndex: 0, want: nil},
+		{name: "stack_out_of_range", stackIndex: 2, want: nil},
+		{
+			name:       "location_without_lines",
+			stackIndex: 1,
+			modify: func(dictionary pprofile.ProfilesDictionary) {
+				dictionary.LocationTable().At(1).Line().RemoveIf(func(pprofile.Line) bool { return true })
+			},
+			want: nil,
+		},
+		{
+			name:       "location_out_of_range",
+			stackIndex: 1,
+			modify: func(dictionary pprofile.ProfilesDictionary) {
+				dictionary.StackTable().At(1).LocationIndices().SetAt(0, 5)
+			},
+			want: nil,
+		},
+		{
+			name:       "function_out_of_range",
+			stackIndex: 1,
+			modify: func(dictionary pprofile.ProfilesDictionary) {
+				dictionary.LocationTable().At(1).Line().At(0).SetFunctionIndex(5)
+			},
+			want: nil,
+		},
+		{
+			name:       "name_out_of_range",
+			stackIndex: 1,
+			modify: func(dictionary pprofile.ProfilesDictionary) {
+				dictionary.FunctionTable().At(1).SetNameStrindex(5)
+			},
+			want: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			exprFunc, err := sampleLeafFunction()
+			require.NoError(t, err)
+			dictionary := newDictionary()
+			if tc.modify != nil {
+				tc.modify(dictionary)
+			}
+			sample := pprofile.NewSample()
+			sample.SetStackIndex(tc.stackIndex)
+			result, err := exprFunc(nil, ottlprofilesample.NewTransformContext(
+				sample,
+				pprofile.NewProfile(),
+				dictionary,
+				pcommon.NewInstrumentationScope(),
+				pcommon.NewResource(),
+				pprofile.NewScopeProfiles(),
+				pprofile.NewResourceProfiles(),
+			))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, result)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package customottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/customottl"
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofilesample"
+)
+
+func NewSampleValueFactory() ottl.Factory[ottlprofilesample.TransformContext] {
+	return ottl.NewFactory("SampleValue", nil, createSampleValueFunction)
+}
+
+func createSampleValueFunction(ottl.FunctionContext, ottl.Arguments) (ottl.ExprFunc[ottlprofilesample.TransformContext], error) {
+	return sampleValue()
+}
+
+// sampleValue returns the total value of the sample, in the unit of the sample
+// type of the profile, e.g. nanoseconds of CPU time or bytes allocated.
+func sampleValue() (ottl.ExprFunc[ottlprofilesample.TransformContext], error) {
+	return func(_ context.Context, tCtx ottlprofilesample.TransformContext) (any, error) {
+		sample := tCtx.GetProfileSample()
+		if sample.Values().Len() == 0 {
+			// Without values, each timestamp records a single occurrence
+			return int64(sample.TimestampsUnixNano().Len()), nil
+		}
+		var total int64
+		for _, v := range sample.Values().All() {
+			total += v
+		}
+		return total, nil
+	}, nil
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package customottl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofilesample"
+)
+
+func Test_SampleValue(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		values     []int64
+		timestamps []uint64
+		want       int64
+	}{
+		{name: "empty", want: 0},
+		{name: "single_value", values: []int64{42}, timestamps: []uint64{1}, want: 42},
+		{name: "multiple_values", values: []int64{10, 20, 30}, timestamps: []uint64{1, 2, 3}, want: 60},
+		{name: "timestamps_only", timestamps: []uint64{1, 2, 3, 4}, want: 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			exprFunc, err := sampleValue()
+			require.NoError(t, err)
+			sample := pprofile.NewSample()
+			sample.Values().FromRaw(tc.values)
+			sample.TimestampsUnixNano().FromRaw(tc.timestamps)
+			result, err := exprFunc(nil, ottlprofilesample.NewTransformContext(
+				sample,
+				pprofile.NewProfile(),
+				pprofile.NewProfilesDictionary(),
+				pcommon.NewInstrumentationScope(),
+				pcommon.NewResource(),
+				pprofile.NewScopeProfiles(),
+				pprofile.NewResourceProfiles(),
+			))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, result)
+		})
+	}
+}
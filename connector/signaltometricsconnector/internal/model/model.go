@@ -4,6 +4,7 @@
 package model // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/model"
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -21,6 +22,15 @@ type AttributeKeyValue struct {
 	DefaultValue pcommon.Value
 }
 
+// ComputedAttribute is an attribute whose value is computed from an OTTL
+// value expression instead of being looked up in the incoming data.
+type ComputedAttribute[K any] struct {
+	Key          string
+	Optional     bool
+	DefaultValue pcommon.Value
+	Value        *ottl.ValueExpression[K]
+}
+
 type MetricKey struct {
 	Name        string
 	Type        pmetric.MetricType
@@ -130,6 +140,7 @@ type MetricDef[K any] struct {
 	Key                       MetricKey
 	IncludeResourceAttributes []AttributeKeyValue
 	Attributes                []AttributeKeyValue
+	ComputedAttributes        []ComputedAttribute[K]
 	Conditions                *ottl.ConditionSequence[K]
 	ExponentialHistogram      *ExponentialHistogram[K]
 	ExplicitHistogram         *ExplicitHistogram[K]
@@ -151,7 +162,19 @@ func (md *MetricDef[K]) FromMetricInfo(
 	if err != nil {
 		return fmt.Errorf("failed to parse include resource attribute config: %w", err)
 	}
-	md.Attributes, err = parseAttributeConfigs(mi.Attributes)
+	var lookedUpAttributes, computedAttributes []config.Attribute
+	for _, attr := range mi.Attributes {
+		if attr.Value != "" {
+			computedAttributes = append(computedAttributes, attr)
+			continue
+		}
+		lookedUpAttributes = append(lookedUpAttributes, attr)
+	}
+	md.Attributes, err = parseAttributeConfigs(lookedUpAttributes)
+	if err != nil {
+		return fmt.Errorf("failed to parse attribute config: %w", err)
+	}
+	md.ComputedAttributes, err = parseComputedAttributeConfigs(computedAttributes, parser)
 	if err != nil {
 		return fmt.Errorf("failed to parse attribute config: %w", err)
 	}
@@ -240,7 +263,34 @@ func (md *MetricDef[K]) FilterAttributes(attrs pcommon.Map) (pcommon.Map, bool)
 			return pcommon.Map{}, false
 		}
 	}
-	return filterAttributes(attrs, md.Attributes, len(md.Attributes)), true
+	return filterAttributes(attrs, md.Attributes, len(md.Attributes)+len(md.ComputedAttributes)), true
+}
+
+// ComputeAttributes evaluates the `ComputedAttributes` of the metric definition
+// and adds them to the attributes filtered by `FilterAttributes`. Similar to
+// attribute filtering, if the value of a computed attribute without a default
+// value and not optional is nil, the method returns false, signaling that the
+// metric should not be processed.
+func (md *MetricDef[K]) ComputeAttributes(ctx context.Context, tCtx K, attrs pcommon.Map) (bool, error) {
+	for _, attr := range md.ComputedAttributes {
+		val, err := attr.Value.Eval(ctx, tCtx)
+		if err != nil {
+			return false, fmt.Errorf("failed to evaluate value for attribute %s: %w", attr.Key, err)
+		}
+		if val == nil {
+			switch {
+			case attr.DefaultValue.Type() != pcommon.ValueTypeEmpty:
+				attr.DefaultValue.CopyTo(attrs.PutEmpty(attr.Key))
+			case !attr.Optional:
+				return false, nil
+			}
+			continue
+		}
+		if err := attrs.PutEmpty(attr.Key).FromRaw(val); err != nil {
+			return false, fmt.Errorf("failed to set value for attribute %s: %w", attr.Key, err)
+		}
+	}
+	return true, nil
 }
 
 func filterAttributes(attrs pcommon.Map, filters []AttributeKeyValue, expectedLen int) pcommon.Map {
@@ -278,3 +328,29 @@ func parseAttributeConfigs(cfgs []config.Attribute) ([]AttributeKeyValue, error)
 	}
 	return kvs, nil
 }
+
+func parseComputedAttributeConfigs[K any](cfgs []config.Attribute, parser ottl.Parser[K]) ([]ComputedAttribute[K], error) {
+	var errs []error
+	attrs := make([]ComputedAttribute[K], len(cfgs))
+	for i, attr := range cfgs {
+		val := pcommon.NewValueEmpty()
+		if err := val.FromRaw(attr.DefaultValue); err != nil {
+			errs = append(errs, err)
+		}
+		expr, err := parser.ParseValueExpression(attr.Value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse value OTTL expression for attribute %s: %w", attr.Key, err))
+		}
+		attrs[i] = ComputedAttribute[K]{
+			Key:          attr.Key,
+			Optional:     attr.Optional,
+			DefaultValue: val,
+			Value:        expr,
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return attrs, nil
+}
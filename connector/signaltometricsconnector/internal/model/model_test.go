@@ -11,9 +11,12 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
 )
 
 const (
@@ -170,6 +173,88 @@ func TestFilterAttributes(t *testing.T) {
 	}
 }
 
+func TestComputeAttributes(t *testing.T) {
+	parser, err := ottlspan.NewParser(
+		ottlfuncs.StandardFuncs[ottlspan.TransformContext](),
+		componenttest.NewNopTelemetrySettings(),
+	)
+	require.NoError(t, err)
+	span := ptrace.NewSpan()
+	span.SetName("GET /users")
+	tCtx := ottlspan.NewTransformContext(
+		span,
+		pcommon.NewInstrumentationScope(),
+		pcommon.NewResource(),
+		ptrace.NewScopeSpans(),
+		ptrace.NewResourceSpans(),
+	)
+	for _, tc := range []struct {
+		name               string
+		attributes         []ComputedAttribute[ottlspan.TransformContext]
+		expectedDecision   bool           // whether to process the entity or not
+		expectedAttributes map[string]any // map of filtered and computed attributes
+	}{
+		{
+			name:             "no_attribute_configured",
+			expectedDecision: true,
+			expectedAttributes: map[string]any{
+				"key.1": "val.1",
+			},
+		},
+		{
+			name: "attributes_computed",
+			attributes: []ComputedAttribute[ottlspan.TransformContext]{
+				testComputedAttribute(t, parser, "span.name", `name`, false, nil),
+				testComputedAttribute(t, parser, "span.name.length", `Len(name)`, false, nil),
+			},
+			expectedDecision: true,
+			expectedAttributes: map[string]any{
+				"key.1":            "val.1",
+				"span.name":        "GET /users",
+				"span.name.length": int64(10),
+			},
+		},
+		{
+			// A nil value is replaced by the default value, or omitted
+			// if the attribute is optional.
+			name: "nil_value_with_default_or_optional",
+			attributes: []ComputedAttribute[ottlspan.TransformContext]{
+				testComputedAttribute(t, parser, "key.302", `attributes["key.404"]`, false, "default"),
+				testComputedAttribute(t, parser, "key.412", `attributes["key.404"]`, true, nil),
+			},
+			expectedDecision: true,
+			expectedAttributes: map[string]any{
+				"key.1":   "val.1",
+				"key.302": "default",
+			},
+		},
+		{
+			// A required attribute with a nil value results in the entity
+			// not being processed.
+			name: "nil_value_required",
+			attributes: []ComputedAttribute[ottlspan.TransformContext]{
+				testComputedAttribute(t, parser, "key.404", `attributes["key.404"]`, false, nil),
+			},
+			expectedDecision: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			md := MetricDef[ottlspan.TransformContext]{
+				ComputedAttributes: tc.attributes,
+			}
+			attrs := pcommon.NewMap()
+			attrs.PutStr("key.1", "val.1")
+			ok, err := md.ComputeAttributes(t.Context(), tCtx, attrs)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDecision, ok)
+			if ok {
+				// Only if the decision is true, the map should be compared
+				assert.Empty(t, cmp.Diff(tc.expectedAttributes, attrs.AsRaw()))
+			}
+		})
+	}
+}
+
 func testCollectorInstanceInfo(t *testing.T) CollectorInstanceInfo {
 	t.Helper()
 
@@ -198,3 +283,23 @@ func testAttributeKeyValue(
 		DefaultValue: defaultVal,
 	}
 }
+
+func testComputedAttribute(
+	t *testing.T,
+	parser ottl.Parser[ottlspan.TransformContext],
+	k, expr string,
+	optional bool,
+	val any,
+) ComputedAttribute[ottlspan.TransformContext] {
+	t.Helper()
+
+	value, err := parser.ParseValueExpression(expr)
+	require.NoError(t, err)
+	kv := testAttributeKeyValue(t, k, optional, val)
+	return ComputedAttribute[ottlspan.TransformContext]{
+		Key:          kv.Key,
+		Optional:     kv.Optional,
+		DefaultValue: kv.DefaultValue,
+		Value:        value,
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// defaultLabels are the labels added by the translator unless disabled.
+var defaultLabels = []string{"exporter", "job", "instance", "level"}
+
+// Config defines configuration for the Loki exporter.
+type Config struct {
+	confighttp.ClientConfig `mapstructure:",squash"`
+	QueueConfig             exporterhelper.QueueBatchConfig `mapstructure:"sending_queue"`
+	BackOffConfig           configretry.BackOffConfig       `mapstructure:"retry_on_failure"`
+
+	// Labels are the attributes promoted to stream labels, in addition to the
+	// ones selected by the `loki.resource.labels` and `loki.attribute.labels` hints.
+	Labels LabelsConfig `mapstructure:"labels"`
+
+	// DefaultLabelsEnabled allows disabling the exporter, job, instance and level
+	// labels, they are all enabled by default.
+	DefaultLabelsEnabled map[string]bool `mapstructure:"default_labels_enabled"`
+}
+
+// LabelsConfig selects the attributes promoted to stream labels. Label names are
+// the attribute names normalized to the Prometheus label names rules.
+type LabelsConfig struct {
+	// ResourceAttributes are the resource attributes promoted to labels.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+	// Attributes are the log record attributes promoted to labels.
+	Attributes []string `mapstructure:"attributes"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return fmt.Errorf("endpoint must be a valid URL: %w", err)
+	}
+	for label := range cfg.DefaultLabelsEnabled {
+		if !slices.Contains(defaultLabels, label) {
+			return fmt.Errorf("default_labels_enabled: unknown label %q, expected one of %v", label, defaultLabels)
+		}
+	}
+	for _, attr := range cfg.Labels.ResourceAttributes {
+		if attr == "" {
+			return errors.New("labels::resource_attributes must not contain empty names")
+		}
+	}
+	for _, attr := range cfg.Labels.Attributes {
+		if attr == "" {
+			return errors.New("labels::attributes must not contain empty names")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: func() component.Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Endpoint = "https://loki.example.com:3100/loki/api/v1/push"
+				cfg.Timeout = 10 * time.Second
+				cfg.Headers = map[string]configopaque.String{"X-Scope-OrgID": "tenant-1"}
+				cfg.Labels = LabelsConfig{
+					ResourceAttributes: []string{"service.name", "k8s.namespace.name"},
+					Attributes:         []string{"http.method"},
+				}
+				cfg.DefaultLabelsEnabled = map[string]bool{"exporter": false, "job": true}
+				cfg.QueueConfig.NumConsumers = 1
+				cfg.BackOffConfig.InitialInterval = 10 * time.Second
+				cfg.BackOffConfig.MaxInterval = time.Minute
+				cfg.BackOffConfig.MaxElapsedTime = 10 * time.Minute
+				return cfg
+			}(),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			errorMessage: "endpoint is required",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unknown_default_label"),
+			errorMessage: `default_labels_enabled: unknown label "service", expected one of [exporter job instance level]`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "empty_label"),
+			errorMessage: "labels::attributes must not contain empty names",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.errorMessage != "" {
+				assert.EqualError(t, xconfmap.Validate(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/grafana/loki/pkg/push"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+const (
+	headerContentType = "Content-Type"
+	headerRetryAfter  = "Retry-After"
+	headerTenant      = "X-Scope-OrgID"
+	headerUserAgent   = "User-Agent"
+
+	protobufContentType = "application/x-protobuf"
+
+	// maxErrorBodySize bounds the part of an error response kept in the error message.
+	maxErrorBodySize = 1024
+)
+
+// outOfOrderReasons are the messages Loki responds with when entries are rejected
+// because they are older than the ones already ingested for the same stream.
+var outOfOrderReasons = []string{"out of order", "too far behind"}
+
+type lokiExporter struct {
+	config    *Config
+	settings  component.TelemetrySettings
+	logger    *zap.Logger
+	client    *http.Client
+	userAgent string
+}
+
+func newExporter(cfg *Config, set exporter.Settings) *lokiExporter {
+	return &lokiExporter{
+		config:   cfg,
+		settings: set.TelemetrySettings,
+		logger:   set.Logger,
+		userAgent: fmt.Sprintf("%s/%s (%s/%s)",
+			set.BuildInfo.Description, set.BuildInfo.Version, runtime.GOOS, runtime.GOARCH),
+	}
+}
+
+func (l *lokiExporter) start(ctx context.Context, host component.Host) error {
+	client, err := l.config.ToClient(ctx, host, l.settings)
+	if err != nil {
+		return err
+	}
+	l.client = client
+	return nil
+}
+
+func (l *lokiExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	requests := loki.LogsToLokiRequestsWithLabels(ld, l.config.DefaultLabelsEnabled,
+		l.config.Labels.ResourceAttributes, l.config.Labels.Attributes)
+
+	// The requests of the tenants are all sent, the data of the successful ones is
+	// sent again on retries, which Loki ignores as duplicates.
+	var errs []error
+	retryable := false
+	for tenant, request := range requests {
+		if request.Report.NumDropped > 0 {
+			l.logger.Debug("Some logs could not be converted and were dropped",
+				zap.String("tenant", tenant),
+				zap.Int("dropped", request.Report.NumDropped),
+				zap.Errors("errors", request.Report.Errors))
+		}
+		if len(request.Streams) == 0 {
+			continue
+		}
+
+		err := l.send(ctx, tenant, request.PushRequest)
+		if err == nil {
+			continue
+		}
+		errs = append(errs, err)
+		if !consumererror.IsPermanent(err) {
+			retryable = true
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err == nil || retryable {
+		return err
+	}
+	return consumererror.NewPermanent(err)
+}
+
+func (l *lokiExporter) send(ctx context.Context, tenant string, request *push.PushRequest) error {
+	sortEntries(request)
+	buf, err := request.Marshal()
+	if err != nil {
+		return consumererror.NewPermanent(fmt.Errorf("failed to marshal push request: %w", err))
+	}
+	buf = snappy.Encode(nil, buf)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.config.Endpoint, bytes.NewReader(buf))
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	req.Header.Set(headerContentType, protobufContentType)
+	req.Header.Set(headerUserAgent, l.userAgent)
+	if tenant != "" {
+		req.Header.Set(headerTenant, tenant)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make an HTTP request: %w", err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	err = fmt.Errorf("HTTP %d %q: %s", resp.StatusCode, http.StatusText(resp.StatusCode), bytes.TrimSpace(body))
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+		// Loki responds with 429 when the ingestion rate or the stream limits are exceeded.
+		return exporterhelper.NewThrottleRetry(err, retryAfter(resp.Header.Get(headerRetryAfter)))
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return err
+	case isOutOfOrder(string(body)):
+		// Sending the entries again would be rejected the same way, the entries
+		// which were in order were accepted by Loki.
+		l.logger.Warn("Loki rejected out of order entries",
+			zap.String("tenant", tenant), zap.Error(err))
+		return consumererror.NewPermanent(fmt.Errorf("entries out of order: %w", err))
+	default:
+		return consumererror.NewPermanent(err)
+	}
+}
+
+// sortEntries sorts the entries of each stream by timestamp, as Loki rejects the
+// entries older than the last one received for the stream unless unordered writes
+// are enabled.
+func sortEntries(request *push.PushRequest) {
+	for i := range request.Streams {
+		slices.SortStableFunc(request.Streams[i].Entries, func(a, b push.Entry) int {
+			return a.Timestamp.Compare(b.Timestamp)
+		})
+	}
+}
+
+func isOutOfOrder(body string) bool {
+	for _, reason := range outOfOrderReasons {
+		if strings.Contains(body, reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryAfter parses the value of a Retry-After header, either a number of seconds
+// or an HTTP date. It returns 0 when the value is missing or invalid.
+func retryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
+
+func (*lokiExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/grafana/loki/pkg/push"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter/internal/metadata"
+)
+
+// lokiServer decodes the push requests it receives, by tenant.
+type lokiServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests map[string][]*push.PushRequest
+}
+
+func newLokiServer(t *testing.T, handler http.HandlerFunc) *lokiServer {
+	s := &lokiServer{requests: map[string][]*push.PushRequest{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, protobufContentType, r.Header.Get(headerContentType))
+		compressed, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		buf, err := snappy.Decode(nil, compressed)
+		assert.NoError(t, err)
+		var req push.PushRequest
+		assert.NoError(t, req.Unmarshal(buf))
+
+		s.mu.Lock()
+		tenant := r.Header.Get(headerTenant)
+		s.requests[tenant] = append(s.requests[tenant], &req)
+		s.mu.Unlock()
+
+		if handler != nil {
+			handler(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestExporter(t *testing.T, endpoint string, configure func(*Config)) *lokiExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	if configure != nil {
+		configure(cfg)
+	}
+	exp := newExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+	require.NoError(t, exp.start(t.Context(), componenttest.NewNopHost()))
+	return exp
+}
+
+func TestPushLogs(t *testing.T) {
+	server := newLokiServer(t, nil)
+	exp := newTestExporter(t, server.URL, func(cfg *Config) {
+		cfg.Labels = LabelsConfig{
+			ResourceAttributes: []string{"service.name"},
+			Attributes:         []string{"http.method"},
+		}
+		cfg.DefaultLabelsEnabled = map[string]bool{"exporter": false}
+	})
+
+	now := time.Now()
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	rl.Resource().Attributes().PutStr("tenant", "acme")
+	rl.Resource().Attributes().PutStr("loki.tenant", "tenant")
+	logs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for i, method := range []string{"GET", "POST", "GET"} {
+		lr := logs.AppendEmpty()
+		// The records are the most recent first.
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(-time.Duration(i) * time.Second)))
+		lr.Attributes().PutStr("http.method", method)
+		lr.Body().SetStr(method)
+	}
+	ld.ResourceLogs().At(0).CopyTo(ld.ResourceLogs().AppendEmpty())
+	ld.ResourceLogs().At(1).Resource().Attributes().Remove("loki.tenant")
+
+	require.NoError(t, exp.pushLogs(t.Context(), ld))
+
+	require.Len(t, server.requests, 2)
+	for _, tenant := range []string{"", "acme"} {
+		require.Len(t, server.requests[tenant], 1)
+		streams := map[string][]push.Entry{}
+		for _, stream := range server.requests[tenant][0].Streams {
+			streams[stream.Labels] = stream.Entries
+		}
+		require.Len(t, streams, 2)
+
+		get := streams[`{http_method="GET", job="checkout", service_name="checkout"}`]
+		require.Len(t, get, 2)
+		assert.True(t, get[0].Timestamp.Before(get[1].Timestamp), "entries must be sorted by timestamp")
+		assert.Len(t, streams[`{http_method="POST", job="checkout", service_name="checkout"}`], 1)
+	}
+}
+
+func TestPushLogs_Responses(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		header     http.Header
+		body       string
+		permanent  bool
+		retryAfter bool
+		errMessage string
+	}{
+		{
+			name:       "rate limited",
+			status:     http.StatusTooManyRequests,
+			header:     http.Header{headerRetryAfter: []string{"30"}},
+			body:       "Ingestion rate limit exceeded for user acme",
+			retryAfter: true,
+			errMessage: `HTTP 429 "Too Many Requests": Ingestion rate limit exceeded for user acme`,
+		},
+		{
+			name:       "server error",
+			status:     http.StatusInternalServerError,
+			body:       "rpc error",
+			errMessage: `HTTP 500 "Internal Server Error": rpc error`,
+		},
+		{
+			name:       "out of order",
+			status:     http.StatusBadRequest,
+			body:       "entry with timestamp 2024-01-01 00:00:00 +0000 UTC ignored, reason: 'entry too far behind'",
+			permanent:  true,
+			errMessage: `entries out of order: HTTP 400 "Bad Request": entry with timestamp 2024-01-01 00:00:00 +0000 UTC ignored, reason: 'entry too far behind'`,
+		},
+		{
+			name:       "bad request",
+			status:     http.StatusBadRequest,
+			body:       "error at least one label pair is required per stream",
+			permanent:  true,
+			errMessage: `HTTP 400 "Bad Request": error at least one label pair is required per stream`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newLokiServer(t, func(w http.ResponseWriter, _ *http.Request) {
+				for k, v := range tt.header {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+			exp := newTestExporter(t, server.URL, nil)
+
+			ld := plog.NewLogs()
+			ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+
+			err := exp.pushLogs(t.Context(), ld)
+			require.ErrorContains(t, err, tt.errMessage)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+			if tt.retryAfter {
+				// The delay requested by Loki is passed to the retry sender.
+				assert.Contains(t, err.Error(), "30s")
+			}
+		})
+	}
+}
+
+func TestPushLogs_Empty(t *testing.T) {
+	server := newLokiServer(t, nil)
+	exp := newTestExporter(t, server.URL, nil)
+
+	require.NoError(t, exp.pushLogs(t.Context(), plog.NewLogs()))
+	assert.Empty(t, server.requests)
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), retryAfter(""))
+	assert.Equal(t, time.Duration(0), retryAfter("soon"))
+	assert.Equal(t, 5*time.Second, retryAfter("5"))
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	assert.InDelta(t, time.Minute, retryAfter(date), float64(2*time.Second))
+	assert.Equal(t, time.Duration(0), retryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)))
+}
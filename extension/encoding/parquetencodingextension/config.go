@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+var _ xconfmap.Validator = (*Config)(nil)
+
+var compressionCodecs = map[string]compress.Compression{
+	"none":   compress.Codecs.Uncompressed,
+	"snappy": compress.Codecs.Snappy,
+	"gzip":   compress.Codecs.Gzip,
+	"zstd":   compress.Codecs.Zstd,
+}
+
+type Config struct {
+	// Compression is the codec used to compress the column chunks of the Parquet files.
+	// One of none, snappy, gzip or zstd.
+	Compression string `mapstructure:"compression"`
+	// MaxRowGroupLength is the maximum number of rows in a row group.
+	MaxRowGroupLength int64 `mapstructure:"max_row_group_length"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (c *Config) Validate() error {
+	if _, ok := compressionCodecs[c.Compression]; !ok {
+		return fmt.Errorf("unsupported compression: %q", c.Compression)
+	}
+	if c.MaxRowGroupLength <= 0 {
+		return errors.New("max_row_group_length must be greater than 0")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name:   "zstd",
+			modify: func(c *Config) { c.Compression = "zstd" },
+		},
+		{
+			name:        "unsupported compression",
+			modify:      func(c *Config) { c.Compression = "lzo" },
+			expectedErr: `unsupported compression: "lzo"`,
+		},
+		{
+			name:        "invalid row group length",
+			modify:      func(c *Config) { c.MaxRowGroupLength = 0 },
+			expectedErr: "max_row_group_length must be greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := createDefaultConfig().(*Config)
+			tt.modify(c)
+			err := c.Validate()
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
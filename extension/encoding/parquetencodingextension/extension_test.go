@@ -0,0 +1,302 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	testTime  = time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	testTrace = pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	testSpan  = pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+)
+
+func TestMarshalTraces(t *testing.T) {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("tracer")
+	ss.Scope().SetVersion("1.0.0")
+
+	root := ss.Spans().AppendEmpty()
+	root.SetTraceID(testTrace)
+	root.SetSpanID(testSpan)
+	root.SetName("GET /cart")
+	root.SetKind(ptrace.SpanKindServer)
+	root.SetStartTimestamp(pcommon.NewTimestampFromTime(testTime))
+	root.SetEndTimestamp(pcommon.NewTimestampFromTime(testTime.Add(time.Second)))
+	root.Status().SetCode(ptrace.StatusCodeError)
+	root.Status().SetMessage("boom")
+	root.Attributes().PutStr("http.method", "GET")
+	root.Attributes().PutInt("http.status_code", 500)
+	event := root.Events().AppendEmpty()
+	event.SetName("exception")
+	event.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	event.Attributes().PutStr("exception.type", "io.EOF")
+
+	child := ss.Spans().AppendEmpty()
+	child.SetTraceID(testTrace)
+	child.SetSpanID(pcommon.SpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1}))
+	child.SetParentSpanID(testSpan)
+	child.SetName("SELECT")
+	link := child.Links().AppendEmpty()
+	link.SetTraceID(testTrace)
+	link.SetSpanID(testSpan)
+
+	ex := newTestExtension(t)
+	buf, err := ex.MarshalTraces(traces)
+	require.NoError(t, err)
+
+	tbl := readParquet(t, buf)
+	require.Equal(t, int64(2), tbl.NumRows())
+
+	assert.Equal(t, map[string]string{"service.name": "checkout"}, attributes(column(t, tbl, "resource_attributes"), 0))
+	assert.Equal(t, "tracer", column(t, tbl, "scope_name").(*array.String).Value(1))
+	assert.Equal(t, "1.0.0", column(t, tbl, "scope_version").(*array.String).Value(1))
+
+	traceIDs := column(t, tbl, "trace_id").(*array.String)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", traceIDs.Value(0))
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", traceIDs.Value(1))
+	parents := column(t, tbl, "parent_span_id").(*array.String)
+	assert.True(t, parents.IsNull(0))
+	assert.Equal(t, "0102030405060708", parents.Value(1))
+
+	assert.Equal(t, "GET /cart", column(t, tbl, "name").(*array.String).Value(0))
+	assert.Equal(t, "Server", column(t, tbl, "kind").(*array.String).Value(0))
+	assert.Equal(t, arrow.Timestamp(testTime.UnixNano()), column(t, tbl, "start_time").(*array.Timestamp).Value(0))
+	assert.Equal(t, int64(time.Second), column(t, tbl, "duration_ns").(*array.Int64).Value(0))
+	assert.Equal(t, "Error", column(t, tbl, "status_code").(*array.String).Value(0))
+	assert.Equal(t, "boom", column(t, tbl, "status_message").(*array.String).Value(0))
+	assert.True(t, column(t, tbl, "status_message").IsNull(1))
+	assert.Equal(t, map[string]string{"http.method": "GET", "http.status_code": "500"}, attributes(column(t, tbl, "attributes"), 0))
+
+	events := column(t, tbl, "events").(*array.List)
+	start, end := events.ValueOffsets(0)
+	require.Equal(t, int64(1), end-start)
+	eventFields := events.ListValues().(*array.Struct)
+	assert.Equal(t, "exception", eventFields.Field(1).(*array.String).Value(int(start)))
+	assert.Equal(t, map[string]string{"exception.type": "io.EOF"}, attributes(eventFields.Field(2), int(start)))
+	start, end = events.ValueOffsets(1)
+	assert.Equal(t, start, end)
+
+	links := column(t, tbl, "links").(*array.List)
+	start, end = links.ValueOffsets(1)
+	require.Equal(t, int64(1), end-start)
+	assert.Equal(t, "0102030405060708", links.ListValues().(*array.Struct).Field(1).(*array.String).Value(int(start)))
+}
+
+func TestMarshalLogs(t *testing.T) {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.26.0")
+	rl.Resource().Attributes().PutStr("host.name", "web-1")
+	sl := rl.ScopeLogs().AppendEmpty()
+
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(testTime.Add(time.Millisecond)))
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetSeverityText("WARN")
+	lr.Body().SetStr("disk almost full")
+	lr.Attributes().PutDouble("usage", 0.95)
+	lr.SetTraceID(testTrace)
+	lr.SetSpanID(testSpan)
+	lr.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+
+	structured := sl.LogRecords().AppendEmpty()
+	structured.Body().SetEmptyMap().PutStr("msg", "hello")
+
+	ex := newTestExtension(t)
+	buf, err := ex.MarshalLogs(logs)
+	require.NoError(t, err)
+
+	tbl := readParquet(t, buf)
+	require.Equal(t, int64(2), tbl.NumRows())
+
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.26.0", column(t, tbl, "resource_schema_url").(*array.String).Value(0))
+	assert.True(t, column(t, tbl, "scope_name").IsNull(0))
+	assert.Equal(t, arrow.Timestamp(testTime.UnixNano()), column(t, tbl, "time").(*array.Timestamp).Value(0))
+	assert.True(t, column(t, tbl, "time").IsNull(1))
+	assert.Equal(t, int32(plog.SeverityNumberWarn), column(t, tbl, "severity_number").(*array.Int32).Value(0))
+	assert.Equal(t, "WARN", column(t, tbl, "severity_text").(*array.String).Value(0))
+	assert.Equal(t, "disk almost full", column(t, tbl, "body").(*array.String).Value(0))
+	assert.JSONEq(t, `{"msg":"hello"}`, column(t, tbl, "body").(*array.String).Value(1))
+	assert.Equal(t, map[string]string{"usage": "0.95"}, attributes(column(t, tbl, "attributes"), 0))
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", column(t, tbl, "trace_id").(*array.String).Value(0))
+	assert.True(t, column(t, tbl, "trace_id").IsNull(1))
+	assert.Equal(t, uint32(1), column(t, tbl, "flags").(*array.Uint32).Value(0))
+}
+
+func TestMarshalMetrics(t *testing.T) {
+	metrics := pmetric.NewMetrics()
+	sm := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("queue.size")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(42)
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetUnit("{request}")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sumDP := sum.Sum().DataPoints().AppendEmpty()
+	sumDP.SetDoubleValue(1.5)
+	sumDP.Attributes().PutStr("method", "GET")
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	histogramDP := histogram.Histogram().DataPoints().AppendEmpty()
+	histogramDP.SetCount(3)
+	histogramDP.SetSum(6)
+	histogramDP.BucketCounts().FromRaw([]uint64{1, 2})
+	histogramDP.ExplicitBounds().FromRaw([]float64{5})
+
+	expHistogram := sm.Metrics().AppendEmpty()
+	expHistogram.SetName("size")
+	expHistogramDP := expHistogram.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	expHistogramDP.SetCount(4)
+	expHistogramDP.SetScale(2)
+	expHistogramDP.SetZeroCount(1)
+	expHistogramDP.Positive().SetOffset(-1)
+	expHistogramDP.Positive().BucketCounts().FromRaw([]uint64{3})
+
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("duration")
+	summaryDP := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	summaryDP.SetCount(10)
+	summaryDP.SetSum(20)
+	quantile := summaryDP.QuantileValues().AppendEmpty()
+	quantile.SetQuantile(0.99)
+	quantile.SetValue(5)
+
+	ex := newTestExtension(t)
+	buf, err := ex.MarshalMetrics(metrics)
+	require.NoError(t, err)
+
+	tbl := readParquet(t, buf)
+	require.Equal(t, int64(5), tbl.NumRows())
+
+	names := column(t, tbl, "metric_name").(*array.String)
+	types := column(t, tbl, "metric_type").(*array.String)
+	for i, expected := range []string{"Gauge", "Sum", "Histogram", "ExponentialHistogram", "Summary"} {
+		assert.Equal(t, expected, types.Value(i))
+	}
+	assert.Equal(t, "queue.size", names.Value(0))
+
+	// Gauge
+	assert.Equal(t, int64(42), column(t, tbl, "value_int").(*array.Int64).Value(0))
+	assert.True(t, column(t, tbl, "value_double").IsNull(0))
+	assert.True(t, column(t, tbl, "aggregation_temporality").IsNull(0))
+
+	// Sum
+	assert.Equal(t, "{request}", column(t, tbl, "metric_unit").(*array.String).Value(1))
+	assert.Equal(t, 1.5, column(t, tbl, "value_double").(*array.Float64).Value(1))
+	assert.True(t, column(t, tbl, "value_int").IsNull(1))
+	assert.Equal(t, "Cumulative", column(t, tbl, "aggregation_temporality").(*array.String).Value(1))
+	assert.True(t, column(t, tbl, "is_monotonic").(*array.Boolean).Value(1))
+	assert.Equal(t, map[string]string{"method": "GET"}, attributes(column(t, tbl, "attributes"), 1))
+
+	// Histogram
+	assert.Equal(t, uint64(3), column(t, tbl, "count").(*array.Uint64).Value(2))
+	assert.Equal(t, 6.0, column(t, tbl, "sum").(*array.Float64).Value(2))
+	assert.True(t, column(t, tbl, "min").IsNull(2))
+	assert.Equal(t, []uint64{1, 2}, uint64List(column(t, tbl, "bucket_counts"), 2))
+	assert.True(t, column(t, tbl, "bucket_counts").IsNull(0))
+
+	// Exponential histogram
+	assert.Equal(t, int32(2), column(t, tbl, "scale").(*array.Int32).Value(3))
+	assert.Equal(t, uint64(1), column(t, tbl, "zero_count").(*array.Uint64).Value(3))
+	assert.Equal(t, int32(-1), column(t, tbl, "positive_offset").(*array.Int32).Value(3))
+	assert.Equal(t, []uint64{3}, uint64List(column(t, tbl, "positive_bucket_counts"), 3))
+	assert.True(t, column(t, tbl, "sum").IsNull(3))
+
+	// Summary
+	quantiles := column(t, tbl, "quantile_values").(*array.List)
+	start, end := quantiles.ValueOffsets(4)
+	require.Equal(t, int64(1), end-start)
+	quantileFields := quantiles.ListValues().(*array.Struct)
+	assert.Equal(t, 0.99, quantileFields.Field(0).(*array.Float64).Value(int(start)))
+	assert.Equal(t, 5.0, quantileFields.Field(1).(*array.Float64).Value(int(start)))
+}
+
+func TestMarshalEmpty(t *testing.T) {
+	ex := newTestExtension(t)
+	buf, err := ex.MarshalTraces(ptrace.NewTraces())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), readParquet(t, buf).NumRows())
+}
+
+func TestMarshalCompression(t *testing.T) {
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+
+	for codec := range compressionCodecs {
+		t.Run(codec, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Compression = codec
+			buf, err := newExtension(cfg).MarshalLogs(logs)
+			require.NoError(t, err)
+			tbl := readParquet(t, buf)
+			assert.Equal(t, "hello", column(t, tbl, "body").(*array.String).Value(0))
+		})
+	}
+}
+
+func newTestExtension(t *testing.T) *parquetExtension {
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, cfg.Validate())
+	return newExtension(cfg)
+}
+
+func readParquet(t *testing.T, buf []byte) arrow.Table {
+	tbl, err := pqarrow.ReadTable(t.Context(), bytes.NewReader(buf), parquet.NewReaderProperties(memory.DefaultAllocator), pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	t.Cleanup(tbl.Release)
+	return tbl
+}
+
+func column(t *testing.T, tbl arrow.Table, name string) arrow.Array {
+	indices := tbl.Schema().FieldIndices(name)
+	require.Len(t, indices, 1)
+	chunks := tbl.Column(indices[0]).Data().Chunks()
+	require.Len(t, chunks, 1)
+	return chunks[0]
+}
+
+func attributes(arr arrow.Array, i int) map[string]string {
+	m := arr.(*array.Map)
+	keys := m.Keys().(*array.String)
+	items := m.Items().(*array.String)
+	start, end := m.ValueOffsets(i)
+	attrs := make(map[string]string, end-start)
+	for j := int(start); j < int(end); j++ {
+		attrs[keys.Value(j)] = items.Value(j)
+	}
+	return attrs
+}
+
+func uint64List(arr arrow.Array, i int) []uint64 {
+	l := arr.(*array.List)
+	start, end := l.ValueOffsets(i)
+	values := l.ListValues().(*array.Uint64)
+	return values.Uint64Values()[start:end]
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+var logSchema = newSchema(
+	arrow.Field{Name: "time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "observed_time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "severity_number", Type: arrow.PrimitiveTypes.Int32},
+	arrow.Field{Name: "severity_text", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "event_name", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "body", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "attributes", Type: attributesType},
+	arrow.Field{Name: "trace_id", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "span_id", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+)
+
+func appendLogRecords(b *array.RecordBuilder, logs plog.Logs) {
+	r := &rowBuilder{b: b}
+	for _, rl := range logs.ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				r.appendResource(rl.Resource(), rl.SchemaUrl(), sl.Scope())
+				appendTimestamp(r.column("time"), lr.Timestamp())
+				appendTimestamp(r.column("observed_time"), lr.ObservedTimestamp())
+				r.column("severity_number").(*array.Int32Builder).Append(int32(lr.SeverityNumber()))
+				appendString(r.column("severity_text"), lr.SeverityText())
+				appendString(r.column("event_name"), lr.EventName())
+				appendString(r.column("body"), lr.Body().AsString())
+				appendAttributes(r.column("attributes"), lr.Attributes())
+				appendString(r.column("trace_id"), lr.TraceID().String())
+				appendString(r.column("span_id"), lr.SpanID().String())
+				r.column("flags").(*array.Uint32Builder).Append(uint32(lr.Flags()))
+				r.endRow()
+			}
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var (
+	quantileValueType = arrow.StructOf(
+		arrow.Field{Name: "quantile", Type: arrow.PrimitiveTypes.Float64},
+		arrow.Field{Name: "value", Type: arrow.PrimitiveTypes.Float64},
+	)
+
+	dataPointSchema = newSchema(
+		arrow.Field{Name: "metric_name", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "metric_description", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "metric_unit", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "metric_type", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "aggregation_temporality", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "is_monotonic", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+		arrow.Field{Name: "start_time", Type: timestampType, Nullable: true},
+		arrow.Field{Name: "time", Type: timestampType, Nullable: true},
+		arrow.Field{Name: "attributes", Type: attributesType},
+		arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+		// Gauge and sum values.
+		arrow.Field{Name: "value_int", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		arrow.Field{Name: "value_double", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		// Histogram, exponential histogram and summary values.
+		arrow.Field{Name: "count", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+		arrow.Field{Name: "sum", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		arrow.Field{Name: "min", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		arrow.Field{Name: "max", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		arrow.Field{Name: "bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+		arrow.Field{Name: "explicit_bounds", Type: arrow.ListOf(arrow.PrimitiveTypes.Float64), Nullable: true},
+		arrow.Field{Name: "scale", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		arrow.Field{Name: "zero_count", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+		arrow.Field{Name: "positive_offset", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		arrow.Field{Name: "positive_bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+		arrow.Field{Name: "negative_offset", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		arrow.Field{Name: "negative_bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+		arrow.Field{Name: "quantile_values", Type: arrow.ListOf(quantileValueType), Nullable: true},
+	)
+)
+
+func appendDataPoints(b *array.RecordBuilder, metrics pmetric.Metrics) {
+	r := &rowBuilder{b: b}
+	for _, rm := range metrics.ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, m := range sm.Metrics().All() {
+				appendMetricDataPoints(r, rm, sm, m)
+			}
+		}
+	}
+}
+
+func appendMetricDataPoints(r *rowBuilder, rm pmetric.ResourceMetrics, sm pmetric.ScopeMetrics, m pmetric.Metric) {
+	// startRow appends the columns shared by all the data points of the metric.
+	startRow := func(start, ts pcommon.Timestamp, attrs pcommon.Map, flags pmetric.DataPointFlags) {
+		r.appendResource(rm.Resource(), rm.SchemaUrl(), sm.Scope())
+		r.column("metric_name").(*array.StringBuilder).Append(m.Name())
+		appendString(r.column("metric_description"), m.Description())
+		appendString(r.column("metric_unit"), m.Unit())
+		r.column("metric_type").(*array.StringBuilder).Append(m.Type().String())
+		appendTimestamp(r.column("start_time"), start)
+		appendTimestamp(r.column("time"), ts)
+		appendAttributes(r.column("attributes"), attrs)
+		r.column("flags").(*array.Uint32Builder).Append(uint32(flags))
+	}
+
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for _, dp := range m.Gauge().DataPoints().All() {
+			startRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+			appendNumberValue(r, dp)
+			r.endRow()
+		}
+	case pmetric.MetricTypeSum:
+		sum := m.Sum()
+		for _, dp := range sum.DataPoints().All() {
+			startRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+			r.column("aggregation_temporality").(*array.StringBuilder).Append(sum.AggregationTemporality().String())
+			r.column("is_monotonic").(*array.BooleanBuilder).Append(sum.IsMonotonic())
+			appendNumberValue(r, dp)
+			r.endRow()
+		}
+	case pmetric.MetricTypeHistogram:
+		histogram := m.Histogram()
+		for _, dp := range histogram.DataPoints().All() {
+			startRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+			r.column("aggregation_temporality").(*array.StringBuilder).Append(histogram.AggregationTemporality().String())
+			r.column("count").(*array.Uint64Builder).Append(dp.Count())
+			if dp.HasSum() {
+				r.column("sum").(*array.Float64Builder).Append(dp.Sum())
+			}
+			if dp.HasMin() {
+				r.column("min").(*array.Float64Builder).Append(dp.Min())
+			}
+			if dp.HasMax() {
+				r.column("max").(*array.Float64Builder).Append(dp.Max())
+			}
+			appendUint64List(r.column("bucket_counts"), dp.BucketCounts().AsRaw())
+			appendFloat64List(r.column("explicit_bounds"), dp.ExplicitBounds().AsRaw())
+			r.endRow()
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		histogram := m.ExponentialHistogram()
+		for _, dp := range histogram.DataPoints().All() {
+			startRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+			r.column("aggregation_temporality").(*array.StringBuilder).Append(histogram.AggregationTemporality().String())
+			r.column("count").(*array.Uint64Builder).Append(dp.Count())
+			if dp.HasSum() {
+				r.column("sum").(*array.Float64Builder).Append(dp.Sum())
+			}
+			if dp.HasMin() {
+				r.column("min").(*array.Float64Builder).Append(dp.Min())
+			}
+			if dp.HasMax() {
+				r.column("max").(*array.Float64Builder).Append(dp.Max())
+			}
+			r.column("scale").(*array.Int32Builder).Append(dp.Scale())
+			r.column("zero_count").(*array.Uint64Builder).Append(dp.ZeroCount())
+			r.column("positive_offset").(*array.Int32Builder).Append(dp.Positive().Offset())
+			appendUint64List(r.column("positive_bucket_counts"), dp.Positive().BucketCounts().AsRaw())
+			r.column("negative_offset").(*array.Int32Builder).Append(dp.Negative().Offset())
+			appendUint64List(r.column("negative_bucket_counts"), dp.Negative().BucketCounts().AsRaw())
+			r.endRow()
+		}
+	case pmetric.MetricTypeSummary:
+		for _, dp := range m.Summary().DataPoints().All() {
+			startRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+			r.column("count").(*array.Uint64Builder).Append(dp.Count())
+			r.column("sum").(*array.Float64Builder).Append(dp.Sum())
+			lb := r.column("quantile_values").(*array.ListBuilder)
+			lb.Append(true)
+			sb := lb.ValueBuilder().(*array.StructBuilder)
+			for _, qv := range dp.QuantileValues().All() {
+				sb.Append(true)
+				sb.FieldBuilder(0).(*array.Float64Builder).Append(qv.Quantile())
+				sb.FieldBuilder(1).(*array.Float64Builder).Append(qv.Value())
+			}
+			r.endRow()
+		}
+	}
+}
+
+func appendNumberValue(r *rowBuilder, dp pmetric.NumberDataPoint) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		r.column("value_int").(*array.Int64Builder).Append(dp.IntValue())
+	case pmetric.NumberDataPointValueTypeDouble:
+		r.column("value_double").(*array.Float64Builder).Append(dp.DoubleValue())
+	}
+}
+
+func appendUint64List(b array.Builder, values []uint64) {
+	lb := b.(*array.ListBuilder)
+	lb.Append(true)
+	lb.ValueBuilder().(*array.Uint64Builder).AppendValues(values, nil)
+}
+
+func appendFloat64List(b array.Builder, values []float64) {
+	lb := b.(*array.ListBuilder)
+	lb.Append(true)
+	lb.ValueBuilder().(*array.Float64Builder).AppendValues(values, nil)
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+var (
+	// attributesType holds attributes as a map of string values, non-string values
+	// are stored with their string representation.
+	attributesType = arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String)
+	timestampType  = &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}
+)
+
+// resourceFields are the leading fields of every schema, describing the resource
+// and the instrumentation scope of a row.
+var resourceFields = []arrow.Field{
+	{Name: "resource_attributes", Type: attributesType},
+	{Name: "resource_schema_url", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "scope_name", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "scope_version", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "scope_attributes", Type: attributesType},
+}
+
+func newSchema(fields ...arrow.Field) *arrow.Schema {
+	return arrow.NewSchema(append(append([]arrow.Field{}, resourceFields...), fields...), nil)
+}
+
+// rowBuilder appends rows to a record builder. Nullable columns that were not
+// set while building a row are filled with nulls by endRow.
+type rowBuilder struct {
+	b    *array.RecordBuilder
+	rows int
+}
+
+func (r *rowBuilder) column(name string) array.Builder {
+	return r.b.Field(r.b.Schema().FieldIndices(name)[0])
+}
+
+func (r *rowBuilder) appendResource(resource pcommon.Resource, resourceSchemaURL string, scope pcommon.InstrumentationScope) {
+	appendAttributes(r.column("resource_attributes"), resource.Attributes())
+	appendString(r.column("resource_schema_url"), resourceSchemaURL)
+	appendString(r.column("scope_name"), scope.Name())
+	appendString(r.column("scope_version"), scope.Version())
+	appendAttributes(r.column("scope_attributes"), scope.Attributes())
+}
+
+func (r *rowBuilder) endRow() {
+	r.rows++
+	for i := range r.b.Schema().NumFields() {
+		if f := r.b.Field(i); f.Len() < r.rows {
+			f.AppendNull()
+		}
+	}
+}
+
+// appendString appends s, or a null if s is empty.
+func appendString(b array.Builder, s string) {
+	if s == "" {
+		b.AppendNull()
+		return
+	}
+	b.(*array.StringBuilder).Append(s)
+}
+
+// appendTimestamp appends ts, or a null if ts is not set.
+func appendTimestamp(b array.Builder, ts pcommon.Timestamp) {
+	if ts == 0 {
+		b.AppendNull()
+		return
+	}
+	b.(*array.TimestampBuilder).Append(arrow.Timestamp(ts))
+}
+
+func appendAttributes(b array.Builder, attrs pcommon.Map) {
+	mb := b.(*array.MapBuilder)
+	mb.Append(true)
+	keys := mb.KeyBuilder().(*array.StringBuilder)
+	items := mb.ItemBuilder().(*array.StringBuilder)
+	for k, v := range attrs.All() {
+		keys.Append(k)
+		items.Append(v.AsString())
+	}
+}
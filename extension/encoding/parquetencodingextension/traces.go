@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	spanEventType = arrow.StructOf(
+		arrow.Field{Name: "time", Type: timestampType, Nullable: true},
+		arrow.Field{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "attributes", Type: attributesType},
+	)
+	spanLinkType = arrow.StructOf(
+		arrow.Field{Name: "trace_id", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "span_id", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "trace_state", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "attributes", Type: attributesType},
+	)
+
+	spanSchema = newSchema(
+		arrow.Field{Name: "trace_id", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "span_id", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "parent_span_id", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "trace_state", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "kind", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "start_time", Type: timestampType, Nullable: true},
+		arrow.Field{Name: "end_time", Type: timestampType, Nullable: true},
+		arrow.Field{Name: "duration_ns", Type: arrow.PrimitiveTypes.Int64},
+		arrow.Field{Name: "status_code", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "status_message", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "attributes", Type: attributesType},
+		arrow.Field{Name: "events", Type: arrow.ListOf(spanEventType)},
+		arrow.Field{Name: "links", Type: arrow.ListOf(spanLinkType)},
+	)
+)
+
+func appendSpans(b *array.RecordBuilder, traces ptrace.Traces) {
+	r := &rowBuilder{b: b}
+	for _, rs := range traces.ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			for _, span := range ss.Spans().All() {
+				r.appendResource(rs.Resource(), rs.SchemaUrl(), ss.Scope())
+				r.column("trace_id").(*array.StringBuilder).Append(span.TraceID().String())
+				r.column("span_id").(*array.StringBuilder).Append(span.SpanID().String())
+				appendString(r.column("parent_span_id"), span.ParentSpanID().String())
+				appendString(r.column("trace_state"), span.TraceState().AsRaw())
+				r.column("name").(*array.StringBuilder).Append(span.Name())
+				r.column("kind").(*array.StringBuilder).Append(span.Kind().String())
+				appendTimestamp(r.column("start_time"), span.StartTimestamp())
+				appendTimestamp(r.column("end_time"), span.EndTimestamp())
+				r.column("duration_ns").(*array.Int64Builder).Append(int64(span.EndTimestamp()) - int64(span.StartTimestamp()))
+				r.column("status_code").(*array.StringBuilder).Append(span.Status().Code().String())
+				appendString(r.column("status_message"), span.Status().Message())
+				appendAttributes(r.column("attributes"), span.Attributes())
+				appendSpanEvents(r.column("events").(*array.ListBuilder), span.Events())
+				appendSpanLinks(r.column("links").(*array.ListBuilder), span.Links())
+				r.endRow()
+			}
+		}
+	}
+}
+
+func appendSpanEvents(lb *array.ListBuilder, events ptrace.SpanEventSlice) {
+	lb.Append(true)
+	sb := lb.ValueBuilder().(*array.StructBuilder)
+	for _, event := range events.All() {
+		sb.Append(true)
+		appendTimestamp(sb.FieldBuilder(0), event.Timestamp())
+		appendString(sb.FieldBuilder(1), event.Name())
+		appendAttributes(sb.FieldBuilder(2), event.Attributes())
+	}
+}
+
+func appendSpanLinks(lb *array.ListBuilder, links ptrace.SpanLinkSlice) {
+	lb.Append(true)
+	sb := lb.ValueBuilder().(*array.StructBuilder)
+	for _, link := range links.All() {
+		sb.Append(true)
+		appendString(sb.FieldBuilder(0), link.TraceID().String())
+		appendString(sb.FieldBuilder(1), link.SpanID().String())
+		appendString(sb.FieldBuilder(2), link.TraceState().AsRaw())
+		appendAttributes(sb.FieldBuilder(3), link.Attributes())
+	}
+}
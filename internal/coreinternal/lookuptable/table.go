@@ -0,0 +1,319 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lookuptable loads tables of rows from CSV or JSON files, to look up the row
+// matching a key, and reloads them when their file changes.
+package lookuptable // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/lookuptable"
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// FormatCSV is the format of CSV files whose first record is the header naming the columns
+	FormatCSV = "csv"
+	// FormatJSON is the format of JSON files made of an object of rows or an array of rows
+	FormatJSON = "json"
+
+	// DefaultReloadInterval is the default minimum interval between two checks for changes of the file
+	DefaultReloadInterval = 10 * time.Second
+)
+
+// Config is the configuration of a lookup table.
+type Config struct {
+	// Path is the path of the file the table is loaded from.
+	Path string
+	// Format is the format of the file, either "csv" or "json". It is inferred
+	// from the extension of the file when empty.
+	Format string
+	// Key is the column matched against the looked up keys. It is required for CSV
+	// files and JSON arrays of objects, and ignored for JSON objects of rows keyed
+	// by their key.
+	Key string
+	// ReloadInterval is the minimum interval between two checks for changes of the
+	// file, on lookup. The table is never reloaded when it is not positive.
+	ReloadInterval time.Duration
+}
+
+// Validate checks the configuration of the table, and returns its format.
+func (c Config) Validate() (string, error) {
+	if c.Path == "" {
+		return "", errors.New("path is required")
+	}
+
+	format := c.Format
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(c.Path)) {
+		case ".csv":
+			format = FormatCSV
+		case ".json":
+			format = FormatJSON
+		default:
+			return "", fmt.Errorf("cannot infer the format of %q, format must be set to '%s' or '%s'", c.Path, FormatCSV, FormatJSON)
+		}
+	}
+
+	switch format {
+	case FormatCSV:
+		if c.Key == "" {
+			return "", errors.New("key is required for CSV tables")
+		}
+	case FormatJSON:
+	default:
+		return "", fmt.Errorf("invalid format %q, must be one of '%s' or '%s'", format, FormatCSV, FormatJSON)
+	}
+	return format, nil
+}
+
+// Table is a table of rows loaded from a file, keyed by the value of their key column.
+type Table struct {
+	path           string
+	format         string
+	key            string
+	reloadInterval time.Duration
+	logger         *zap.Logger
+
+	mu   sync.RWMutex
+	rows map[string]map[string]any
+
+	// lastCheck is the time the file was last checked for changes, in Unix nanoseconds
+	lastCheck atomic.Int64
+	// checkMu guards the state of the file the rows were loaded from
+	checkMu sync.Mutex
+	modTime time.Time
+	size    int64
+}
+
+// New loads a table from the file of the config.
+func New(cfg Config, logger *zap.Logger) (*Table, error) {
+	format, err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Table{
+		path:           cfg.Path,
+		format:         format,
+		key:            cfg.Key,
+		reloadInterval: cfg.ReloadInterval,
+		logger:         logger,
+	}
+
+	info, err := os.Stat(t.path)
+	if err != nil {
+		return nil, err
+	}
+	if t.rows, err = t.load(); err != nil {
+		return nil, err
+	}
+	t.lastCheck.Store(time.Now().UnixNano())
+	t.modTime = info.ModTime()
+	t.size = info.Size()
+	return t, nil
+}
+
+// Lookup returns the row whose key column equals the key, without its key column.
+// The table is reloaded first if its file changed since it was last checked, unless
+// another lookup is already checking it, in which case the previous rows are used.
+// The returned row is shared and must not be modified.
+func (t *Table) Lookup(key string) (map[string]any, bool) {
+	t.reloadIfChanged()
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	row, ok := t.rows[key]
+	return row, ok
+}
+
+// Len returns the number of rows of the table.
+func (t *Table) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.rows)
+}
+
+func (t *Table) reloadIfChanged() {
+	if t.reloadInterval <= 0 {
+		return
+	}
+
+	now := time.Now()
+	if now.Sub(time.Unix(0, t.lastCheck.Load())) < t.reloadInterval {
+		return
+	}
+	// Lookups don't wait for the lookup checking the file, they keep using the previous
+	// rows until it is loaded.
+	if !t.checkMu.TryLock() {
+		return
+	}
+	defer t.checkMu.Unlock()
+	if now.Sub(time.Unix(0, t.lastCheck.Load())) < t.reloadInterval {
+		// the file was checked in the meantime
+		return
+	}
+	t.lastCheck.Store(now.UnixNano())
+
+	info, err := os.Stat(t.path)
+	if err != nil {
+		t.logger.Warn("Failed to check lookup table for changes, keeping the previous rows", zap.String("path", t.path), zap.Error(err))
+		return
+	}
+	if info.ModTime().Equal(t.modTime) && info.Size() == t.size {
+		return
+	}
+	// the file is not loaded again until it changes, even if this load fails
+	t.modTime = info.ModTime()
+	t.size = info.Size()
+
+	rows, err := t.load()
+	if err != nil {
+		t.logger.Warn("Failed to reload lookup table, keeping the previous rows", zap.String("path", t.path), zap.Error(err))
+		return
+	}
+
+	t.mu.Lock()
+	t.rows = rows
+	t.mu.Unlock()
+	t.logger.Debug("Reloaded lookup table", zap.String("path", t.path), zap.Int("rows", len(rows)))
+}
+
+func (t *Table) load() (map[string]map[string]any, error) {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if t.format == FormatCSV {
+		return loadCSV(f, t.key)
+	}
+	return loadJSON(f, t.key)
+}
+
+// loadCSV loads the rows of a CSV file whose first record is the header naming the columns.
+func loadCSV(r io.Reader, key string) (map[string]map[string]any, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("missing CSV header")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+
+	keyIndex := -1
+	for i, column := range header {
+		if column == key {
+			keyIndex = i
+			break
+		}
+	}
+	if keyIndex < 0 {
+		return nil, fmt.Errorf("key %q is not a column of the CSV header", key)
+	}
+
+	rows := make(map[string]map[string]any)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read CSV record: %w", err)
+		}
+
+		row := make(map[string]any, len(header)-1)
+		for i, column := range header {
+			if i != keyIndex {
+				row[column] = record[i]
+			}
+		}
+		rows[record[keyIndex]] = row
+	}
+}
+
+// loadJSON loads the rows of a JSON file, either an object of rows keyed by their key,
+// or an array of rows whose key column is the key.
+func loadJSON(r io.Reader, key string) (map[string]map[string]any, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode JSON: %w", err)
+	}
+
+	rows := make(map[string]map[string]any)
+	switch doc := doc.(type) {
+	case map[string]any:
+		for k, v := range doc {
+			row, ok := v.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("row %q is not a JSON object", k)
+			}
+			rows[k] = normalize(row).(map[string]any)
+		}
+	case []any:
+		if key == "" {
+			return nil, errors.New("key is required for JSON arrays")
+		}
+		for i, v := range doc {
+			row, ok := v.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("row %d is not a JSON object", i)
+			}
+			var k string
+			switch value := row[key].(type) {
+			case string:
+				k = value
+			case json.Number:
+				k = value.String()
+			default:
+				return nil, fmt.Errorf("row %d has no string or number %q key", i, key)
+			}
+			delete(row, key)
+			rows[k] = normalize(row).(map[string]any)
+		}
+	default:
+		return nil, errors.New("JSON table must be an object or an array of objects")
+	}
+	return rows, nil
+}
+
+// normalize converts the JSON numbers of a value into integers when they are integers,
+// or floats otherwise.
+func normalize(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, item := range v {
+			v[k] = normalize(item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
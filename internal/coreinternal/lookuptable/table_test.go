@@ -0,0 +1,299 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lookuptable
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            Config
+		expectedFormat string
+		expectedErr    string
+	}{
+		{
+			name:           "csv extension",
+			cfg:            Config{Path: "codes.CSV", Key: "id"},
+			expectedFormat: FormatCSV,
+		},
+		{
+			name:           "json extension",
+			cfg:            Config{Path: "codes.json"},
+			expectedFormat: FormatJSON,
+		},
+		{
+			name:           "explicit format",
+			cfg:            Config{Path: "codes.txt", Format: FormatJSON, Key: "id"},
+			expectedFormat: FormatJSON,
+		},
+		{
+			name:        "missing path",
+			cfg:         Config{Key: "id"},
+			expectedErr: "path is required",
+		},
+		{
+			name:        "unknown extension",
+			cfg:         Config{Path: "codes.txt", Key: "id"},
+			expectedErr: `cannot infer the format of "codes.txt"`,
+		},
+		{
+			name:        "invalid format",
+			cfg:         Config{Path: "codes.csv", Format: "xml", Key: "id"},
+			expectedErr: `invalid format "xml"`,
+		},
+		{
+			name:        "csv without key",
+			cfg:         Config{Path: "codes.csv"},
+			expectedErr: "key is required for CSV tables",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := tt.cfg.Validate()
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedFormat, format)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		content     string
+		key         string
+		expected    map[string]map[string]any
+		expectedErr string
+	}{
+		{
+			name:    "csv",
+			file:    "codes.csv",
+			content: "id,message,severity\nE1,\"disk full, retrying\",error\nE2,timeout,warn\n",
+			key:     "id",
+			expected: map[string]map[string]any{
+				"E1": {"message": "disk full, retrying", "severity": "error"},
+				"E2": {"message": "timeout", "severity": "warn"},
+			},
+		},
+		{
+			name:     "csv without rows",
+			file:     "codes.csv",
+			content:  "id,message\n",
+			key:      "id",
+			expected: map[string]map[string]any{},
+		},
+		{
+			name:        "csv without header",
+			file:        "codes.csv",
+			content:     "",
+			key:         "id",
+			expectedErr: "missing CSV header",
+		},
+		{
+			name:        "csv missing key column",
+			file:        "codes.csv",
+			content:     "code,message\nE1,timeout\n",
+			key:         "id",
+			expectedErr: `key "id" is not a column of the CSV header`,
+		},
+		{
+			name:        "csv wrong number of fields",
+			file:        "codes.csv",
+			content:     "id,message\nE1,timeout,extra\n",
+			key:         "id",
+			expectedErr: "read CSV record",
+		},
+		{
+			name:    "json object",
+			file:    "tenants.json",
+			content: `{"t1": {"name": "acme", "tier": 2, "ratio": 0.5, "tags": ["a", 1]}, "t2": {"name": "globex"}}`,
+			expected: map[string]map[string]any{
+				"t1": {"name": "acme", "tier": int64(2), "ratio": 0.5, "tags": []any{"a", int64(1)}},
+				"t2": {"name": "globex"},
+			},
+		},
+		{
+			name:    "json array",
+			file:    "hosts.json",
+			content: `[{"id": 1, "name": "web-1"}, {"id": "db", "name": "db-1", "zone": {"region": "eu"}}]`,
+			key:     "id",
+			expected: map[string]map[string]any{
+				"1":  {"name": "web-1"},
+				"db": {"name": "db-1", "zone": map[string]any{"region": "eu"}},
+			},
+		},
+		{
+			name:        "json array without key",
+			file:        "hosts.json",
+			content:     `[{"id": 1, "name": "web-1"}]`,
+			expectedErr: "key is required for JSON arrays",
+		},
+		{
+			name:        "json array missing key",
+			file:        "hosts.json",
+			content:     `[{"name": "web-1"}]`,
+			key:         "id",
+			expectedErr: `row 0 has no string or number "id" key`,
+		},
+		{
+			name:        "json invalid row",
+			file:        "hosts.json",
+			content:     `{"web": "web-1"}`,
+			expectedErr: `row "web" is not a JSON object`,
+		},
+		{
+			name:        "json invalid table",
+			file:        "hosts.json",
+			content:     `"hosts"`,
+			expectedErr: "JSON table must be an object or an array of objects",
+		},
+		{
+			name:        "json syntax error",
+			file:        "hosts.json",
+			content:     `{"web":`,
+			expectedErr: "decode JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			writeFile(t, path, tt.content)
+
+			table, err := New(Config{Path: path, Key: tt.key}, zap.NewNop())
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, table.rows)
+			assert.Equal(t, len(tt.expected), table.Len())
+		})
+	}
+}
+
+func TestNewMissingFile(t *testing.T) {
+	_, err := New(Config{Path: filepath.Join(t.TempDir(), "missing.csv"), Key: "id"}, zap.NewNop())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLookup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "codes.csv")
+	writeFile(t, path, "id,message\nE1,timeout\n")
+
+	table, err := New(Config{Path: path, Key: "id"}, zap.NewNop())
+	require.NoError(t, err)
+
+	row, ok := table.Lookup("E1")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]any{"message": "timeout"}, row)
+
+	_, ok = table.Lookup("E2")
+	assert.False(t, ok)
+}
+
+func TestReload(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	path := filepath.Join(t.TempDir(), "codes.csv")
+	writeFile(t, path, "id,message\nE1,timeout\n")
+
+	table, err := New(Config{Path: path, Key: "id", ReloadInterval: time.Nanosecond}, zap.New(core))
+	require.NoError(t, err)
+
+	// the file changes
+	writeFile(t, path, "id,message\nE1,connection timeout\nE2,disk full\n")
+	row, ok := table.Lookup("E2")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]any{"message": "disk full"}, row)
+	row, ok = table.Lookup("E1")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]any{"message": "connection timeout"}, row)
+
+	// the file is modified with the same size
+	writeFile(t, path, "id,message\nE1,connection timeout\nE3,disk full\n")
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	_, ok = table.Lookup("E3")
+	assert.True(t, ok)
+
+	// the rows are kept when the file cannot be loaded
+	writeFile(t, path, "id,message\nE1\n")
+	_, ok = table.Lookup("E3")
+	assert.True(t, ok)
+	require.Equal(t, 1, logs.FilterMessage("Failed to reload lookup table, keeping the previous rows").Len())
+
+	// the failed load is not attempted again until the file changes
+	_, ok = table.Lookup("E3")
+	assert.True(t, ok)
+	require.Equal(t, 1, logs.Len())
+
+	// the rows are kept when the file is removed
+	require.NoError(t, os.Remove(path))
+	_, ok = table.Lookup("E3")
+	assert.True(t, ok)
+	require.Equal(t, 1, logs.FilterMessage("Failed to check lookup table for changes, keeping the previous rows").Len())
+}
+
+func TestReloadInProgress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "codes.csv")
+	writeFile(t, path, "id,message\nE1,timeout\n")
+
+	table, err := New(Config{Path: path, Key: "id", ReloadInterval: time.Nanosecond}, zap.NewNop())
+	require.NoError(t, err)
+
+	// another lookup is checking the file, the lookup doesn't wait for it
+	table.checkMu.Lock()
+	writeFile(t, path, "id,message\nE2,disk full\n")
+	_, ok := table.Lookup("E2")
+	assert.False(t, ok)
+	_, ok = table.Lookup("E1")
+	assert.True(t, ok, "the previous rows should be used while the file is checked")
+	table.checkMu.Unlock()
+
+	_, ok = table.Lookup("E2")
+	assert.True(t, ok)
+}
+
+func TestReloadInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "codes.csv")
+	writeFile(t, path, "id,message\nE1,timeout\n")
+
+	table, err := New(Config{Path: path, Key: "id", ReloadInterval: time.Hour}, zap.NewNop())
+	require.NoError(t, err)
+
+	writeFile(t, path, "id,message\nE2,disk full\n")
+	_, ok := table.Lookup("E2")
+	assert.False(t, ok, "the file should not be checked before the reload interval")
+}
+
+func TestReloadDisabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "codes.csv")
+	writeFile(t, path, "id,message\nE1,timeout\n")
+
+	table, err := New(Config{Path: path, Key: "id"}, zap.NewNop())
+	require.NoError(t, err)
+
+	writeFile(t, path, "id,message\nE2,disk full\n")
+	_, ok := table.Lookup("E2")
+	assert.False(t, ok)
+}
@@ -1286,6 +1286,34 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutInt("indexof", 2)
 			},
 		},
+		{
+			statement: `set(attributes["test"], Map(["a", "b"], v => ToUpperCase(v)))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("A")
+				s.AppendEmpty().SetStr("B")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Filter(attributes, (k, v) => HasPrefix(k, "http.") and v != "get"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("http.path", "/health")
+				m.PutStr("http.url", "http://localhost/health")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Any(attributes["things"], thing => thing["name"] == "bar"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutBool("test", true)
+			},
+		},
+		{
+			statement: `set(attributes["test"], All(attributes["things"], thing => IsString(thing["name"]) and thing["name"] != attributes["foo"]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutBool("test", false)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -231,7 +231,7 @@ func (p *Parser[K]) newKeys(keys []key) ([]Key[K], error) {
 		var getter Getter[K]
 		if keys[i].Expression != nil {
 			if keys[i].Expression.Path != nil {
-				g, err := p.buildGetSetterFromPath(keys[i].Expression.Path)
+				g, err := p.newPathGetter(keys[i].Expression.Path)
 				if err != nil {
 					return nil, err
 				}
@@ -404,7 +404,16 @@ func (p *Parser[K]) buildArgs(ed editor, argsVal reflect.Value) error {
 			fieldType = manager.get().Type()
 		}
 
+		if arg.Lambda != nil && !strings.HasPrefix(fieldType.Name(), "Lambda") {
+			return fmt.Errorf("invalid argument at position %v: lambdas are not supported by this parameter", i)
+		}
+
 		switch {
+		case strings.HasPrefix(fieldType.Name(), "Lambda"):
+			if arg.Lambda == nil {
+				return fmt.Errorf("invalid argument at position %v: must be a lambda", i)
+			}
+			val, err = p.newLambda(arg.Lambda)
 		case strings.HasPrefix(fieldType.Name(), "FunctionGetter"):
 			var name string
 			switch {
@@ -536,6 +545,9 @@ func (p *Parser[K]) buildSliceArg(argVal value, argType reflect.Type) (any, erro
 }
 
 func (p *Parser[K]) buildGetSetterFromPath(path *path) (GetSetter[K], error) {
+	if _, ok := p.lambdaParameterGetter(path); ok {
+		return nil, fmt.Errorf("lambda parameter %q cannot be set", buildOriginalText(path))
+	}
 	np, err := p.newPath(path)
 	if err != nil {
 		return nil, err
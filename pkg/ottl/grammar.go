@@ -6,6 +6,7 @@ package ottl // import "github.com/open-telemetry/opentelemetry-collector-contri
 import (
 	"encoding/hex"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/alecthomas/participle/v2/lexer"
@@ -223,14 +224,43 @@ func (c *converter) accept(v grammarVisitor) {
 
 type argument struct {
 	Name         string  `parser:"(@(Lowercase(Uppercase | Lowercase)*) Equal)?"`
-	Value        value   `parser:"( @@"`
+	Lambda       *lambda `parser:"( @@"`
+	Value        value   `parser:"| @@"`
 	FunctionName *string `parser:"| @(Uppercase(Uppercase | Lowercase)*) )"`
 }
 
 func (a *argument) accept(v grammarVisitor) {
+	if a.Lambda != nil {
+		a.Lambda.accept(v)
+		return
+	}
 	a.Value.accept(v)
 }
 
+// lambda represents an anonymous expression passed as a function argument, such as
+// `value => ToLowerCase(value)` or `(key, value) => HasPrefix(key, "http.")`.
+type lambda struct {
+	Parameters []string   `parser:"( @Lowercase | '(' @Lowercase ( ',' @Lowercase )? ')' ) Arrow"`
+	Body       lambdaBody `parser:"@@"`
+}
+
+func (l *lambda) accept(v grammarVisitor) {
+	scoped := &lambdaScopeVisitor{grammarVisitor: v, parameters: l.Parameters}
+	if l.Body.Value != nil {
+		l.Body.Value.accept(scoped)
+	}
+	if l.Body.Condition != nil {
+		l.Body.Condition.accept(scoped)
+	}
+}
+
+// lambdaBody is the expression of a lambda. Values are only matched when they are
+// not followed by a comparison or a boolean operator, otherwise the body is a condition.
+type lambdaBody struct {
+	Value     *value             `parser:"( @@ (?= ',' | ')')"`
+	Condition *booleanExpression `parser:"| @@ )"`
+}
+
 // value represents a part of a parsed statement which is resolved to a value of some sort. This can be a telemetry path
 // mathExpression, function call, or literal.
 type value struct {
@@ -283,6 +313,11 @@ func (p *path) accept(v grammarVisitor) {
 	}
 }
 
+// isLambdaParameter returns true if the path refers to one of the given lambda parameters.
+func (p *path) isLambdaParameter(parameters []string) bool {
+	return p.Context == "" && len(p.Fields) == 1 && slices.Contains(parameters, p.Fields[0].Name)
+}
+
 // field is an item within a path.
 type field struct {
 	Name string `parser:"@Lowercase"`
@@ -511,6 +546,7 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `OpAddSub`, Pattern: `\+|\-`},
 		{Name: `OpMultDiv`, Pattern: `\/|\*`},
 		{Name: `Boolean`, Pattern: `\b(true|false)\b`},
+		{Name: `Arrow`, Pattern: `=>`},
 		{Name: `Equal`, Pattern: `=`},
 		{Name: `LParen`, Pattern: `\(`},
 		{Name: `RParen`, Pattern: `\)`},
@@ -594,3 +630,17 @@ func (g *grammarCustomErrorsVisitor) visitMathExprLiteral(v *mathExprLiteral) {
 		g.add(fmt.Errorf("converter names must start with an uppercase letter but got '%v'", v.Editor.Function))
 	}
 }
+
+// lambdaScopeVisitor hides the paths referring to the parameters of a lambda from the
+// wrapped visitor, as they aren't telemetry paths.
+type lambdaScopeVisitor struct {
+	grammarVisitor
+	parameters []string
+}
+
+func (l *lambdaScopeVisitor) visitPath(v *path) {
+	if v.isLambdaParameter(l.parameters) {
+		return
+	}
+	l.grammarVisitor.visitPath(v)
+}
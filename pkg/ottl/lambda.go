@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// Lambda is a function argument representing an anonymous expression, such as
+// `value => ToLowerCase(value)`, which functions evaluate for each item of a collection.
+// Lambdas declare either one parameter bound to the item's value, or two parameters
+// bound to the item's key (or index) and value, as in `(key, value) => HasPrefix(key, "http.")`.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type Lambda[K any] interface {
+	// Invoke evaluates the lambda expression with its parameters bound to the given key and value.
+	Invoke(ctx context.Context, tCtx K, key, value any) (any, error)
+}
+
+// StandardLambda is a basic implementation of Lambda.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type StandardLambda[K any] struct {
+	Invoker func(ctx context.Context, tCtx K, key, value any) (any, error)
+}
+
+// Invoke evaluates the lambda expression with its parameters bound to the given key and value.
+func (l StandardLambda[K]) Invoke(ctx context.Context, tCtx K, key, value any) (any, error) {
+	return l.Invoker(ctx, tCtx, key, value)
+}
+
+// lambdaParameter is the context key under which the values of a lambda parameter are
+// stored while its lambda is invoked. Each parsed lambda has its own keys, so nested
+// lambdas can refer to the parameters of their enclosing lambdas.
+type lambdaParameter struct {
+	name string
+}
+
+func (p *Parser[K]) newLambda(l *lambda) (Lambda[K], error) {
+	scoped := *p
+	scoped.lambdaParameters = maps.Clone(p.lambdaParameters)
+	if scoped.lambdaParameters == nil {
+		scoped.lambdaParameters = map[string]*lambdaParameter{}
+	}
+
+	parameters := make([]*lambdaParameter, len(l.Parameters))
+	for i, name := range l.Parameters {
+		if i > 0 && l.Parameters[0] == name {
+			return nil, fmt.Errorf("duplicate lambda parameter %q", name)
+		}
+		parameters[i] = &lambdaParameter{name: name}
+		scoped.lambdaParameters[name] = parameters[i]
+	}
+
+	var body Getter[K]
+	switch {
+	case l.Body.Value != nil:
+		getter, err := scoped.newGetter(*l.Body.Value)
+		if err != nil {
+			return nil, err
+		}
+		body = getter
+	case l.Body.Condition != nil:
+		boolExpr, err := scoped.newBoolExpr(l.Body.Condition)
+		if err != nil {
+			return nil, err
+		}
+		body = &exprGetter[K]{expr: Expr[K]{exprFunc: func(ctx context.Context, tCtx K) (any, error) {
+			return boolExpr.Eval(ctx, tCtx)
+		}}}
+	default:
+		// In practice, can't happen since the DSL grammar guarantees one is set
+		return nil, errors.New("no lambda body set. This is a bug in the OpenTelemetry Transformation Language")
+	}
+
+	return StandardLambda[K]{Invoker: func(ctx context.Context, tCtx K, key, value any) (any, error) {
+		if len(parameters) == 1 {
+			ctx = context.WithValue(ctx, parameters[0], value)
+		} else {
+			ctx = context.WithValue(ctx, parameters[0], key)
+			ctx = context.WithValue(ctx, parameters[1], value)
+		}
+		return body.Get(ctx, tCtx)
+	}}, nil
+}
+
+// lambdaParameterGetter returns a Getter for the path if it refers to a parameter of an
+// enclosing lambda. The parameter's value can be indexed like the result of a converter.
+func (p *Parser[K]) lambdaParameterGetter(path *path) (Getter[K], bool) {
+	if path.Context != "" || len(path.Fields) != 1 {
+		return nil, false
+	}
+	parameter, ok := p.lambdaParameters[path.Fields[0].Name]
+	if !ok {
+		return nil, false
+	}
+	return &exprGetter[K]{
+		expr: Expr[K]{exprFunc: func(ctx context.Context, _ K) (any, error) {
+			return ctx.Value(parameter), nil
+		}},
+		keys: path.Fields[0].Keys,
+	}, true
+}
+
+// newPathGetter returns a Getter for the path, which can either be a telemetry path or
+// a lambda parameter.
+func (p *Parser[K]) newPathGetter(path *path) (Getter[K], error) {
+	if getter, ok := p.lambdaParameterGetter(path); ok {
+		return getter, nil
+	}
+	return p.buildGetSetterFromPath(path)
+}
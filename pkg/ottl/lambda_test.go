@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type lambdaApplyArguments struct {
+	Target Getter[any]
+	Fn     Lambda[any]
+}
+
+type lambdaPairArguments struct {
+	Key   Getter[any]
+	Value Getter[any]
+}
+
+type lambdaSetArguments struct {
+	Target Setter[any]
+	Value  Getter[any]
+}
+
+func lambdaTestFunctions() map[string]Factory[any] {
+	return CreateFactoryMap(
+		createFactory("Apply", &lambdaApplyArguments{}, func(target Getter[any], fn Lambda[any]) (ExprFunc[any], error) {
+			return func(ctx context.Context, tCtx any) (any, error) {
+				val, err := target.Get(ctx, tCtx)
+				if err != nil {
+					return nil, err
+				}
+				items, ok := val.([]any)
+				if !ok {
+					return nil, fmt.Errorf("unexpected target %T", val)
+				}
+				result := make([]any, len(items))
+				for i, item := range items {
+					if result[i], err = fn.Invoke(ctx, tCtx, int64(i), item); err != nil {
+						return nil, err
+					}
+				}
+				return result, nil
+			}, nil
+		}),
+		createFactory("Pair", &lambdaPairArguments{}, func(key, value Getter[any]) (ExprFunc[any], error) {
+			return func(ctx context.Context, tCtx any) (any, error) {
+				k, err := key.Get(ctx, tCtx)
+				if err != nil {
+					return nil, err
+				}
+				v, err := value.Get(ctx, tCtx)
+				if err != nil {
+					return nil, err
+				}
+				return fmt.Sprintf("%v=%v", k, v), nil
+			}, nil
+		}),
+		createFactory("Set", &lambdaSetArguments{}, func(Setter[any], Getter[any]) (ExprFunc[any], error) {
+			return func(context.Context, any) (any, error) {
+				return nil, nil
+			}, nil
+		}),
+	)
+}
+
+func lambdaTestParsePath(p Path[any]) (GetSetter[any], error) {
+	return &StandardGetSetter[any]{
+		Getter: func(_ context.Context, tCtx any) (any, error) {
+			return tCtx.(map[string]any)[p.Name()], nil
+		},
+		Setter: func(context.Context, any, any) error {
+			return nil
+		},
+	}, nil
+}
+
+func Test_Lambda(t *testing.T) {
+	tCtx := map[string]any{
+		"list": []any{"a", "b"},
+		"maps": []any{map[string]any{"key": "x"}, map[string]any{"key": "y"}},
+		"name": "b",
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   any
+	}{
+		{
+			name:       "value parameter",
+			expression: `Apply(list, v => v)`,
+			expected:   []any{"a", "b"},
+		},
+		{
+			name:       "key and value parameters",
+			expression: `Apply(list, (i, v) => Pair(i, v))`,
+			expected:   []any{"0=a", "1=b"},
+		},
+		{
+			name:       "condition body",
+			expression: `Apply(list, v => v == name or v == "c")`,
+			expected:   []any{false, true},
+		},
+		{
+			name:       "parenthesized condition body",
+			expression: `Apply(list, v => (v != name))`,
+			expected:   []any{true, false},
+		},
+		{
+			name:       "indexed parameter",
+			expression: `Apply(maps, m => m["key"])`,
+			expected:   []any{"x", "y"},
+		},
+		{
+			name:       "nested lambdas",
+			expression: `Apply(list, v => Apply(list, w => Pair(v, w)))`,
+			expected:   []any{[]any{"a=a", "a=b"}, []any{"b=a", "b=b"}},
+		},
+		{
+			name:       "shadowed parameter",
+			expression: `Apply(list, v => Apply(maps, v => v["key"]))`,
+			expected:   []any{[]any{"x", "y"}, []any{"x", "y"}},
+		},
+		{
+			name:       "named argument",
+			expression: `Apply(target = list, fn = v => Pair(v, name))`,
+			expected:   []any{"a=b", "b=b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewParser(lambdaTestFunctions(), lambdaTestParsePath, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			expr, err := p.ParseValueExpression(tt.expression)
+			require.NoError(t, err)
+			result, err := expr.Eval(t.Context(), tCtx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Lambda_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		expected  string
+	}{
+		{
+			name:      "lambda expected",
+			statement: `Set(name, Apply(list, list))`,
+			expected:  "invalid argument at position 1: must be a lambda",
+		},
+		{
+			name:      "lambda not supported",
+			statement: `Set(name, Pair(v => v, list))`,
+			expected:  "invalid argument at position 0: lambdas are not supported by this parameter",
+		},
+		{
+			name:      "duplicate parameter",
+			statement: `Set(name, Apply(list, (v, v) => v))`,
+			expected:  `duplicate lambda parameter "v"`,
+		},
+		{
+			name:      "parameter set",
+			statement: `Set(name, Apply(list, v => Set(v, "a")))`,
+			expected:  `lambda parameter "v" cannot be set`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewParser(lambdaTestFunctions(), lambdaTestParsePath, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			_, err = p.ParseValueExpression(tt.statement)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func Test_Lambda_PrependContextToPaths(t *testing.T) {
+	p, err := NewParser(
+		lambdaTestFunctions(),
+		lambdaTestParsePath,
+		componenttest.NewNopTelemetrySettings(),
+		WithPathContextNames[any]([]string{"foo"}),
+	)
+	require.NoError(t, err)
+
+	result, err := p.prependContextToValueExpressionPaths("foo", `Apply(list, (i, v) => Apply(v, w => Pair(w, name)))`)
+	require.NoError(t, err)
+	assert.Equal(t, `Apply(foo.list, (i, v) => Apply(v, w => Pair(w, foo.name)))`, result)
+}
@@ -157,6 +157,17 @@ func Test_lexer(t *testing.T) {
 			{"Int", "1"},
 			{"Punct", "]"},
 		}},
+		{"Lambda", `(k, v) => v == k`, false, []result{
+			{"LParen", "("},
+			{"Lowercase", "k"},
+			{"Punct", ","},
+			{"Lowercase", "v"},
+			{"RParen", ")"},
+			{"Arrow", "=>"},
+			{"Lowercase", "v"},
+			{"OpComparison", "=="},
+			{"Lowercase", "k"},
+		}},
 	}
 
 	for _, tt := range tests {
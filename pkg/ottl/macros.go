@@ -0,0 +1,553 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MacroParameterType is the kind of argument accepted by a Macro parameter.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type MacroParameterType string
+
+const (
+	// MacroParameterValue accepts any OTTL value expression. It is the default parameter type.
+	MacroParameterValue MacroParameterType = "value"
+	// MacroParameterPath accepts a path, which can be used as the target of editors.
+	MacroParameterPath MacroParameterType = "path"
+	// MacroParameterCondition accepts an OTTL condition, which can be used as a boolean expression.
+	MacroParameterCondition MacroParameterType = "condition"
+	// MacroParameterString accepts a string literal.
+	MacroParameterString MacroParameterType = "string"
+	// MacroParameterInt accepts an int literal.
+	MacroParameterInt MacroParameterType = "int"
+	// MacroParameterFloat accepts a float or an int literal.
+	MacroParameterFloat MacroParameterType = "float"
+	// MacroParameterBool accepts a boolean literal.
+	MacroParameterBool MacroParameterType = "bool"
+)
+
+// MacroParameter is a named parameter of a Macro.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type MacroParameter struct {
+	// Name is the name used to reference the parameter in the macro body.
+	Name string `mapstructure:"name"`
+	// Type is the kind of argument accepted by the parameter, it defaults to MacroParameterValue.
+	Type MacroParameterType `mapstructure:"type"`
+}
+
+// Macro is a named and parameterized OTTL template that can be invoked like a function.
+//
+// A statement macro defines Statements and is invoked as an editor, for example
+// `normalize_http(attributes["url"]) where kind == SPAN_KIND_SERVER`. The invocation is replaced
+// by the macro statements, the where clause of the invocation being added to each of them.
+//
+// A condition macro defines a Condition and is invoked as a converter within conditions,
+// for example `IsHealthCheck(attributes["http.route"])`. The invocation is replaced by the
+// parenthesized macro condition.
+//
+// The parameters are referenced in the macro body as context-less paths made of their name,
+// and are replaced by the invocation arguments.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type Macro struct {
+	// Name is the name used to invoke the macro. Statement macros names must start with a lowercase
+	// letter and condition macros names with an uppercase letter.
+	Name string `mapstructure:"name"`
+	// Parameters are the parameters of the macro, all of them are required.
+	Parameters []MacroParameter `mapstructure:"parameters"`
+	// Statements are the OTTL statements the macro expands to.
+	Statements []string `mapstructure:"statements"`
+	// Condition is the OTTL condition the macro expands to.
+	Condition string `mapstructure:"condition"`
+}
+
+var (
+	statementMacroNameRegexp = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+	conditionMacroNameRegexp = regexp.MustCompile(`^[A-Z][a-zA-Z0-9_]*$`)
+	macroParameterNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	macroReservedWords       = []string{"where", "nil", "not", "and", "or", "true", "false"}
+	macroLexer               = sync.OnceValue(buildLexer)
+)
+
+func (m *Macro) validate() error {
+	// The conditions parameters aren't valid boolean expressions on their own, so they're
+	// replaced by a boolean literal to check the macro body syntax.
+	placeholders := map[string]string{}
+	for i, p := range m.Parameters {
+		if !macroParameterNameRegexp.MatchString(p.Name) || slices.Contains(macroReservedWords, p.Name) {
+			return fmt.Errorf("invalid parameter name %q, it must start with a lowercase letter and contain only lowercase letters, digits and underscores", p.Name)
+		}
+		if slices.ContainsFunc(m.Parameters[:i], func(other MacroParameter) bool { return other.Name == p.Name }) {
+			return fmt.Errorf("duplicate parameter %q", p.Name)
+		}
+		switch p.Type {
+		case MacroParameterCondition:
+			placeholders[p.Name] = "true"
+		case "", MacroParameterValue, MacroParameterPath, MacroParameterString, MacroParameterInt, MacroParameterFloat, MacroParameterBool:
+		default:
+			return fmt.Errorf("parameter %q has an unknown type %q", p.Name, p.Type)
+		}
+	}
+
+	switch {
+	case len(m.Statements) > 0 && m.Condition != "":
+		return errors.New("statements and condition are mutually exclusive")
+	case len(m.Statements) > 0:
+		if !statementMacroNameRegexp.MatchString(m.Name) || slices.Contains(macroReservedWords, m.Name) {
+			return errors.New("statement macro names must start with a lowercase letter and contain only letters, digits and underscores")
+		}
+		for i, statement := range m.Statements {
+			checked, err := substituteMacroParameters(statement, placeholders)
+			if err == nil {
+				_, err = parseStatement(checked)
+			}
+			if err != nil {
+				return fmt.Errorf("invalid statement %d %q: %w", i, statement, err)
+			}
+		}
+	case m.Condition != "":
+		if !conditionMacroNameRegexp.MatchString(m.Name) {
+			return errors.New("condition macro names must start with an uppercase letter and contain only letters, digits and underscores")
+		}
+		checked, err := substituteMacroParameters(m.Condition, placeholders)
+		if err == nil {
+			_, err = parseCondition(checked)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid condition %q: %w", m.Condition, err)
+		}
+	default:
+		return errors.New("either statements or a condition must be defined")
+	}
+	return nil
+}
+
+// checkArgument verifies at parse time that the argument is accepted by the parameter.
+func (p *MacroParameter) checkArgument(arg string) error {
+	if p.Type == MacroParameterCondition {
+		_, err := parseCondition(arg)
+		return err
+	}
+	val, err := parseValueExpression(arg)
+	if err != nil {
+		return err
+	}
+	var ok bool
+	switch p.Type {
+	case MacroParameterPath:
+		ok = val.Literal != nil && val.Literal.Path != nil
+	case MacroParameterString:
+		ok = val.String != nil
+	case MacroParameterInt:
+		ok = val.Literal != nil && val.Literal.Int != nil
+	case MacroParameterFloat:
+		ok = val.Literal != nil && (val.Literal.Float != nil || val.Literal.Int != nil)
+	case MacroParameterBool:
+		ok = val.Bool != nil
+	default:
+		ok = true
+	}
+	if !ok {
+		return fmt.Errorf("expected a %s but got %q", p.Type, arg)
+	}
+	return nil
+}
+
+// macroExpander replaces the macros invocations of OTTL statements, conditions and value
+// expressions by the macros bodies.
+type macroExpander struct {
+	macros map[string]*Macro
+}
+
+func newMacroExpander(macros []Macro) (*macroExpander, error) {
+	macros = slices.Clone(macros)
+	e := &macroExpander{macros: make(map[string]*Macro, len(macros))}
+	var errs []error
+	for i := range macros {
+		m := &macros[i]
+		if _, ok := e.macros[m.Name]; ok {
+			errs = append(errs, fmt.Errorf("duplicate macro %q", m.Name))
+			continue
+		}
+		if err := m.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid macro %q: %w", m.Name, err))
+			continue
+		}
+		e.macros[m.Name] = m
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return e, nil
+}
+
+// expandStatement returns the statements resulting of the expansion of the given statement.
+func (e *macroExpander) expandStatement(statement string) ([]string, error) {
+	return e.statement(statement, nil)
+}
+
+// expandExpression returns the expansion of the given condition or value expression.
+func (e *macroExpander) expandExpression(expression string) ([]string, error) {
+	expanded, err := e.conditions(expression, nil)
+	if err != nil {
+		return nil, err
+	}
+	return []string{expanded}, nil
+}
+
+// expandAll returns the expansions of all the given OTTL strings.
+func (e *macroExpander) expandAll(values []string, expand func(*macroExpander, string) ([]string, error)) ([]string, error) {
+	if e == nil {
+		return values, nil
+	}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		expanded, err := expand(e, value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to expand OTTL macros in %q: %w", value, err)
+		}
+		result = append(result, expanded...)
+	}
+	return result, nil
+}
+
+// statement expands the statement macro invoked by the statement, if any, and the condition
+// macros invoked within the statement. The stack holds the names of the macros being expanded.
+func (e *macroExpander) statement(statement string, stack []string) ([]string, error) {
+	tokens, err := tokenizeMacroInput(statement)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) < 2 || !tokens[0].ident || tokens[1].value != "(" {
+		return e.single(statement, stack)
+	}
+	m, ok := e.macros[tokens[0].value]
+	if !ok || len(m.Statements) == 0 {
+		return e.single(statement, stack)
+	}
+
+	args, closing, err := macroArguments(statement, tokens, 1)
+	if err != nil {
+		return nil, fmt.Errorf("macro %q: %w", m.Name, err)
+	}
+	var where string
+	if rest := tokens[closing+1:]; len(rest) > 0 {
+		if rest[0].value != "where" || len(rest) == 1 {
+			return nil, fmt.Errorf("macro %q: unexpected %q after the invocation", m.Name, statement[rest[0].offset:])
+		}
+		if where, err = e.conditions(statement[rest[1].offset:], stack); err != nil {
+			return nil, err
+		}
+	}
+
+	params, err := e.bind(m, args, stack)
+	if err != nil {
+		return nil, err
+	}
+	stack = append(slices.Clone(stack), m.Name)
+	var result []string
+	for _, body := range m.Statements {
+		substituted, err := substituteMacroParameters(body, params)
+		if err != nil {
+			return nil, fmt.Errorf("macro %q: %w", m.Name, err)
+		}
+		expanded, err := e.statement(substituted, stack)
+		if err != nil {
+			return nil, fmt.Errorf("macro %q: %w", m.Name, err)
+		}
+		for _, s := range expanded {
+			if where != "" {
+				if s, err = addWhereClause(s, where); err != nil {
+					return nil, fmt.Errorf("macro %q: %w", m.Name, err)
+				}
+			}
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (e *macroExpander) single(statement string, stack []string) ([]string, error) {
+	expanded, err := e.conditions(statement, stack)
+	if err != nil {
+		return nil, err
+	}
+	return []string{expanded}, nil
+}
+
+// conditions expands the condition macros invoked within the given OTTL string.
+func (e *macroExpander) conditions(ottl string, stack []string) (string, error) {
+	tokens, err := tokenizeMacroInput(ottl)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	left := 0
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if !tok.ident || i+1 >= len(tokens) || tokens[i+1].value != "(" || (i > 0 && tokens[i-1].value == ".") {
+			continue
+		}
+		m, ok := e.macros[tok.value]
+		if !ok {
+			continue
+		}
+		if m.Condition == "" {
+			return "", fmt.Errorf("statement macro %q can only be invoked as a statement", m.Name)
+		}
+		args, closing, err := macroArguments(ottl, tokens, i+1)
+		if err != nil {
+			return "", fmt.Errorf("macro %q: %w", m.Name, err)
+		}
+		params, err := e.bind(m, args, stack)
+		if err != nil {
+			return "", err
+		}
+		substituted, err := substituteMacroParameters(m.Condition, params)
+		if err != nil {
+			return "", fmt.Errorf("macro %q: %w", m.Name, err)
+		}
+		expanded, err := e.conditions(substituted, append(slices.Clone(stack), m.Name))
+		if err != nil {
+			return "", fmt.Errorf("macro %q: %w", m.Name, err)
+		}
+		sb.WriteString(ottl[left:tok.offset])
+		sb.WriteString("(")
+		sb.WriteString(expanded)
+		sb.WriteString(")")
+		left = tokens[closing].end()
+		i = closing
+	}
+	sb.WriteString(ottl[left:])
+	return sb.String(), nil
+}
+
+// bind checks the invocation arguments of the macro and returns the text replacing each of
+// its parameters. The condition macros invoked within the arguments are expanded.
+func (e *macroExpander) bind(m *Macro, args []string, stack []string) (map[string]string, error) {
+	if slices.Contains(stack, m.Name) {
+		return nil, fmt.Errorf("macro %q is invoked recursively", m.Name)
+	}
+	if len(args) != len(m.Parameters) {
+		return nil, fmt.Errorf("macro %q expects %d arguments but got %d", m.Name, len(m.Parameters), len(args))
+	}
+	params := make(map[string]string, len(args))
+	for i, arg := range args {
+		p := &m.Parameters[i]
+		expanded, err := e.conditions(arg, stack)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.checkArgument(expanded); err != nil {
+			return nil, fmt.Errorf("macro %q: invalid argument for parameter %q: %w", m.Name, p.Name, err)
+		}
+		if p.Type == MacroParameterCondition {
+			expanded = "(" + expanded + ")"
+		}
+		params[p.Name] = expanded
+	}
+	return params, nil
+}
+
+// macroToken is a lexer token, adjacent uppercase and lowercase tokens are merged into a
+// single identifier token.
+type macroToken struct {
+	value  string
+	offset int
+	ident  bool
+}
+
+func (t macroToken) end() int {
+	return t.offset + len(t.value)
+}
+
+func tokenizeMacroInput(ottl string) ([]macroToken, error) {
+	def := macroLexer()
+	symbols := def.Symbols()
+	lex, err := def.LexString("", ottl)
+	if err != nil {
+		return nil, err
+	}
+	var tokens []macroToken
+	for {
+		tok, err := lex.Next()
+		if err != nil {
+			return nil, err
+		}
+		if tok.EOF() {
+			return tokens, nil
+		}
+		ident := tok.Type == symbols["Lowercase"] || tok.Type == symbols["Uppercase"]
+		if ident && len(tokens) > 0 {
+			if last := &tokens[len(tokens)-1]; last.ident && last.end() == tok.Pos.Offset {
+				last.value += tok.Value
+				continue
+			}
+		}
+		tokens = append(tokens, macroToken{value: tok.Value, offset: tok.Pos.Offset, ident: ident})
+	}
+}
+
+// macroArguments returns the arguments of the invocation whose opening parenthesis is the
+// token at the given index, and the index of the closing parenthesis.
+func macroArguments(ottl string, tokens []macroToken, opening int) ([]string, int, error) {
+	var args []string
+	depth := 0
+	start := opening + 1
+	for i := opening; i < len(tokens); i++ {
+		switch tokens[i].value {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if start == i && len(args) == 0 {
+				return nil, i, nil
+			}
+			arg, err := macroArgument(ottl, tokens, start, i)
+			if err != nil {
+				return nil, 0, err
+			}
+			return append(args, arg), i, nil
+		case ",":
+			if depth != 1 {
+				continue
+			}
+			arg, err := macroArgument(ottl, tokens, start, i)
+			if err != nil {
+				return nil, 0, err
+			}
+			args = append(args, arg)
+			start = i + 1
+		}
+	}
+	return nil, 0, errors.New("missing closing parenthesis")
+}
+
+func macroArgument(ottl string, tokens []macroToken, start, end int) (string, error) {
+	if start == end {
+		return "", errors.New("empty argument")
+	}
+	if tokens[start].ident && start+1 < end && tokens[start+1].value == "=" {
+		return "", fmt.Errorf("named argument %q is not supported", tokens[start].value)
+	}
+	return strings.TrimSpace(ottl[tokens[start].offset:tokens[end].offset]), nil
+}
+
+// substituteMacroParameters replaces the parameters references of the macro body, which are
+// the context-less paths made of a single parameter name.
+func substituteMacroParameters(body string, params map[string]string) (string, error) {
+	tokens, err := tokenizeMacroInput(body)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	left := 0
+	for i, tok := range tokens {
+		arg, ok := params[tok.value]
+		if !ok || !tok.ident || (i > 0 && tokens[i-1].value == ".") {
+			continue
+		}
+		if i+1 < len(tokens) && (tokens[i+1].value == "(" || tokens[i+1].value == "=") {
+			continue
+		}
+		sb.WriteString(body[left:tok.offset])
+		sb.WriteString(arg)
+		left = tok.end()
+	}
+	sb.WriteString(body[left:])
+	return sb.String(), nil
+}
+
+// addWhereClause adds the condition to the where clause of the statement.
+func addWhereClause(statement, condition string) (string, error) {
+	tokens, err := tokenizeMacroInput(statement)
+	if err != nil {
+		return "", err
+	}
+	if len(tokens) < 2 || tokens[1].value != "(" {
+		return "", fmt.Errorf("invalid statement %q", statement)
+	}
+	_, closing, err := macroArguments(statement, tokens, 1)
+	if err != nil {
+		return "", err
+	}
+	if closing+2 < len(tokens) && tokens[closing+1].value == "where" {
+		return fmt.Sprintf("%s where (%s) and (%s)", statement[:tokens[closing].end()], condition, statement[tokens[closing+2].offset:]), nil
+	}
+	return fmt.Sprintf("%s where %s", strings.TrimSpace(statement), condition), nil
+}
+
+// parseWithMacros expands the macros invoked by the given OTTL strings and parses the
+// expansions, the errors refer to the OTTL strings the expansions come from. When set, the
+// prepend function is applied to the expansions, so the paths of the macros bodies get the
+// context of the parser too, and the modifications are reported to the log function. The
+// parsed statements keep the index of the OTTL string they were expanded from.
+func parseWithMacros[T any](
+	e *macroExpander,
+	values []string,
+	expand func(*macroExpander, string) ([]string, error),
+	prepend func(string) (string, error),
+	log func(original, modified []string),
+	parse func([]string) ([]*T, error),
+) ([]*T, error) {
+	if e == nil {
+		return parse(values)
+	}
+	var parsed []*T
+	var errs []error
+	var original, modified []string
+	for i, value := range values {
+		expanded, err := expand(e, value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to expand OTTL macros in %q: %w", value, err))
+			continue
+		}
+		invoked := len(expanded) != 1 || expanded[0] != value
+		if prepend != nil {
+			prepended := make([]string, len(expanded))
+			for i := 0; err == nil && i < len(expanded); i++ {
+				prepended[i], err = prepend(expanded[i])
+			}
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			original = append(original, expanded...)
+			modified = append(modified, prepended...)
+			expanded = prepended
+		}
+		p, err := parse(expanded)
+		if err != nil {
+			if invoked {
+				err = fmt.Errorf("unable to parse the OTTL macros expansion of %q: %w", value, err)
+			}
+			errs = append(errs, err)
+			continue
+		}
+		for _, item := range p {
+			if s, ok := any(item).(interface{ setSourceIndex(int) }); ok {
+				s.setSourceIndex(i)
+			}
+		}
+		parsed = append(parsed, p...)
+	}
+	if log != nil && len(original) > 0 {
+		log(original, modified)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMacros() []Macro {
+	return []Macro{
+		{
+			Name: "normalize",
+			Parameters: []MacroParameter{
+				{Name: "target", Type: MacroParameterPath},
+				{Name: "value"},
+			},
+			Statements: []string{
+				`set(target, value)`,
+				`set(attributes["normalized"], true) where IsEmpty(target)`,
+				`mark(target)`,
+			},
+		},
+		{
+			Name:       "mark",
+			Parameters: []MacroParameter{{Name: "source"}},
+			Statements: []string{`set(attributes["marked"], source)`},
+		},
+		{
+			Name:       "IsEmpty",
+			Parameters: []MacroParameter{{Name: "value"}},
+			Condition:  `value == nil or value == ""`,
+		},
+		{
+			Name:       "Unless",
+			Parameters: []MacroParameter{{Name: "condition", Type: MacroParameterCondition}},
+			Condition:  `not condition and name != nil`,
+		},
+		{
+			Name:       "loop",
+			Statements: []string{`loop()`},
+		},
+	}
+}
+
+func Test_macroExpander_expandStatement(t *testing.T) {
+	expander, err := newMacroExpander(testMacros())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		statement string
+		expected  []string
+	}{
+		{
+			name:      "no macros",
+			statement: `set(attributes["test"], "pass") where name == "foo"`,
+			expected:  []string{`set(attributes["test"], "pass") where name == "foo"`},
+		},
+		{
+			name:      "statement macro",
+			statement: `normalize(attributes["test"], "pass")`,
+			expected: []string{
+				`set(attributes["test"], "pass")`,
+				`set(attributes["normalized"], true) where (attributes["test"] == nil or attributes["test"] == "")`,
+				`set(attributes["marked"], attributes["test"])`,
+			},
+		},
+		{
+			name:      "statement macro with where clause",
+			statement: `normalize(attributes["test"], Concat(["a", "b"], "")) where name == "foo"`,
+			expected: []string{
+				`set(attributes["test"], Concat(["a", "b"], "")) where name == "foo"`,
+				`set(attributes["normalized"], true) where (name == "foo") and ((attributes["test"] == nil or attributes["test"] == ""))`,
+				`set(attributes["marked"], attributes["test"]) where name == "foo"`,
+			},
+		},
+		{
+			name:      "condition macros",
+			statement: `set(attributes["test"], "pass") where IsEmpty(name) or Unless(IsEmpty(attributes["foo"]))`,
+			expected: []string{
+				`set(attributes["test"], "pass") where (name == nil or name == "") or (not ((attributes["foo"] == nil or attributes["foo"] == "")) and name != nil)`,
+			},
+		},
+		{
+			name:      "macro names in strings and paths",
+			statement: `set(attributes["IsEmpty(name)"], resource.normalize)`,
+			expected:  []string{`set(attributes["IsEmpty(name)"], resource.normalize)`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded, err := expander.expandStatement(tt.statement)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, expanded)
+			for _, statement := range expanded {
+				_, err = parseStatement(statement)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_macroExpander_expandExpression(t *testing.T) {
+	expander, err := newMacroExpander(testMacros())
+	require.NoError(t, err)
+
+	expanded, err := expander.expandExpression(`IsEmpty(name) and attributes["test"] == "pass"`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`(name == nil or name == "") and attributes["test"] == "pass"`}, expanded)
+}
+
+func Test_macroExpander_errors(t *testing.T) {
+	expander, err := newMacroExpander(testMacros())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		statement string
+		expected  string
+	}{
+		{
+			name:      "missing argument",
+			statement: `normalize(attributes["test"])`,
+			expected:  `macro "normalize" expects 2 arguments but got 1`,
+		},
+		{
+			name:      "invalid argument type",
+			statement: `normalize("test", "pass")`,
+			expected:  `macro "normalize": invalid argument for parameter "target": expected a path but got "\"test\""`,
+		},
+		{
+			name:      "invalid condition argument",
+			statement: `set(name, "pass") where Unless(name)`,
+			expected:  `macro "Unless": invalid argument for parameter "condition": condition has invalid syntax`,
+		},
+		{
+			name:      "named argument",
+			statement: `normalize(target = attributes["test"], "pass")`,
+			expected:  `macro "normalize": named argument "target" is not supported`,
+		},
+		{
+			name:      "empty argument",
+			statement: `normalize(attributes["test"], )`,
+			expected:  `macro "normalize": empty argument`,
+		},
+		{
+			name:      "recursive invocation",
+			statement: `loop()`,
+			expected:  `macro "loop": macro "loop" is invoked recursively`,
+		},
+		{
+			name:      "statement macro in condition",
+			statement: `set(name, "pass") where mark(name)`,
+			expected:  `statement macro "mark" can only be invoked as a statement`,
+		},
+		{
+			name:      "nested error location",
+			statement: `set(name, "pass") where Unless(IsEmpty())`,
+			expected:  `macro "IsEmpty" expects 1 arguments but got 0`,
+		},
+		{
+			name:      "unexpected tokens",
+			statement: `mark(name) other`,
+			expected:  `macro "mark": unexpected "other" after the invocation`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := expander.expandStatement(tt.statement)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func Test_newMacroExpander_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		macro    Macro
+		expected string
+	}{
+		{
+			name:     "no body",
+			macro:    Macro{Name: "empty"},
+			expected: `invalid macro "empty": either statements or a condition must be defined`,
+		},
+		{
+			name:     "statements and condition",
+			macro:    Macro{Name: "both", Statements: []string{`set(name, "a")`}, Condition: `name == "a"`},
+			expected: `invalid macro "both": statements and condition are mutually exclusive`,
+		},
+		{
+			name:     "invalid statement macro name",
+			macro:    Macro{Name: "Upper", Statements: []string{`set(name, "a")`}},
+			expected: `invalid macro "Upper": statement macro names must start with a lowercase letter`,
+		},
+		{
+			name:     "invalid condition macro name",
+			macro:    Macro{Name: "lower", Condition: `name == "a"`},
+			expected: `invalid macro "lower": condition macro names must start with an uppercase letter`,
+		},
+		{
+			name:     "invalid statement",
+			macro:    Macro{Name: "invalid", Statements: []string{`set(name, "a")`, `set(name`}},
+			expected: `invalid macro "invalid": invalid statement 1 "set(name": statement has invalid syntax`,
+		},
+		{
+			name:     "invalid condition",
+			macro:    Macro{Name: "Invalid", Condition: `name ==`},
+			expected: `invalid macro "Invalid": invalid condition "name ==": condition has invalid syntax`,
+		},
+		{
+			name:     "invalid parameter name",
+			macro:    Macro{Name: "IsValid", Parameters: []MacroParameter{{Name: "Value"}}, Condition: `name == "a"`},
+			expected: `invalid macro "IsValid": invalid parameter name "Value"`,
+		},
+		{
+			name:     "reserved parameter name",
+			macro:    Macro{Name: "IsValid", Parameters: []MacroParameter{{Name: "where"}}, Condition: `name == "a"`},
+			expected: `invalid macro "IsValid": invalid parameter name "where"`,
+		},
+		{
+			name:     "duplicate parameter",
+			macro:    Macro{Name: "IsValid", Parameters: []MacroParameter{{Name: "a"}, {Name: "a"}}, Condition: `a == "a"`},
+			expected: `invalid macro "IsValid": duplicate parameter "a"`,
+		},
+		{
+			name:     "unknown parameter type",
+			macro:    Macro{Name: "IsValid", Parameters: []MacroParameter{{Name: "a", Type: "map"}}, Condition: `a == "a"`},
+			expected: `invalid macro "IsValid": parameter "a" has an unknown type "map"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newMacroExpander([]Macro{tt.macro})
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+
+	_, err := newMacroExpander([]Macro{testMacros()[0], testMacros()[0]})
+	assert.ErrorContains(t, err, `duplicate macro "normalize"`)
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type AllArguments[K any] struct {
+	Target    ottl.Getter[K]
+	Predicate ottl.Lambda[K]
+}
+
+func NewAllFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("All", &AllArguments[K]{}, createAllFunction[K])
+}
+
+func createAllFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*AllArguments[K])
+
+	if !ok {
+		return nil, errors.New("AllFactory args must be of type *AllArguments[K]")
+	}
+
+	return allItems(args.Target, args.Predicate), nil
+}
+
+func allItems[K any](target ottl.Getter[K], predicate ottl.Lambda[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		items, err := getLambdaTarget(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+
+		all := true
+		err = items.each(func(key any, value pcommon.Value) (bool, error) {
+			matches, err := evaluatePredicate(ctx, tCtx, predicate, key, value)
+			all = matches
+			return matches, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return all, nil
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_all(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   any
+		expected bool
+	}{
+		{
+			name:     "slice match",
+			target:   []any{"http.a", "http.b"},
+			expected: true,
+		},
+		{
+			name:     "slice no match",
+			target:   []any{"http.a", "b"},
+			expected: false,
+		},
+		{
+			name:     "map no match",
+			target:   map[string]any{"a": "b", "http.c": "d"},
+			expected: false,
+		},
+		{
+			name:     "empty",
+			target:   []any{},
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}
+
+			result, err := allItems[any](target, hasPrefixLambda("http."))(t.Context(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
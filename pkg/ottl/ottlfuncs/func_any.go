@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type AnyArguments[K any] struct {
+	Target    ottl.Getter[K]
+	Predicate ottl.Lambda[K]
+}
+
+func NewAnyFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Any", &AnyArguments[K]{}, createAnyFunction[K])
+}
+
+func createAnyFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*AnyArguments[K])
+
+	if !ok {
+		return nil, errors.New("AnyFactory args must be of type *AnyArguments[K]")
+	}
+
+	return anyItems(args.Target, args.Predicate), nil
+}
+
+func anyItems[K any](target ottl.Getter[K], predicate ottl.Lambda[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		items, err := getLambdaTarget(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+		err = items.each(func(key any, value pcommon.Value) (bool, error) {
+			matches, err := evaluatePredicate(ctx, tCtx, predicate, key, value)
+			found = matches
+			return !matches, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return found, nil
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type FilterArguments[K any] struct {
+	Target    ottl.Getter[K]
+	Predicate ottl.Lambda[K]
+}
+
+func NewFilterFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Filter", &FilterArguments[K]{}, createFilterFunction[K])
+}
+
+func createFilterFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*FilterArguments[K])
+
+	if !ok {
+		return nil, errors.New("FilterFactory args must be of type *FilterArguments[K]")
+	}
+
+	return filterItems(args.Target, args.Predicate), nil
+}
+
+func filterItems[K any](target ottl.Getter[K], predicate ottl.Lambda[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		items, err := getLambdaTarget(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+
+		if items.isMap {
+			output := pcommon.NewMap()
+			err = items.each(func(key any, value pcommon.Value) (bool, error) {
+				matches, err := evaluatePredicate(ctx, tCtx, predicate, key, value)
+				if matches {
+					value.CopyTo(output.PutEmpty(key.(string)))
+				}
+				return true, err
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output, nil
+		}
+
+		output := pcommon.NewSlice()
+		err = items.each(func(key any, value pcommon.Value) (bool, error) {
+			matches, err := evaluatePredicate(ctx, tCtx, predicate, key, value)
+			if matches {
+				value.CopyTo(output.AppendEmpty())
+			}
+			return true, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return output, nil
+	}
+}
+
+// evaluatePredicate invokes a lambda which must return a boolean.
+func evaluatePredicate[K any](ctx context.Context, tCtx K, predicate ottl.Lambda[K], key any, value pcommon.Value) (bool, error) {
+	result, err := predicate.Invoke(ctx, tCtx, key, ottlcommon.GetValue(value))
+	if err != nil {
+		return false, err
+	}
+	matches, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("predicate must return a bool but got %T", result)
+	}
+	return matches, nil
+}
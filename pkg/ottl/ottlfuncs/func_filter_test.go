@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// hasPrefixLambda matches the items whose key or value starts with the prefix.
+func hasPrefixLambda(prefix string) ottl.Lambda[any] {
+	return ottl.StandardLambda[any]{
+		Invoker: func(_ context.Context, _ any, key, value any) (any, error) {
+			if k, ok := key.(string); ok {
+				return strings.HasPrefix(k, prefix), nil
+			}
+			v, ok := value.(string)
+			return ok && strings.HasPrefix(v, prefix), nil
+		},
+	}
+}
+
+func Test_filter(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   any
+		expected any
+	}{
+		{
+			name:     "slice",
+			target:   []any{"http.a", int64(1), "b", "http.c"},
+			expected: []any{"http.a", "http.c"},
+		},
+		{
+			name:     "map",
+			target:   map[string]any{"http.a": "a", "b": "http.b", "http.c": map[string]any{"c": "d"}},
+			expected: map[string]any{"http.a": "a", "http.c": map[string]any{"c": "d"}},
+		},
+		{
+			name:     "no matches",
+			target:   []any{"a"},
+			expected: []any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}
+
+			result, err := filterItems[any](target, hasPrefixLambda("http."))(t.Context(), nil)
+			require.NoError(t, err)
+			switch r := result.(type) {
+			case pcommon.Slice:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			case pcommon.Map:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			default:
+				t.Fatalf("unexpected result %T", result)
+			}
+		})
+	}
+}
+
+func Test_filter_non_bool(t *testing.T) {
+	target := ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return []any{"a"}, nil
+		},
+	}
+	predicate := ottl.StandardLambda[any]{
+		Invoker: func(_ context.Context, _ any, _, value any) (any, error) {
+			return value, nil
+		},
+	}
+
+	_, err := filterItems[any](target, predicate)(t.Context(), nil)
+	assert.EqualError(t, err, "predicate must return a bool but got string")
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/lookuptable"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type LookupArguments[K any] struct {
+	Target ottl.StringLikeGetter[K]
+	Path   string
+	Key    ottl.Optional[string]
+	Format ottl.Optional[string]
+}
+
+func NewLookupFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Lookup", &LookupArguments[K]{}, createLookupFunction[K])
+}
+
+func createLookupFunction[K any](fCtx ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*LookupArguments[K])
+
+	if !ok {
+		return nil, errors.New("LookupFactory args must be of type *LookupArguments[K]")
+	}
+
+	logger := fCtx.Set.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return lookup(args.Target, args.Path, args.Key, args.Format, logger)
+}
+
+// lookup returns the row of the table loaded from the file at path whose key column matches the target
+func lookup[K any](target ottl.StringLikeGetter[K], path string, key, format ottl.Optional[string], logger *zap.Logger) (ottl.ExprFunc[K], error) {
+	cfg := lookuptable.Config{
+		Path:           path,
+		ReloadInterval: lookuptable.DefaultReloadInterval,
+	}
+	if !key.IsEmpty() {
+		cfg.Key = key.Get()
+	}
+	if !format.IsEmpty() {
+		cfg.Format = format.Get()
+	}
+
+	table, err := lookuptable.New(cfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("load lookup table: %w", err)
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewMap()
+		if val == nil {
+			return result, nil
+		}
+		row, ok := table.Lookup(*val)
+		if !ok {
+			return result, nil
+		}
+		if err := result.FromRaw(row); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}, nil
+}
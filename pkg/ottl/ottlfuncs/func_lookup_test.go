@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_lookup(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "errors.csv")
+	require.NoError(t, os.WriteFile(csvPath, []byte("id,error.message,error.severity\nE100,disk full,error\n"), 0o600))
+	jsonPath := filepath.Join(dir, "hosts.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`[{"id": 42, "host.name": "web-1", "host.tags": ["a", "b"]}]`), 0o600))
+	tablePath := filepath.Join(dir, "tenants.table")
+	require.NoError(t, os.WriteFile(tablePath, []byte(`{"t1": {"tenant.name": "acme", "tenant.tier": 2}}`), 0o600))
+
+	tests := []struct {
+		name     string
+		target   any
+		path     string
+		key      ottl.Optional[string]
+		format   ottl.Optional[string]
+		expected map[string]any
+	}{
+		{
+			name:     "csv match",
+			target:   "E100",
+			path:     csvPath,
+			key:      ottl.NewTestingOptional("id"),
+			expected: map[string]any{"error.message": "disk full", "error.severity": "error"},
+		},
+		{
+			name:     "csv no match",
+			target:   "E200",
+			path:     csvPath,
+			key:      ottl.NewTestingOptional("id"),
+			expected: map[string]any{},
+		},
+		{
+			name:     "nil target",
+			target:   nil,
+			path:     csvPath,
+			key:      ottl.NewTestingOptional("id"),
+			expected: map[string]any{},
+		},
+		{
+			name:     "json array with number target",
+			target:   int64(42),
+			path:     jsonPath,
+			key:      ottl.NewTestingOptional("id"),
+			expected: map[string]any{"host.name": "web-1", "host.tags": []any{"a", "b"}},
+		},
+		{
+			name:     "json object with explicit format",
+			target:   "t1",
+			path:     tablePath,
+			format:   ottl.NewTestingOptional("json"),
+			expected: map[string]any{"tenant.name": "acme", "tenant.tier": int64(2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringLikeGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}
+
+			exprFunc, err := lookup[any](target, tt.path, tt.key, tt.format, zap.NewNop())
+			require.NoError(t, err)
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_lookup_invalid_table(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "errors.csv")
+	require.NoError(t, os.WriteFile(csvPath, []byte("id,error.message\nE100,disk full\n"), 0o600))
+
+	tests := []struct {
+		name        string
+		path        string
+		key         ottl.Optional[string]
+		format      ottl.Optional[string]
+		expectedErr string
+	}{
+		{
+			name:        "missing key",
+			path:        csvPath,
+			expectedErr: "key is required for CSV tables",
+		},
+		{
+			name:        "unknown key",
+			path:        csvPath,
+			key:         ottl.NewTestingOptional("code"),
+			expectedErr: `key "code" is not a column of the CSV header`,
+		},
+		{
+			name:        "invalid format",
+			path:        csvPath,
+			key:         ottl.NewTestingOptional("id"),
+			format:      ottl.NewTestingOptional("xml"),
+			expectedErr: `invalid format "xml"`,
+		},
+		{
+			name:        "missing file",
+			path:        filepath.Join(dir, "missing.csv"),
+			key:         ottl.NewTestingOptional("id"),
+			expectedErr: "load lookup table",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringLikeGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return "E100", nil
+				},
+			}
+
+			_, err := lookup[any](target, tt.path, tt.key, tt.format, zap.NewNop())
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func Test_createLookupFunction(t *testing.T) {
+	csvPath := filepath.Join(t.TempDir(), "errors.csv")
+	require.NoError(t, os.WriteFile(csvPath, []byte("id,error.message\nE100,disk full\n"), 0o600))
+
+	args := &LookupArguments[any]{
+		Target: ottl.StandardStringLikeGetter[any]{
+			Getter: func(context.Context, any) (any, error) {
+				return "E100", nil
+			},
+		},
+		Path: csvPath,
+		Key:  ottl.NewTestingOptional("id"),
+	}
+
+	exprFunc, err := createLookupFunction[any](ottl.FunctionContext{Set: componenttest.NewNopTelemetrySettings()}, args)
+	require.NoError(t, err)
+	result, err := exprFunc(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"error.message": "disk full"}, result.(pcommon.Map).AsRaw())
+
+	_, err = createLookupFunction[any](ottl.FunctionContext{}, nil)
+	assert.ErrorContains(t, err, "LookupFactory args must be of type *LookupArguments[K]")
+}
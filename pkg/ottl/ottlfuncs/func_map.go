@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type MapArguments[K any] struct {
+	Target ottl.Getter[K]
+	Fn     ottl.Lambda[K]
+}
+
+func NewMapFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Map", &MapArguments[K]{}, createMapFunction[K])
+}
+
+func createMapFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*MapArguments[K])
+
+	if !ok {
+		return nil, errors.New("MapFactory args must be of type *MapArguments[K]")
+	}
+
+	return mapItems(args.Target, args.Fn), nil
+}
+
+func mapItems[K any](target ottl.Getter[K], fn ottl.Lambda[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		items, err := getLambdaTarget(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+
+		if items.isMap {
+			output := pcommon.NewMap()
+			output.EnsureCapacity(items.m.Len())
+			err = items.each(func(key any, value pcommon.Value) (bool, error) {
+				result, err := fn.Invoke(ctx, tCtx, key, ottlcommon.GetValue(value))
+				if err != nil {
+					return false, err
+				}
+				return true, setLambdaResult(output.PutEmpty(key.(string)), result)
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output, nil
+		}
+
+		output := pcommon.NewSlice()
+		output.EnsureCapacity(items.s.Len())
+		err = items.each(func(key any, value pcommon.Value) (bool, error) {
+			result, err := fn.Invoke(ctx, tCtx, key, ottlcommon.GetValue(value))
+			if err != nil {
+				return false, err
+			}
+			return true, setLambdaResult(output.AppendEmpty(), result)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return output, nil
+	}
+}
+
+// lambdaTarget is the map or the slice whose items a lambda is applied to.
+type lambdaTarget struct {
+	m     pcommon.Map
+	s     pcommon.Slice
+	isMap bool
+}
+
+func getLambdaTarget[K any](ctx context.Context, tCtx K, target ottl.Getter[K]) (lambdaTarget, error) {
+	val, err := target.Get(ctx, tCtx)
+	if err != nil {
+		return lambdaTarget{}, err
+	}
+	getter := func(context.Context, K) (any, error) {
+		return val, nil
+	}
+
+	switch v := val.(type) {
+	case pcommon.Map, map[string]any:
+		m, err := ottl.StandardPMapGetter[K]{Getter: getter}.Get(ctx, tCtx)
+		return lambdaTarget{m: m, isMap: true}, err
+	case pcommon.Value:
+		if v.Type() == pcommon.ValueTypeMap {
+			return lambdaTarget{m: v.Map(), isMap: true}, nil
+		}
+	}
+	s, err := ottl.StandardPSliceGetter[K]{Getter: getter}.Get(ctx, tCtx)
+	if err != nil {
+		return lambdaTarget{}, fmt.Errorf("target must be a map or a slice: %w", err)
+	}
+	return lambdaTarget{s: s}, nil
+}
+
+// each calls fn with the key, or the int64 index, and the value of every item until
+// fn returns false or an error.
+func (t lambdaTarget) each(fn func(key any, value pcommon.Value) (bool, error)) error {
+	if t.isMap {
+		for k, v := range t.m.All() {
+			next, err := fn(k, v)
+			if err != nil || !next {
+				return err
+			}
+		}
+		return nil
+	}
+	for i, v := range t.s.All() {
+		next, err := fn(int64(i), v)
+		if err != nil || !next {
+			return err
+		}
+	}
+	return nil
+}
+
+// setLambdaResult sets the value returned by a lambda, which can either be a raw value or
+// a pdata value.
+func setLambdaResult(target pcommon.Value, result any) error {
+	switch v := result.(type) {
+	case pcommon.Value:
+		v.CopyTo(target)
+	case pcommon.Map:
+		v.CopyTo(target.SetEmptyMap())
+	case pcommon.Slice:
+		v.CopyTo(target.SetEmptySlice())
+	default:
+		if err := target.FromRaw(v); err != nil {
+			return fmt.Errorf("unsupported lambda result: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_map(t *testing.T) {
+	pairs := ottl.StandardLambda[any]{
+		Invoker: func(_ context.Context, _ any, key, value any) (any, error) {
+			return fmt.Sprintf("%v=%v", key, value), nil
+		},
+	}
+	nested := ottl.StandardLambda[any]{
+		Invoker: func(_ context.Context, _ any, _, value any) (any, error) {
+			m := pcommon.NewMap()
+			m.PutStr("value", value.(string))
+			return m, nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		target   any
+		fn       ottl.Lambda[any]
+		expected any
+	}{
+		{
+			name:     "slice",
+			target:   []any{"a", "b"},
+			fn:       pairs,
+			expected: []any{"0=a", "1=b"},
+		},
+		{
+			name:     "map",
+			target:   map[string]any{"a": "b", "c": int64(1)},
+			fn:       pairs,
+			expected: map[string]any{"a": "a=b", "c": "c=1"},
+		},
+		{
+			name: "pcommon value",
+			target: func() pcommon.Value {
+				v := pcommon.NewValueSlice()
+				v.Slice().AppendEmpty().SetStr("a")
+				return v
+			}(),
+			fn:       nested,
+			expected: []any{map[string]any{"value": "a"}},
+		},
+		{
+			name:     "empty",
+			target:   []any{},
+			fn:       pairs,
+			expected: []any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}
+
+			result, err := mapItems[any](target, tt.fn)(t.Context(), nil)
+			require.NoError(t, err)
+			switch r := result.(type) {
+			case pcommon.Slice:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			case pcommon.Map:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			default:
+				t.Fatalf("unexpected result %T", result)
+			}
+		})
+	}
+}
+
+func Test_map_error(t *testing.T) {
+	target := ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return "a", nil
+		},
+	}
+	fn := ottl.StandardLambda[any]{
+		Invoker: func(context.Context, any, any, any) (any, error) {
+			return nil, errors.New("unexpected invocation")
+		},
+	}
+
+	_, err := mapItems[any](target, fn)(t.Context(), nil)
+	assert.ErrorContains(t, err, "target must be a map or a slice")
+
+	target.Getter = func(context.Context, any) (any, error) {
+		return []any{"a"}, nil
+	}
+	_, err = mapItems[any](target, fn)(t.Context(), nil)
+	assert.ErrorContains(t, err, "unexpected invocation")
+}
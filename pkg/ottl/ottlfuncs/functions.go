@@ -68,6 +68,7 @@ func converters[K any]() []ottl.Factory[K] {
 		NewIsStringFactory[K](),
 		NewLenFactory[K](),
 		NewLogFactory[K](),
+		NewLookupFactory[K](),
 		NewIsValidLuhnFactory[K](),
 		NewMD5Factory[K](),
 		NewMicrosecondsFactory[K](),
@@ -125,5 +126,9 @@ func converters[K any]() []ottl.Factory[K] {
 		NewProfileIDFactory[K](),
 		NewParseIntFactory[K](),
 		NewKeysFactory[K](),
+		NewMapFactory[K](),
+		NewFilterFactory[K](),
+		NewAnyFactory[K](),
+		NewAllFactory[K](),
 	}
 }
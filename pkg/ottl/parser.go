@@ -10,10 +10,12 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/alecthomas/participle/v2"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -24,6 +26,13 @@ type Statement[K any] struct {
 	condition         BoolExpr[K]
 	origText          string
 	telemetrySettings component.TelemetrySettings
+	// sourceIndex is the index of the statement among the statements given to ParseStatements.
+	// The statements expanded from a macro invocation have the index of the invocation.
+	sourceIndex int
+}
+
+func (s *Statement[K]) setSourceIndex(index int) {
+	s.sourceIndex = index
 }
 
 // Execute is a function that will execute the statement's function if the statement's condition is met.
@@ -69,6 +78,7 @@ type Parser[K any] struct {
 	enumParser        EnumParser
 	telemetrySettings component.TelemetrySettings
 	pathContextNames  map[string]struct{}
+	lambdaParameters  map[string]*lambdaParameter
 }
 
 // NewParser creates a new Parser
@@ -128,12 +138,13 @@ func (p *Parser[K]) ParseStatements(statements []string) ([]*Statement[K], error
 	parsedStatements := make([]*Statement[K], 0, len(statements))
 	var parseErrs []error
 
-	for _, statement := range statements {
+	for i, statement := range statements {
 		ps, err := p.ParseStatement(statement)
 		if err != nil {
 			parseErrs = append(parseErrs, fmt.Errorf("unable to parse OTTL statement %q: %w", statement, err))
 			continue
 		}
+		ps.sourceIndex = i
 		parsedStatements = append(parsedStatements, ps)
 	}
 
@@ -364,6 +375,8 @@ type StatementSequence[K any] struct {
 	statements        []*Statement[K]
 	errorMode         ErrorMode
 	telemetrySettings component.TelemetrySettings
+	telemetry         *sequenceTelemetry
+	tracer            *sequenceTracer
 }
 
 // StatementSequenceOption is an option for a StatementSequence
@@ -376,6 +389,41 @@ func WithStatementSequenceErrorMode[K any](errorMode ErrorMode) StatementSequenc
 	}
 }
 
+// WithStatementSequenceTelemetry enables recording, for each statement of a StatementSequence, the number of
+// executions, condition matches and errors, and the cumulative execution duration as metrics of the
+// component.TelemetrySettings' MeterProvider. The metrics are labelled with the given attributes, which should
+// identify the component and the sequence, and with the index of the statement among the statements given to
+// ParseStatements. The statements expanded from a macro invocation are recorded under the index of the invocation.
+func WithStatementSequenceTelemetry[K any](attributes ...attribute.KeyValue) StatementSequenceOption[K] {
+	return func(s *StatementSequence[K]) {
+		indexes := make([]int, len(s.statements))
+		for i, statement := range s.statements {
+			indexes[i] = statement.sourceIndex
+		}
+		telemetry, err := newSequenceTelemetry(s.telemetrySettings, "statement", "executions", indexes, attributes)
+		if err != nil {
+			s.telemetrySettings.Logger.Warn("failed to create the OTTL statements telemetry", zap.Error(err))
+			return
+		}
+		s.telemetry = telemetry
+	}
+}
+
+// WithStatementSequenceTracing enables the trace mode of a StatementSequence. One out of every sampleEvery
+// executions of the sequence is traced, recording for each statement whether its condition matched, the error
+// it returned, and the changes it made to the fields of the TransformContext. The traces are passed to the
+// handler, or logged with the component.TelemetrySettings' Logger when the handler is nil.
+//
+// Experimental: *NOTE* this option is subject to change or removal in the future.
+func WithStatementSequenceTracing[K any](sampleEvery int, handler TraceHandler) StatementSequenceOption[K] {
+	return func(s *StatementSequence[K]) {
+		if handler == nil {
+			handler = NewLoggerTraceHandler(s.telemetrySettings.Logger)
+		}
+		s.tracer = newSequenceTracer(sampleEvery, handler)
+	}
+}
+
 // NewStatementSequence creates a new StatementSequence with the provided Statement slice and component.TelemetrySettings.
 // The default ErrorMode is `Propagate`.
 // You may also augment the StatementSequence with a slice of StatementSequenceOption.
@@ -399,8 +447,27 @@ func (s *StatementSequence[K]) Execute(ctx context.Context, tCtx K) error {
 	if s.telemetrySettings.Logger.Core().Enabled(zap.DebugLevel) {
 		s.telemetrySettings.Logger.Debug("initial TransformContext before executing StatementSequence", zap.Any("TransformContext", tCtx))
 	}
-	for _, statement := range s.statements {
-		_, _, err := statement.Execute(ctx, tCtx)
+	var trace *SequenceTrace
+	var snapshot map[string]any
+	if s.tracer != nil && s.tracer.sample() {
+		trace = &SequenceTrace{}
+		snapshot = snapshotTransformContext(tCtx)
+		defer func() {
+			s.tracer.handler(ctx, *trace)
+		}()
+	}
+	for i, statement := range s.statements {
+		var start time.Time
+		if s.telemetry != nil {
+			start = time.Now()
+		}
+		_, matched, err := statement.Execute(ctx, tCtx)
+		if s.telemetry != nil {
+			s.telemetry.record(ctx, i, start, matched, err)
+		}
+		if trace != nil {
+			snapshot = trace.record(i, statement.origText, matched, err, snapshot, tCtx)
+		}
 		if err != nil {
 			if s.errorMode == PropagateError {
 				err = fmt.Errorf("failed to execute statement: %v, %w", statement.origText, err)
@@ -422,6 +489,7 @@ type ConditionSequence[K any] struct {
 	errorMode         ErrorMode
 	telemetrySettings component.TelemetrySettings
 	logicOp           LogicOperation
+	telemetry         *sequenceTelemetry
 }
 
 // ConditionSequenceOption is an option for a ConditionSequence
@@ -443,6 +511,25 @@ func WithLogicOperation[K any](logicOp LogicOperation) ConditionSequenceOption[K
 	}
 }
 
+// WithConditionSequenceTelemetry enables recording, for each condition of a ConditionSequence, the number of
+// evaluations, matches and errors, and the cumulative evaluation duration as metrics of the
+// component.TelemetrySettings' MeterProvider. The metrics are labelled with the given attributes, which should
+// identify the component and the sequence, and with the index of the condition in the sequence.
+func WithConditionSequenceTelemetry[K any](attributes ...attribute.KeyValue) ConditionSequenceOption[K] {
+	return func(c *ConditionSequence[K]) {
+		indexes := make([]int, len(c.conditions))
+		for i := range indexes {
+			indexes[i] = i
+		}
+		telemetry, err := newSequenceTelemetry(c.telemetrySettings, "condition", "evaluations", indexes, attributes)
+		if err != nil {
+			c.telemetrySettings.Logger.Warn("failed to create the OTTL conditions telemetry", zap.Error(err))
+			return
+		}
+		c.telemetry = telemetry
+	}
+}
+
 // NewConditionSequence creates a new ConditionSequence with the provided Condition slice and component.TelemetrySettings.
 // The default ErrorMode is `Propagate` and the default LogicOperation is `OR`.
 // You may also augment the ConditionSequence with a slice of ConditionSequenceOption.
@@ -469,8 +556,15 @@ func NewConditionSequence[K any](conditions []*Condition[K], telemetrySettings c
 // When using the AND LogicOperation with the `ignore` ErrorMode the sequence will evaluate to false if all conditions error.
 func (c *ConditionSequence[K]) Eval(ctx context.Context, tCtx K) (bool, error) {
 	var atLeastOneMatch bool
-	for _, condition := range c.conditions {
+	for i, condition := range c.conditions {
+		var start time.Time
+		if c.telemetry != nil {
+			start = time.Now()
+		}
 		match, err := condition.Eval(ctx, tCtx)
+		if c.telemetry != nil {
+			c.telemetry.record(ctx, i, start, match, err)
+		}
 		if c.telemetrySettings.Logger.Core().Enabled(zap.DebugLevel) {
 			c.telemetrySettings.Logger.Debug("condition evaluation result", zap.String("condition", condition.origText), zap.Bool("match", match), zap.Any("TransformContext", tCtx))
 		}
@@ -80,6 +80,7 @@ type ParserCollection[R any] struct {
 	contextInferrerCandidates map[string]*priorityContextInferrerCandidate
 	candidatesLowerContexts   map[string][]string
 	modifiedLogging           bool
+	macros                    *macroExpander
 	Settings                  component.TelemetrySettings
 	ErrorMode                 ErrorMode
 }
@@ -112,6 +113,16 @@ func NewParserCollection[R any](
 		}
 	}
 
+	if pc.macros != nil {
+		for name := range pc.macros.macros {
+			for context, candidate := range pc.contextInferrerCandidates {
+				if candidate.hasFunctionName(name) {
+					return nil, fmt.Errorf(`macro "%s" conflicts with the function of the same name of the context "%s"`, name, context)
+				}
+			}
+		}
+	}
+
 	return pc, nil
 }
 
@@ -200,7 +211,8 @@ func createConditionsParserWithConverter[K, R any](converter ParsedConditionsCon
 	return func(pc *ParserCollection[R], context string, conditions ConditionsGetter, prependPathsContext bool) (R, error) {
 		var err error
 		var parsingConditions []string
-		if prependPathsContext {
+		// When macros are configured, the paths contexts are prepended to the macros expansions.
+		if prependPathsContext && pc.macros == nil {
 			originalConditions := conditions.GetConditions()
 			parsingConditions = make([]string, 0, len(originalConditions))
 			for _, cond := range originalConditions {
@@ -220,7 +232,17 @@ func createConditionsParserWithConverter[K, R any](converter ParsedConditionsCon
 		} else {
 			parsingConditions = conditions.GetConditions()
 		}
-		parsedConditions, err := parser.ParseConditions(parsingConditions)
+		var prepend func(string) (string, error)
+		var log func(original, modified []string)
+		if prependPathsContext {
+			prepend = func(ottl string) (string, error) {
+				return parser.prependContextToConditionPaths(context, ottl)
+			}
+			if pc.modifiedLogging {
+				log = pc.logModifications
+			}
+		}
+		parsedConditions, err := parseWithMacros(pc.macros, parsingConditions, (*macroExpander).expandExpression, prepend, log, parser.ParseConditions)
 		if err != nil {
 			return *new(R), err
 		}
@@ -237,7 +259,8 @@ func createValueExpressionsParserWithConverter[K, R any](converter ParsedValueEx
 	return func(pc *ParserCollection[R], context string, expressions ValueExpressionsGetter, prependPathsContext bool) (R, error) {
 		var err error
 		var parsingValueExpressions []string
-		if prependPathsContext {
+		// When macros are configured, the paths contexts are prepended to the macros expansions.
+		if prependPathsContext && pc.macros == nil {
 			originalValueExpressions := expressions.GetValueExpressions()
 			parsingValueExpressions = make([]string, 0, len(originalValueExpressions))
 			for _, expr := range originalValueExpressions {
@@ -257,7 +280,17 @@ func createValueExpressionsParserWithConverter[K, R any](converter ParsedValueEx
 		} else {
 			parsingValueExpressions = expressions.GetValueExpressions()
 		}
-		parsedValueExpressions, err := parser.ParseValueExpressions(parsingValueExpressions)
+		var prepend func(string) (string, error)
+		var log func(original, modified []string)
+		if prependPathsContext {
+			prepend = func(ottl string) (string, error) {
+				return parser.prependContextToValueExpressionPaths(context, ottl)
+			}
+			if pc.modifiedLogging {
+				log = pc.logModifications
+			}
+		}
+		parsedValueExpressions, err := parseWithMacros(pc.macros, parsingValueExpressions, (*macroExpander).expandExpression, prepend, log, parser.ParseValueExpressions)
 		if err != nil {
 			return *new(R), err
 		}
@@ -274,7 +307,8 @@ func createStatementsParserWithConverter[K, R any](converter ParsedStatementsCon
 	return func(pc *ParserCollection[R], context string, statements StatementsGetter, prependPathsContext bool) (R, error) {
 		var err error
 		var parsingStatements []string
-		if prependPathsContext {
+		// When macros are configured, the paths contexts are prepended to the macros expansions.
+		if prependPathsContext && pc.macros == nil {
 			originalStatements := statements.GetStatements()
 			parsingStatements = make([]string, 0, len(originalStatements))
 			for _, cond := range originalStatements {
@@ -294,7 +328,17 @@ func createStatementsParserWithConverter[K, R any](converter ParsedStatementsCon
 		} else {
 			parsingStatements = statements.GetStatements()
 		}
-		parsedStatements, err := parser.ParseStatements(parsingStatements)
+		var prepend func(string) (string, error)
+		var log func(original, modified []string)
+		if prependPathsContext {
+			prepend = func(ottl string) (string, error) {
+				return parser.prependContextToStatementPaths(context, ottl)
+			}
+			if pc.modifiedLogging {
+				log = pc.logModifications
+			}
+		}
+		parsedStatements, err := parseWithMacros(pc.macros, parsingStatements, (*macroExpander).expandStatement, prepend, log, parser.ParseStatements)
 		if err != nil {
 			return *new(R), err
 		}
@@ -407,6 +451,36 @@ func EnableParserCollectionModifiedPathsLogging[R any](enabled bool) ParserColle
 	}
 }
 
+// WithParserCollectionMacros configures user-defined macros, which can be invoked by the
+// statements, conditions and value expressions parsed by the ParserCollection.
+// The macros invocations are expanded before inferring the context and parsing, so the
+// macros bodies are parsed by the OTTL parser of the context they're invoked from.
+// Macros names must not conflict with the functions of the configured contexts.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func WithParserCollectionMacros[R any](macros []Macro) ParserCollectionOption[R] {
+	return func(tp *ParserCollection[R]) error {
+		if len(macros) == 0 {
+			return nil
+		}
+		expander, err := newMacroExpander(macros)
+		if err != nil {
+			return err
+		}
+		tp.macros = expander
+		return nil
+	}
+}
+
+// ExpandConditionsMacros returns the given conditions with the invocations of the macros
+// configured through WithParserCollectionMacros replaced by the macros bodies. It allows
+// supporting macros in conditions that aren't parsed by the ParserCollection.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func (pc *ParserCollection[R]) ExpandConditionsMacros(conditions []string) ([]string, error) {
+	return pc.macros.expandAll(conditions, (*macroExpander).expandExpression)
+}
+
 type parseCollectionContextInferenceOptions struct {
 	conditions []string
 }
@@ -451,12 +525,20 @@ func (pc *ParserCollection[R]) ParseStatements(statements StatementsGetter, opti
 
 	conditionsValues := parseStatementsOpts.conditions
 
+	inferenceStatements, err := pc.macros.expandAll(statementsValues, (*macroExpander).expandStatement)
+	if err != nil {
+		return *new(R), err
+	}
+	inferenceConditions, err := pc.macros.expandAll(conditionsValues, (*macroExpander).expandExpression)
+	if err != nil {
+		return *new(R), err
+	}
+
 	var inferredContext string
-	var err error
-	if len(conditionsValues) > 0 {
-		inferredContext, err = pc.contextInferrer.infer(statementsValues, conditionsValues, nil)
+	if len(inferenceConditions) > 0 {
+		inferredContext, err = pc.contextInferrer.infer(inferenceStatements, inferenceConditions, nil)
 	} else {
-		inferredContext, err = pc.contextInferrer.inferFromStatements(statementsValues)
+		inferredContext, err = pc.contextInferrer.inferFromStatements(inferenceStatements)
 	}
 
 	if err != nil {
@@ -513,7 +595,11 @@ func (pc *ParserCollection[R]) ParseStatementsWithContext(context string, statem
 // Experimental: *NOTE* this API is subject to change or removal in the future.
 func (pc *ParserCollection[R]) ParseConditions(conditions ConditionsGetter) (R, error) {
 	conditionsValues := conditions.GetConditions()
-	inferredContext, err := pc.contextInferrer.inferFromConditions(conditionsValues)
+	inferenceConditions, err := pc.macros.expandAll(conditionsValues, (*macroExpander).expandExpression)
+	if err != nil {
+		return *new(R), err
+	}
+	inferredContext, err := pc.contextInferrer.inferFromConditions(inferenceConditions)
 	if err != nil {
 		return *new(R), err
 	}
@@ -576,7 +662,15 @@ func (pc *ParserCollection[R]) ParseValueExpressions(expressions ValueExpression
 	}
 	conditionsValues := parseStatementsOpts.conditions
 
-	inferredContext, err := pc.contextInferrer.infer(nil, conditionsValues, expressionStrings)
+	inferenceConditions, err := pc.macros.expandAll(conditionsValues, (*macroExpander).expandExpression)
+	if err != nil {
+		return *new(R), err
+	}
+	inferenceExpressions, err := pc.macros.expandAll(expressionStrings, (*macroExpander).expandExpression)
+	if err != nil {
+		return *new(R), err
+	}
+	inferredContext, err := pc.contextInferrer.infer(nil, inferenceConditions, inferenceExpressions)
 	if err != nil {
 		return *new(R), err
 	}
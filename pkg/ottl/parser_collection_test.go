@@ -910,6 +910,126 @@ func Test_NewValueExpressionsGetter(t *testing.T) {
 	assert.Equal(t, expressions, expressionsGetter.GetValueExpressions())
 }
 
+func Test_ParseStatements_Macros(t *testing.T) {
+	pc, err := NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("foo", mockParser(t, WithPathContextNames[any]([]string{"foo"})), WithStatementConverter(newNopParsedStatementsConverter[any]())),
+		WithParserCollectionMacros[any]([]Macro{
+			{
+				Name: "init",
+				Statements: []string{
+					`set(foo.attributes["a"], "a")`,
+					`set(foo.attributes["b"], "b") where IsFoo(foo.name)`,
+				},
+			},
+			{
+				Name:       "IsFoo",
+				Parameters: []MacroParameter{{Name: "value"}},
+				Condition:  `value == "foo"`,
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	// The context is inferred from the macros bodies.
+	result, err := pc.ParseStatements(mockGetter{values: []string{`init()`, `set(foo.name, "bar") where IsFoo(foo.attributes["c"])`}})
+	require.NoError(t, err)
+
+	statements := result.([]*Statement[any])
+	require.Len(t, statements, 3)
+	assert.Equal(t, `set(foo.attributes["a"], "a")`, statements[0].origText)
+	assert.Equal(t, `set(foo.attributes["b"], "b") where (foo.name == "foo")`, statements[1].origText)
+	assert.Equal(t, `set(foo.name, "bar") where (foo.attributes["c"] == "foo")`, statements[2].origText)
+	// the statements keep the index of the statement they were expanded from
+	assert.Equal(t, 0, statements[0].sourceIndex)
+	assert.Equal(t, 0, statements[1].sourceIndex)
+	assert.Equal(t, 1, statements[2].sourceIndex)
+}
+
+func Test_ParseStatementsWithContext_Macros_PrependPathContext(t *testing.T) {
+	pc, err := NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("foo", mockParser(t, WithPathContextNames[any]([]string{"foo"})), WithStatementConverter(newNopParsedStatementsConverter[any]())),
+		WithParserCollectionMacros[any]([]Macro{
+			{
+				Name:       "copy",
+				Parameters: []MacroParameter{{Name: "source", Type: MacroParameterPath}},
+				Statements: []string{`set(attributes["copy"], source)`},
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	result, err := pc.ParseStatementsWithContext("foo", mockGetter{values: []string{`copy(name)`}}, true)
+	require.NoError(t, err)
+
+	statements := result.([]*Statement[any])
+	require.Len(t, statements, 1)
+	assert.Equal(t, `set(foo.attributes["copy"], foo.name)`, statements[0].origText)
+}
+
+func Test_ParseStatements_MacrosErrors(t *testing.T) {
+	pc, err := NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("foo", mockParser(t, WithPathContextNames[any]([]string{"foo"})), WithStatementConverter(newNopParsedStatementsConverter[any]())),
+		WithParserCollectionMacros[any]([]Macro{
+			{
+				Name:       "apply",
+				Parameters: []MacroParameter{{Name: "target", Type: MacroParameterPath}},
+				Statements: []string{`unknown(target)`},
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	_, err = pc.ParseStatementsWithContext("foo", mockGetter{values: []string{`apply(foo.name)`}}, false)
+	assert.ErrorContains(t, err, `unable to parse the OTTL macros expansion of "apply(foo.name)"`)
+	assert.ErrorContains(t, err, `undefined function "unknown"`)
+
+	_, err = pc.ParseStatementsWithContext("foo", mockGetter{values: []string{`apply("foo")`}}, false)
+	assert.ErrorContains(t, err, `unable to expand OTTL macros in "apply(\"foo\")": macro "apply": invalid argument for parameter "target": expected a path`)
+
+	_, err = pc.ParseStatements(mockGetter{values: []string{`apply()`}})
+	assert.ErrorContains(t, err, `macro "apply" expects 1 arguments but got 0`)
+}
+
+func Test_ParseConditions_Macros(t *testing.T) {
+	pc, err := NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("foo", mockParser(t, WithPathContextNames[any]([]string{"foo"})), WithConditionConverter(newNopParsedConditionsConverter[any]())),
+		WithParserCollectionMacros[any]([]Macro{
+			{
+				Name:       "IsFoo",
+				Parameters: []MacroParameter{{Name: "value"}},
+				Condition:  `value == "foo"`,
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	result, err := pc.ParseConditions(mockGetter{values: []string{`IsFoo(foo.name) or IsFoo(foo.attributes["bar"])`}})
+	require.NoError(t, err)
+
+	conditions := result.([]*Condition[any])
+	require.Len(t, conditions, 1)
+	assert.Equal(t, `(foo.name == "foo") or (foo.attributes["bar"] == "foo")`, conditions[0].origText)
+}
+
+func Test_WithParserCollectionMacros_Error(t *testing.T) {
+	_, err := NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionMacros[any]([]Macro{{Name: "empty"}}),
+	)
+	assert.ErrorContains(t, err, `invalid macro "empty"`)
+
+	_, err = NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionMacros[any]([]Macro{{Name: "set", Statements: []string{`set(foo.name, "foo")`}}}),
+		WithParserCollectionContext("foo", mockParser(t, WithPathContextNames[any]([]string{"foo"})), WithStatementConverter(newNopParsedStatementsConverter[any]())),
+	)
+	assert.ErrorContains(t, err, `macro "set" conflicts with the function of the same name of the context "foo"`)
+}
+
 func mockParser(t *testing.T, options ...Option[any]) *Parser[any] {
 	mockSetFactory := NewFactory("set", &mockSetArguments[any]{},
 		func(_ FunctionContext, _ Arguments) (ExprFunc[any], error) {
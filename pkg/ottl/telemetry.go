@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const scopeName = "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+// sequenceTelemetry records the number of executions, matches and errors, and the cumulative
+// execution duration of each item (statement or condition) of a sequence.
+type sequenceTelemetry struct {
+	executions metric.Int64Counter
+	matches    metric.Int64Counter
+	errors     metric.Int64Counter
+	duration   metric.Float64Counter
+	// attributes holds the measurement attributes of each item, by index.
+	attributes []metric.MeasurementOption
+}
+
+// newSequenceTelemetry creates the telemetry of a sequence of items of the given kind, which
+// is either "statement" or "condition", whose runs are named executionsName. Each item is
+// labelled with the given attributes and its index in indexes, the items sharing an index
+// being recorded together. No telemetry is returned when the settings have no MeterProvider.
+func newSequenceTelemetry(settings component.TelemetrySettings, kind, executionsName string, indexes []int, attributes []attribute.KeyValue) (*sequenceTelemetry, error) {
+	if settings.MeterProvider == nil {
+		return nil, nil
+	}
+	meter := settings.MeterProvider.Meter(scopeName)
+
+	var errs, err error
+	t := &sequenceTelemetry{attributes: make([]metric.MeasurementOption, len(indexes))}
+	t.executions, err = meter.Int64Counter(
+		"otelcol_ottl_"+kind+"_"+executionsName,
+		metric.WithDescription("Number of "+executionsName+" of each OTTL "+kind+"."),
+		metric.WithUnit("{"+executionsName+"}"),
+	)
+	errs = errors.Join(errs, err)
+	t.matches, err = meter.Int64Counter(
+		"otelcol_ottl_"+kind+"_matches",
+		metric.WithDescription("Number of matches of each OTTL "+kind+"."),
+		metric.WithUnit("{matches}"),
+	)
+	errs = errors.Join(errs, err)
+	t.errors, err = meter.Int64Counter(
+		"otelcol_ottl_"+kind+"_errors",
+		metric.WithDescription("Number of errors of each OTTL "+kind+"."),
+		metric.WithUnit("{errors}"),
+	)
+	errs = errors.Join(errs, err)
+	t.duration, err = meter.Float64Counter(
+		"otelcol_ottl_"+kind+"_duration",
+		metric.WithDescription("Cumulative duration of the executions of each OTTL "+kind+"."),
+		metric.WithUnit("s"),
+	)
+	errs = errors.Join(errs, err)
+	if errs != nil {
+		return nil, errs
+	}
+
+	for i, index := range indexes {
+		itemAttributes := make([]attribute.KeyValue, 0, len(attributes)+1)
+		itemAttributes = append(itemAttributes, attributes...)
+		itemAttributes = append(itemAttributes, attribute.Int("ottl."+kind+".index", index))
+		t.attributes[i] = metric.WithAttributeSet(attribute.NewSet(itemAttributes...))
+	}
+	return t, nil
+}
+
+// record records an execution of the item at the given index, which started at start.
+func (t *sequenceTelemetry) record(ctx context.Context, index int, start time.Time, matched bool, err error) {
+	attributes := t.attributes[index]
+	t.executions.Add(ctx, 1, attributes)
+	if matched {
+		t.matches.Add(ctx, 1, attributes)
+	}
+	if err != nil {
+		t.errors.Add(ctx, 1, attributes)
+	}
+	t.duration.Add(ctx, time.Since(start).Seconds(), attributes)
+}
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+func telemetryDataPoints(values map[int]int64, key string) []metricdata.DataPoint[int64] {
+	var dataPoints []metricdata.DataPoint[int64]
+	for index := range 3 {
+		value, ok := values[index]
+		if !ok {
+			continue
+		}
+		dataPoints = append(dataPoints, metricdata.DataPoint[int64]{
+			Attributes: attribute.NewSet(attribute.String("sequence", "test"), attribute.Int(key, index)),
+			Value:      value,
+		})
+	}
+	return dataPoints
+}
+
+func assertTelemetryCounter(t *testing.T, tel *componenttest.Telemetry, name, description, unit, key string, values map[int]int64) {
+	t.Helper()
+	got, err := tel.GetMetric(name)
+	require.NoError(t, err)
+	metricdatatest.AssertEqual(t,
+		metricdata.Metrics{
+			Name:        name,
+			Description: description,
+			Unit:        unit,
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints:  telemetryDataPoints(values, key),
+			},
+		}, got, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+}
+
+func Test_StatementSequence_Telemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) }) //nolint:usetesting
+	settings := tel.NewTelemetrySettings()
+
+	newStatement := func(sourceIndex int, condition boolExpressionEvaluator[any], function ExprFunc[any]) *Statement[any] {
+		return &Statement[any]{
+			condition:         BoolExpr[any]{condition},
+			function:          Expr[any]{exprFunc: function},
+			telemetrySettings: settings,
+			sourceIndex:       sourceIndex,
+		}
+	}
+	noop := func(context.Context, any) (any, error) {
+		return nil, nil
+	}
+
+	statements := NewStatementSequence(
+		[]*Statement[any]{
+			newStatement(0, alwaysTrue[any], noop),
+			newStatement(1, alwaysFalse[any], noop),
+			newStatement(2, alwaysTrue[any], func(context.Context, any) (any, error) {
+				return nil, errors.New("test")
+			}),
+		},
+		settings,
+		WithStatementSequenceErrorMode[any](IgnoreError),
+		WithStatementSequenceTelemetry[any](attribute.String("sequence", "test")),
+	)
+
+	for range 2 {
+		assert.NoError(t, statements.Execute(t.Context(), nil))
+	}
+
+	assertTelemetryCounter(t, tel, "otelcol_ottl_statement_executions", "Number of executions of each OTTL statement.", "{executions}", "ottl.statement.index", map[int]int64{0: 2, 1: 2, 2: 2})
+	assertTelemetryCounter(t, tel, "otelcol_ottl_statement_matches", "Number of matches of each OTTL statement.", "{matches}", "ottl.statement.index", map[int]int64{0: 2, 2: 2})
+	assertTelemetryCounter(t, tel, "otelcol_ottl_statement_errors", "Number of errors of each OTTL statement.", "{errors}", "ottl.statement.index", map[int]int64{2: 2})
+
+	duration, err := tel.GetMetric("otelcol_ottl_statement_duration")
+	require.NoError(t, err)
+	assert.Equal(t, "s", duration.Unit)
+	assert.Len(t, duration.Data.(metricdata.Sum[float64]).DataPoints, 3)
+}
+
+func Test_StatementSequence_Telemetry_MacroExpansion(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) }) //nolint:usetesting
+	settings := tel.NewTelemetrySettings()
+
+	newStatement := func(sourceIndex int) *Statement[any] {
+		return &Statement[any]{
+			condition: BoolExpr[any]{alwaysTrue[any]},
+			function: Expr[any]{exprFunc: func(context.Context, any) (any, error) {
+				return nil, nil
+			}},
+			telemetrySettings: settings,
+			sourceIndex:       sourceIndex,
+		}
+	}
+
+	// the first two statements are the expansion of a macro invoked by the first configured statement
+	statements := NewStatementSequence(
+		[]*Statement[any]{newStatement(0), newStatement(0), newStatement(1)},
+		settings,
+		WithStatementSequenceTelemetry[any](attribute.String("sequence", "test")),
+	)
+	assert.NoError(t, statements.Execute(t.Context(), nil))
+
+	assertTelemetryCounter(t, tel, "otelcol_ottl_statement_executions", "Number of executions of each OTTL statement.", "{executions}", "ottl.statement.index", map[int]int64{0: 2, 1: 1})
+}
+
+func Test_ConditionSequence_Telemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) }) //nolint:usetesting
+	settings := tel.NewTelemetrySettings()
+
+	conditions := NewConditionSequence(
+		[]*Condition[any]{
+			{condition: BoolExpr[any]{func(context.Context, any) (bool, error) {
+				return false, errors.New("test")
+			}}},
+			{condition: BoolExpr[any]{alwaysFalse[any]}},
+			{condition: BoolExpr[any]{alwaysTrue[any]}},
+		},
+		settings,
+		WithConditionSequenceErrorMode[any](IgnoreError),
+		WithConditionSequenceTelemetry[any](attribute.String("sequence", "test")),
+	)
+
+	for range 2 {
+		match, err := conditions.Eval(t.Context(), nil)
+		assert.NoError(t, err)
+		assert.True(t, match)
+	}
+
+	assertTelemetryCounter(t, tel, "otelcol_ottl_condition_evaluations", "Number of evaluations of each OTTL condition.", "{evaluations}", "ottl.condition.index", map[int]int64{0: 2, 1: 2, 2: 2})
+	assertTelemetryCounter(t, tel, "otelcol_ottl_condition_matches", "Number of matches of each OTTL condition.", "{matches}", "ottl.condition.index", map[int]int64{2: 2})
+	assertTelemetryCounter(t, tel, "otelcol_ottl_condition_errors", "Number of errors of each OTTL condition.", "{errors}", "ottl.condition.index", map[int]int64{0: 2})
+}
+
+func Test_StatementSequence_Telemetry_Disabled(t *testing.T) {
+	statements := NewStatementSequence([]*Statement[any]{}, componenttest.NewNopTelemetrySettings())
+	assert.Nil(t, statements.telemetry)
+
+	// without a MeterProvider, the telemetry can't be recorded
+	settings := componenttest.NewNopTelemetrySettings()
+	settings.MeterProvider = nil
+	statements = NewStatementSequence([]*Statement[any]{}, settings, WithStatementSequenceTelemetry[any]())
+	assert.Nil(t, statements.telemetry)
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// FieldChange is a change made by a statement to a field of a TransformContext. The path of the field
+// is made of the names of the nested objects, separated by dots, and of the indexes of the arrays
+// items, as in `log_record.attributes.http.headers[0]`. Before is nil when the field was added, and
+// After is nil when the field was removed.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type FieldChange struct {
+	Path   string
+	Before any
+	After  any
+}
+
+// MarshalLogObject serializes the FieldChange into a zapcore.ObjectEncoder for logging.
+func (c FieldChange) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	encoder.AddString("path", c.Path)
+	return errors.Join(encoder.AddReflected("before", c.Before), encoder.AddReflected("after", c.After))
+}
+
+type fieldChanges []FieldChange
+
+func (c fieldChanges) MarshalLogArray(encoder zapcore.ArrayEncoder) error {
+	var errs error
+	for _, change := range c {
+		errs = errors.Join(errs, encoder.AppendObject(change))
+	}
+	return errs
+}
+
+// StatementTrace is the trace of the execution of a statement.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type StatementTrace struct {
+	// Index is the index of the statement in its StatementSequence.
+	Index     int
+	Statement string
+	// Matched reports whether the condition of the statement matched, and so whether its function was executed.
+	Matched bool
+	Error   error
+	// Changes are the changes the statement made to the TransformContext. They are only recorded when the
+	// TransformContext implements zapcore.ObjectMarshaler, which is the case of all the OTTL contexts.
+	Changes []FieldChange
+}
+
+// MarshalLogObject serializes the StatementTrace into a zapcore.ObjectEncoder for logging.
+func (t StatementTrace) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	encoder.AddInt("index", t.Index)
+	encoder.AddString("statement", t.Statement)
+	encoder.AddBool("matched", t.Matched)
+	if t.Error != nil {
+		encoder.AddString("error", t.Error.Error())
+	}
+	if len(t.Changes) > 0 {
+		return encoder.AddArray("changes", fieldChanges(t.Changes))
+	}
+	return nil
+}
+
+// SequenceTrace is the trace of the execution of a StatementSequence for a TransformContext.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type SequenceTrace struct {
+	Statements []StatementTrace
+}
+
+// MarshalLogArray serializes the traces of the statements into a zapcore.ArrayEncoder for logging.
+func (t SequenceTrace) MarshalLogArray(encoder zapcore.ArrayEncoder) error {
+	var errs error
+	for _, statement := range t.Statements {
+		errs = errors.Join(errs, encoder.AppendObject(statement))
+	}
+	return errs
+}
+
+// TraceHandler receives the traces of the sampled executions of a StatementSequence.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type TraceHandler func(ctx context.Context, trace SequenceTrace)
+
+// NewLoggerTraceHandler returns a TraceHandler logging the traces with the given logger, at the info level.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func NewLoggerTraceHandler(logger *zap.Logger) TraceHandler {
+	return func(_ context.Context, trace SequenceTrace) {
+		logger.Info("OTTL statement sequence trace", zap.Array("statements", trace))
+	}
+}
+
+// sequenceTracer samples the executions of a sequence to trace.
+type sequenceTracer struct {
+	sampleEvery uint64
+	executions  atomic.Uint64
+	handler     TraceHandler
+}
+
+func newSequenceTracer(sampleEvery int, handler TraceHandler) *sequenceTracer {
+	return &sequenceTracer{sampleEvery: uint64(max(sampleEvery, 1)), handler: handler}
+}
+
+// sample reports whether the current execution must be traced.
+func (t *sequenceTracer) sample() bool {
+	return (t.executions.Add(1)-1)%t.sampleEvery == 0
+}
+
+// record adds the trace of a statement to the sequence trace. When the statement matched, the changes
+// it made are computed from the snapshot of the TransformContext taken before its execution. The
+// snapshot of the TransformContext after its execution is returned.
+func (t *SequenceTrace) record(index int, statement string, matched bool, err error, before map[string]any, tCtx any) map[string]any {
+	trace := StatementTrace{
+		Index:     index,
+		Statement: statement,
+		Matched:   matched,
+		Error:     err,
+	}
+	after := before
+	if matched && before != nil {
+		after = snapshotTransformContext(tCtx)
+		trace.Changes = diffSnapshots(before, after)
+	}
+	t.Statements = append(t.Statements, trace)
+	return after
+}
+
+// snapshotTransformContext returns the fields of the TransformContext, by path, or nil if the TransformContext
+// doesn't implement zapcore.ObjectMarshaler.
+func snapshotTransformContext(tCtx any) map[string]any {
+	marshaler, ok := tCtx.(zapcore.ObjectMarshaler)
+	if !ok {
+		return nil
+	}
+	encoder := zapcore.NewMapObjectEncoder()
+	// The fields marshaled before an error are still worth comparing.
+	_ = marshaler.MarshalLogObject(encoder)
+	fields := map[string]any{}
+	flattenSnapshot("", encoder.Fields, fields)
+	return fields
+}
+
+func flattenSnapshot(path string, value any, fields map[string]any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if path == "" {
+				flattenSnapshot(key, item, fields)
+			} else {
+				flattenSnapshot(path+"."+key, item, fields)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			flattenSnapshot(fmt.Sprintf("%s[%d]", path, i), item, fields)
+		}
+	default:
+		fields[path] = v
+	}
+}
+
+// diffSnapshots returns the changes between two snapshots, sorted by path.
+func diffSnapshots(before, after map[string]any) []FieldChange {
+	var changes []FieldChange
+	for path, afterValue := range after {
+		beforeValue, ok := before[path]
+		if !ok || !reflect.DeepEqual(beforeValue, afterValue) {
+			changes = append(changes, FieldChange{Path: path, Before: beforeValue, After: afterValue})
+		}
+	}
+	for path, beforeValue := range before {
+		if _, ok := after[path]; !ok {
+			changes = append(changes, FieldChange{Path: path, Before: beforeValue})
+		}
+	}
+	slices.SortFunc(changes, func(a, b FieldChange) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return changes
+}
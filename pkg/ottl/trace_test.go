@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type traceTestContext struct {
+	name       string
+	attributes map[string]any
+	tags       []string
+}
+
+func (c *traceTestContext) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	encoder.AddString("name", c.name)
+	err := encoder.AddObject("attributes", zapcore.ObjectMarshalerFunc(func(encoder zapcore.ObjectEncoder) error {
+		for key, value := range c.attributes {
+			encoder.AddString(key, value.(string))
+		}
+		return nil
+	}))
+	return errors.Join(err, encoder.AddArray("tags", zapcore.ArrayMarshalerFunc(func(encoder zapcore.ArrayEncoder) error {
+		for _, tag := range c.tags {
+			encoder.AppendString(tag)
+		}
+		return nil
+	})))
+}
+
+func traceTestStatement(text string, condition bool, function func(*traceTestContext) error) *Statement[*traceTestContext] {
+	return &Statement[*traceTestContext]{
+		condition: BoolExpr[*traceTestContext]{func(context.Context, *traceTestContext) (bool, error) {
+			return condition, nil
+		}},
+		function: Expr[*traceTestContext]{exprFunc: func(_ context.Context, tCtx *traceTestContext) (any, error) {
+			return nil, function(tCtx)
+		}},
+		origText:          text,
+		telemetrySettings: componenttest.NewNopTelemetrySettings(),
+	}
+}
+
+func Test_StatementSequence_Tracing(t *testing.T) {
+	var traces []SequenceTrace
+	handler := func(_ context.Context, trace SequenceTrace) {
+		traces = append(traces, trace)
+	}
+
+	statements := NewStatementSequence(
+		[]*Statement[*traceTestContext]{
+			traceTestStatement(`set(name, "b")`, true, func(tCtx *traceTestContext) error {
+				tCtx.name = "b"
+				return nil
+			}),
+			traceTestStatement(`set(attributes["x"], "1") where false`, false, func(tCtx *traceTestContext) error {
+				tCtx.attributes["x"] = "1"
+				return nil
+			}),
+			traceTestStatement(`edit_attributes()`, true, func(tCtx *traceTestContext) error {
+				tCtx.attributes["added"] = "new"
+				tCtx.attributes["changed"] = "after"
+				delete(tCtx.attributes, "removed")
+				tCtx.tags = append(tCtx.tags, "t2")
+				return nil
+			}),
+			traceTestStatement(`fail()`, true, func(*traceTestContext) error {
+				return errors.New("failure")
+			}),
+		},
+		componenttest.NewNopTelemetrySettings(),
+		WithStatementSequenceErrorMode[*traceTestContext](IgnoreError),
+		WithStatementSequenceTracing[*traceTestContext](2, handler),
+	)
+
+	newContext := func() *traceTestContext {
+		return &traceTestContext{
+			name:       "a",
+			attributes: map[string]any{"changed": "before", "removed": "value"},
+			tags:       []string{"t1"},
+		}
+	}
+	for range 3 {
+		require.NoError(t, statements.Execute(t.Context(), newContext()))
+	}
+
+	// the first and the third executions are sampled
+	require.Len(t, traces, 2)
+	assert.Equal(t, traces[0], traces[1])
+	assert.Equal(t, SequenceTrace{Statements: []StatementTrace{
+		{
+			Index:     0,
+			Statement: `set(name, "b")`,
+			Matched:   true,
+			Changes:   []FieldChange{{Path: "name", Before: "a", After: "b"}},
+		},
+		{
+			Index:     1,
+			Statement: `set(attributes["x"], "1") where false`,
+		},
+		{
+			Index:     2,
+			Statement: `edit_attributes()`,
+			Matched:   true,
+			Changes: []FieldChange{
+				{Path: "attributes.added", After: "new"},
+				{Path: "attributes.changed", Before: "before", After: "after"},
+				{Path: "attributes.removed", Before: "value"},
+				{Path: "tags[1]", After: "t2"},
+			},
+		},
+		{
+			Index:     3,
+			Statement: `fail()`,
+			Matched:   true,
+			Error:     errors.New("failure"),
+		},
+	}}, traces[0])
+}
+
+func Test_StatementSequence_Tracing_NotMarshaler(t *testing.T) {
+	var traces []SequenceTrace
+	statements := NewStatementSequence(
+		[]*Statement[any]{
+			{
+				condition: BoolExpr[any]{alwaysTrue[any]},
+				function: Expr[any]{exprFunc: func(context.Context, any) (any, error) {
+					return nil, nil
+				}},
+				origText:          `noop()`,
+				telemetrySettings: componenttest.NewNopTelemetrySettings(),
+			},
+		},
+		componenttest.NewNopTelemetrySettings(),
+		WithStatementSequenceTracing[any](1, func(_ context.Context, trace SequenceTrace) {
+			traces = append(traces, trace)
+		}),
+	)
+
+	require.NoError(t, statements.Execute(t.Context(), map[string]any{}))
+	assert.Equal(t, []SequenceTrace{{Statements: []StatementTrace{{Statement: `noop()`, Matched: true}}}}, traces)
+}
+
+func Test_StatementSequence_Tracing_Logger(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	settings := componenttest.NewNopTelemetrySettings()
+	settings.Logger = zap.New(core)
+
+	statements := NewStatementSequence(
+		[]*Statement[*traceTestContext]{
+			traceTestStatement(`set(name, "b")`, true, func(tCtx *traceTestContext) error {
+				tCtx.name = "b"
+				return nil
+			}),
+		},
+		settings,
+		WithStatementSequenceTracing[*traceTestContext](1, nil),
+	)
+	require.NoError(t, statements.Execute(t.Context(), &traceTestContext{name: "a"}))
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "OTTL statement sequence trace", entry.Message)
+	assert.Equal(t, []any{
+		map[string]any{
+			"index":     int64(0),
+			"statement": `set(name, "b")`,
+			"matched":   true,
+			"changes": []any{
+				map[string]any{"path": "name", "before": "a", "after": "b"},
+			},
+		},
+	}, entry.ContextMap()["statements"])
+}
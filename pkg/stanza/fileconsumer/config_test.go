@@ -316,6 +316,14 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "multiline_auto",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.SplitConfig.Auto = true
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "multiline_line_start_string",
 				Expect: func() *mockOperatorConfig {
@@ -582,6 +590,22 @@ func TestBuild(t *testing.T) {
 				require.Equal(t, 6, m.maxBatches)
 			},
 		},
+		{
+			"ValidCompression",
+			func(cfg *Config) {
+				cfg.Compression = "zstd"
+			},
+			require.NoError,
+			nil,
+		},
+		{
+			"InvalidCompression",
+			func(cfg *Config) {
+				cfg.Compression = "lz4"
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"HeaderConfigNoFlag",
 			func(cfg *Config) {
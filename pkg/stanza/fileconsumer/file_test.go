@@ -15,8 +15,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/ulikunitz/xz"
 	"go.opentelemetry.io/collector/featuregate"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
@@ -1607,6 +1609,72 @@ func TestReadGzipCompressedLogsFromEnd(t *testing.T) {
 	sink.ExpectToken(t, []byte("testlog4"))
 }
 
+// TestReadZstdCompressedLogsFromEnd tests that zstd frames appended to a compressed file
+// are read from the offset at which the previous frames ended
+func TestReadZstdCompressedLogsFromEnd(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = "zstd"
+	cfg.StartAt = "end"
+	operator, sink := testManager(t, cfg)
+
+	temp := filetest.OpenTempWithPattern(t, tempDir, "*.zst")
+
+	appendToLog := func(t *testing.T, content string) {
+		writer, err := zstd.NewWriter(temp)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+	}
+
+	appendToLog(t, "testlog1\ntestlog2\n")
+	operator.poll(t.Context())
+
+	appendToLog(t, "testlog3\n")
+	operator.poll(t.Context())
+	sink.ExpectToken(t, []byte("testlog3"))
+
+	appendToLog(t, "testlog4\n")
+	operator.poll(t.Context())
+	sink.ExpectToken(t, []byte("testlog4"))
+	sink.ExpectNoCalls(t)
+}
+
+// TestReadAutoDetectedCompressedLogs tests that the compression format of each file is
+// detected by its magic bytes, regardless of its extension
+func TestReadAutoDetectedCompressedLogs(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = "auto"
+	cfg.StartAt = "beginning"
+	operator, sink := testManager(t, cfg)
+
+	zstdFile := filetest.OpenTempWithPattern(t, tempDir, "app.log.1")
+	zstdWriter, err := zstd.NewWriter(zstdFile)
+	require.NoError(t, err)
+	_, err = zstdWriter.Write([]byte("zstd log\n"))
+	require.NoError(t, err)
+	require.NoError(t, zstdWriter.Close())
+
+	xzFile := filetest.OpenTempWithPattern(t, tempDir, "app.log.2")
+	xzWriter, err := xz.NewWriter(xzFile)
+	require.NoError(t, err)
+	_, err = xzWriter.Write([]byte("xz log\n"))
+	require.NoError(t, err)
+	require.NoError(t, xzWriter.Close())
+
+	plainFile := filetest.OpenTempWithPattern(t, tempDir, "app.log")
+	filetest.WriteString(t, plainFile, "plain log\n")
+
+	operator.poll(t.Context())
+	sink.ExpectTokens(t, []byte("zstd log"), []byte("xz log"), []byte("plain log"))
+}
+
 func TestArchive(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("Time sensitive tests disabled for now on Windows. See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/32715#issuecomment-2107737828")
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package compression // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const (
+	Gzip  = "gzip"
+	Zstd  = "zstd"
+	Xz    = "xz"
+	Bzip2 = "bzip2"
+	// Auto detects the format of each file, by its magic bytes or its extension.
+	Auto = "auto"
+)
+
+var magicBytes = []struct {
+	format string
+	magic  []byte
+}{
+	{format: Gzip, magic: []byte{0x1f, 0x8b}},
+	{format: Zstd, magic: []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{format: Xz, magic: []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}},
+	{format: Bzip2, magic: []byte{'B', 'Z', 'h'}},
+}
+
+var extensions = map[string]string{
+	".gz":   Gzip,
+	".zst":  Zstd,
+	".zstd": Zstd,
+	".xz":   Xz,
+	".bz2":  Bzip2,
+}
+
+// IsValid returns whether the value is a valid compression setting.
+func IsValid(compression string) bool {
+	switch compression {
+	case "", Gzip, Zstd, Xz, Bzip2, Auto:
+		return true
+	default:
+		return false
+	}
+}
+
+// Detect returns the compression format of the file, identified by the magic bytes at its beginning,
+// or by its extension if they are not recognized. An empty string is returned for uncompressed files.
+func Detect(file *os.File) (string, error) {
+	buf := make([]byte, 6)
+	n, err := file.ReadAt(buf, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("reading magic bytes: %w", err)
+	}
+	for _, m := range magicBytes {
+		if bytes.HasPrefix(buf[:n], m.magic) {
+			return m.format, nil
+		}
+	}
+	return FromExtension(file.Name()), nil
+}
+
+// FromExtension returns the compression format identified by the extension of the file name,
+// or an empty string if the extension is not one of a compressed file.
+func FromExtension(name string) string {
+	return extensions[filepath.Ext(name)]
+}
+
+// NewReader returns a reader decompressing the data of the given format. Concatenated compressed
+// streams are decompressed one after the other. The reader must be closed to release its resources.
+func NewReader(format string, r io.Reader) (io.ReadCloser, error) {
+	switch format {
+	case Gzip:
+		return gzip.NewReader(r)
+	case Zstd:
+		d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	case Xz:
+		xr, err := xz.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(xr), nil
+	case Bzip2:
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression format %q", format)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ulikunitz/xz"
+)
+
+// compress returns the data compressed as a single stream of the given format.
+func compress(t *testing.T, format string, data []byte) []byte {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	var err error
+	switch format {
+	case Gzip:
+		w = gzip.NewWriter(&buf)
+	case Zstd:
+		w, err = zstd.NewWriter(&buf)
+	case Xz:
+		w, err = xz.NewWriter(&buf)
+	default:
+		t.Fatalf("no writer for format %q", format)
+	}
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestNewReader(t *testing.T) {
+	bzip2Streams, err := os.ReadFile(filepath.Join("testdata", "two_streams.bz2"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		format string
+		data   []byte
+	}{
+		{
+			format: Gzip,
+			data:   append(compress(t, Gzip, []byte("first line\n")), compress(t, Gzip, []byte("second line\n"))...),
+		},
+		{
+			format: Zstd,
+			data:   append(compress(t, Zstd, []byte("first line\n")), compress(t, Zstd, []byte("second line\n"))...),
+		},
+		{
+			format: Xz,
+			data:   append(compress(t, Xz, []byte("first line\n")), compress(t, Xz, []byte("second line\n"))...),
+		},
+		{
+			format: Bzip2,
+			data:   bzip2Streams,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			r, err := NewReader(tc.format, bytes.NewReader(tc.data))
+			require.NoError(t, err)
+			decompressed, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, "first line\nsecond line\n", string(decompressed))
+			require.NoError(t, r.Close())
+		})
+	}
+}
+
+func TestNewReaderUnsupported(t *testing.T) {
+	_, err := NewReader("lz4", bytes.NewReader(nil))
+	assert.EqualError(t, err, `unsupported compression format "lz4"`)
+}
+
+func TestDetect(t *testing.T) {
+	bzip2Streams, err := os.ReadFile(filepath.Join("testdata", "two_streams.bz2"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		fileName string
+		data     []byte
+		expected string
+	}{
+		{
+			name:     "gzip",
+			fileName: "app.log",
+			data:     compress(t, Gzip, []byte("line\n")),
+			expected: Gzip,
+		},
+		{
+			name:     "zstd",
+			fileName: "app.log.1",
+			data:     compress(t, Zstd, []byte("line\n")),
+			expected: Zstd,
+		},
+		{
+			name:     "xz",
+			fileName: "app.log",
+			data:     compress(t, Xz, []byte("line\n")),
+			expected: Xz,
+		},
+		{
+			name:     "bzip2",
+			fileName: "app.log",
+			data:     bzip2Streams,
+			expected: Bzip2,
+		},
+		{
+			name:     "uncompressed",
+			fileName: "app.log",
+			data:     []byte("line\n"),
+			expected: "",
+		},
+		{
+			name:     "extension",
+			fileName: "app.log.zst",
+			data:     []byte{},
+			expected: Zstd,
+		},
+		{
+			name:     "magic bytes before extension",
+			fileName: "app.log.gz",
+			data:     compress(t, Xz, []byte("line\n")),
+			expected: Xz,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.fileName)
+			require.NoError(t, os.WriteFile(path, tc.data, 0o600))
+			file, err := os.Open(path)
+			require.NoError(t, err)
+			defer file.Close()
+
+			format, err := Detect(file)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, format)
+		})
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	for _, c := range []string{"", Gzip, Zstd, Xz, Bzip2, Auto} {
+		assert.True(t, IsValid(c), c)
+	}
+	assert.False(t, IsValid("lz4"))
+}
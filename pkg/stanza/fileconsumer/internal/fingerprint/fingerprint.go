@@ -5,15 +5,16 @@ package fingerprint // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"bytes"
-	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
-	"path/filepath"
 
 	"go.opentelemetry.io/collector/featuregate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
 )
 
 const DefaultSize = 1000 // bytes
@@ -42,22 +43,24 @@ func New(first []byte) *Fingerprint {
 // Set decompressData to true to compute fingerprint of compressed files by decompressing its data first
 func NewFromFile(file *os.File, size int, decompressData bool) (*Fingerprint, error) {
 	buf := make([]byte, size)
-	if DecompressedFingerprintFeatureGate.IsEnabled() {
-		if decompressData {
-			if hasGzipExtension(file.Name()) {
-				// If the file is of compressed type, uncompress the data before creating its fingerprint
-				uncompressedData, err := gzip.NewReader(file)
-				if err != nil {
-					return nil, fmt.Errorf("error uncompressing gzip file: %w", err)
-				}
-				defer uncompressedData.Close()
-
-				n, err := uncompressedData.Read(buf)
-				if err != nil && !errors.Is(err, io.EOF) {
-					return nil, fmt.Errorf("error reading fingerprint bytes: %w", err)
-				}
-				return New(buf[:n]), nil
+	if DecompressedFingerprintFeatureGate.IsEnabled() && decompressData {
+		format, err := compression.Detect(file)
+		if err != nil {
+			return nil, err
+		}
+		if format != "" {
+			// If the file is of compressed type, uncompress the data before creating its fingerprint
+			uncompressedData, err := compression.NewReader(format, io.NewSectionReader(file, 0, math.MaxInt64))
+			if err != nil {
+				return nil, fmt.Errorf("error uncompressing %s file: %w", format, err)
+			}
+			defer uncompressedData.Close()
+
+			n, err := io.ReadFull(uncompressedData, buf)
+			if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("error reading fingerprint bytes: %w", err)
 			}
+			return New(buf[:n]), nil
 		}
 	}
 
@@ -68,10 +71,6 @@ func NewFromFile(file *os.File, size int, decompressData bool) (*Fingerprint, er
 	return New(buf[:n]), nil
 }
 
-func hasGzipExtension(filename string) bool {
-	return filepath.Ext(filename) == ".gz"
-}
-
 // Copy creates a new copy of the fingerprint
 func (f Fingerprint) Copy() *Fingerprint {
 	buf := make([]byte, len(f.firstBytes), cap(f.firstBytes))
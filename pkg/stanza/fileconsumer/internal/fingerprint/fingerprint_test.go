@@ -12,6 +12,8 @@ import (
 	"os"
 	"testing"
 
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/filetest"
@@ -337,3 +339,26 @@ func TestCompressionFingerprint(t *testing.T) {
 	uncompressedFP := New(data)
 	uncompressedFP.Equal(compressedFP)
 }
+
+func TestZstdCompressionFingerprint(t *testing.T) {
+	tmp := t.TempDir()
+	compressedFile := filetest.OpenTempWithPattern(t, tmp, "*.zst")
+	zstdWriter, err := zstd.NewWriter(compressedFile)
+	require.NoError(t, err)
+
+	data := []byte("this is a first test line")
+	_, err = zstdWriter.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zstdWriter.Close())
+	offset, err := compressedFile.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+
+	compressedFP, err := NewFromFile(compressedFile, len(data), true)
+	require.NoError(t, err)
+	assert.True(t, New(data).Equal(compressedFP))
+
+	// the fingerprint is computed without moving the file offset
+	newOffset, err := compressedFile.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, offset, newOffset)
+}
@@ -5,7 +5,6 @@ package reader // import "github.com/open-telemetry/opentelemetry-collector-cont
 
 import (
 	"bufio"
-	"compress/gzip"
 	"context"
 	"errors"
 	"io"
@@ -18,6 +17,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/textutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/scanner"
@@ -25,7 +25,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/tokenlen"
 )
 
-const gzipExtension = ".gz"
+// legacyGzipFileType is the file type of gzip files in the metadata persisted by previous versions
+const legacyGzipFileType = ".gz"
 
 type Metadata struct {
 	Fingerprint     *fingerprint.Fingerprint
@@ -70,33 +71,18 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		defer r.unlockFile()
 	}
 
-	switch r.compression {
-	case "gzip":
-		currentEOF, err := r.createGzipReader()
+	if format := r.compressionFormat(); format != "" {
+		currentEOF, err := r.createDecompressor(format)
 		if err != nil {
 			return
 		}
 		// Offset tracking in an uncompressed file is based on the length of emitted tokens, but in this case
-		// we need to set the offset to the end of the file.
+		// we need to set the offset to the end of the file. Appended compressed streams start at this offset.
 		defer func() {
 			r.Offset = currentEOF
+			r.closeDecompressor()
 		}()
-	case "auto":
-		// Identifying a filename by its extension may not always be correct. We could have a compressed file without the .gz extension
-		if r.FileType == gzipExtension {
-			currentEOF, err := r.createGzipReader()
-			if err != nil {
-				return
-			}
-			// Offset tracking in an uncompressed file is based on the length of emitted tokens, but in this case
-			// we need to set the offset to the end of the file.
-			defer func() {
-				r.Offset = currentEOF
-			}()
-		} else {
-			r.reader = r.file
-		}
-	default:
+	} else {
 		r.reader = r.file
 	}
 
@@ -120,9 +106,30 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 	r.readContents(ctx)
 }
 
-// createGzipReader creates gzip reader and returns the file offset
-func (r *Reader) createGzipReader() (int64, error) {
-	// We need to create a gzip reader each time ReadToEnd is called because the underlying
+// compressionFormat returns the compression format of the file, or an empty string if it isn't compressed
+func (r *Reader) compressionFormat() string {
+	if r.compression != compression.Auto {
+		return r.compression
+	}
+	if r.FileType == "" && r.Offset == 0 {
+		// The file may have been too short to detect its type when the reader was created
+		filetype, err := compression.Detect(r.file)
+		if err != nil {
+			r.set.Logger.Debug("failed to detect compression", zap.Error(err))
+		}
+		r.FileType = filetype
+	}
+	// Identifying a file by its extension may not always be correct, so the file type
+	// is detected by the magic bytes at the beginning of the file.
+	if r.FileType == legacyGzipFileType {
+		return compression.Gzip
+	}
+	return r.FileType
+}
+
+// createDecompressor creates a reader decompressing the file and returns the file offset
+func (r *Reader) createDecompressor(format string) (int64, error) {
+	// We need to create a decompressor each time ReadToEnd is called because the underlying
 	// SectionReader can only read a fixed window (from previous offset to EOF).
 	info, err := r.file.Stat()
 	if err != nil {
@@ -130,19 +137,28 @@ func (r *Reader) createGzipReader() (int64, error) {
 		return 0, err
 	}
 	currentEOF := info.Size()
-	// use a gzip Reader with an underlying SectionReader to pick up at the last
-	// offset of a gzip compressed file
-	gzipReader, err := gzip.NewReader(io.NewSectionReader(r.file, r.Offset, currentEOF))
+	// use a decompressor with an underlying SectionReader to pick up at the last
+	// offset of a compressed file
+	decompressor, err := compression.NewReader(format, io.NewSectionReader(r.file, r.Offset, currentEOF-r.Offset))
 	if err != nil {
 		if !errors.Is(err, io.EOF) {
-			r.set.Logger.Error("failed to create gzip reader", zap.Error(err))
+			r.set.Logger.Error("failed to create decompressor", zap.String("compression", format), zap.Error(err))
 		}
 		return 0, err
 	}
-	r.reader = gzipReader
+	r.reader = decompressor
 	return currentEOF, nil
 }
 
+func (r *Reader) closeDecompressor() {
+	if c, ok := r.reader.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			r.set.Logger.Debug("failed to close decompressor", zap.Error(err))
+		}
+	}
+	r.reader = nil
+}
+
 func (r *Reader) readHeader(ctx context.Context) (doneReadingFile bool) {
 	bufPtr := r.getBufPtrFromPool()
 	defer r.bufPool.Put(bufPtr)
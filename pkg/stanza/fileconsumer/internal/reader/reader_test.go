@@ -4,6 +4,7 @@
 package reader
 
 import (
+	"compress/gzip"
 	"context"
 	"fmt"
 	"os"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/scanner"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/filetest"
@@ -48,6 +50,34 @@ func TestFileReader_FingerprintUpdated(t *testing.T) {
 	require.Equal(t, fingerprint.New([]byte("testlog1\n")), reader.Fingerprint)
 }
 
+func TestFileReader_CompressionDetectedOnceWritten(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	temp := filetest.OpenTemp(t, tempDir)
+	tempCopy := filetest.OpenFile(t, temp.Name())
+
+	f, sink := testFactory(t)
+	f.Compression = compression.Auto
+	fp, err := f.NewFingerprint(temp)
+	require.NoError(t, err)
+
+	// the file is empty when the reader is created, its type is unknown
+	reader, err := f.NewReader(tempCopy, fp)
+	require.NoError(t, err)
+	defer reader.Close()
+	require.Empty(t, reader.FileType)
+
+	gzipWriter := gzip.NewWriter(temp)
+	_, err = gzipWriter.Write([]byte("testlog1\n"))
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	reader.ReadToEnd(t.Context())
+	sink.ExpectToken(t, []byte("testlog1"))
+	require.Equal(t, compression.Gzip, reader.FileType)
+}
+
 // Test that a fingerprint:
 // - Starts empty
 // - Updates as a file is read
@@ -107,6 +137,34 @@ func TestFingerprintGrowsAndStops(t *testing.T) {
 }
 
 // This is same test like TestFingerprintGrowsAndStops, but with additional check for fingerprint size check
+func TestFileReader_CompressionDetectedOnceWritten(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	temp := filetest.OpenTemp(t, tempDir)
+	tempCopy := filetest.OpenFile(t, temp.Name())
+
+	f, sink := testFactory(t)
+	f.Compression = compression.Auto
+	fp, err := f.NewFingerprint(temp)
+	require.NoError(t, err)
+
+	// the file is empty when the reader is created, its type is unknown
+	reader, err := f.NewReader(tempCopy, fp)
+	require.NoError(t, err)
+	defer reader.Close()
+	require.Empty(t, reader.FileType)
+
+	gzipWriter := gzip.NewWriter(temp)
+	_, err = gzipWriter.Write([]byte("testlog1\n"))
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	reader.ReadToEnd(t.Context())
+	sink.ExpectToken(t, []byte("testlog1"))
+	require.Equal(t, compression.Gzip, reader.FileType)
+}
+
 // Test that a fingerprint:
 // - Starts empty
 // - Updates as a file is read
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/cef"
+
+import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/internal/secnaming"
+
+const (
+	// NamingRaw keeps the CEF names of the header fields and extensions
+	NamingRaw = secnaming.Raw
+	// NamingECS maps the header fields and well known extensions to Elastic Common Schema fields
+	NamingECS = secnaming.ECS
+	// NamingSemconv maps well known extensions to OpenTelemetry semantic conventions attributes
+	NamingSemconv = secnaming.Semconv
+)
+
+var ecsHeader = map[string]secnaming.Field{
+	"version":        {Name: "cef.version"},
+	"device_vendor":  {Name: "observer.vendor"},
+	"device_product": {Name: "observer.product"},
+	"device_version": {Name: "observer.version"},
+	"signature_id":   {Name: "event.code"},
+	"name":           {Name: "message"},
+	"severity":       {Name: "event.severity", Conversion: secnaming.AsInt},
+}
+
+var ecsExtensions = map[string]secnaming.Field{
+	"act":                      {Name: "event.action"},
+	"app":                      {Name: "network.protocol", Conversion: secnaming.AsLower},
+	"dhost":                    {Name: "destination.domain"},
+	"dmac":                     {Name: "destination.mac"},
+	"dpt":                      {Name: "destination.port", Conversion: secnaming.AsInt},
+	"dst":                      {Name: "destination.ip"},
+	"duser":                    {Name: "destination.user.name"},
+	"dvc":                      {Name: "observer.ip"},
+	"dvchost":                  {Name: "observer.hostname"},
+	"fname":                    {Name: "file.name"},
+	"filePath":                 {Name: "file.path"},
+	"fsize":                    {Name: "file.size", Conversion: secnaming.AsInt},
+	"in":                       {Name: "source.bytes", Conversion: secnaming.AsInt},
+	"out":                      {Name: "destination.bytes", Conversion: secnaming.AsInt},
+	"outcome":                  {Name: "event.outcome"},
+	"proto":                    {Name: "network.transport", Conversion: secnaming.AsLower},
+	"request":                  {Name: "url.original"},
+	"requestClientApplication": {Name: "user_agent.original"},
+	"requestMethod":            {Name: "http.request.method"},
+	"shost":                    {Name: "source.domain"},
+	"smac":                     {Name: "source.mac"},
+	"spt":                      {Name: "source.port", Conversion: secnaming.AsInt},
+	"src":                      {Name: "source.ip"},
+	"suser":                    {Name: "source.user.name"},
+}
+
+var semconvHeader = map[string]secnaming.Field{
+	"version":        {Name: "cef.version"},
+	"device_vendor":  {Name: "cef.device_vendor"},
+	"device_product": {Name: "cef.device_product"},
+	"device_version": {Name: "cef.device_version"},
+	"signature_id":   {Name: "cef.signature_id"},
+	"name":           {Name: "cef.name"},
+	"severity":       {Name: "cef.severity"},
+}
+
+var semconvExtensions = map[string]secnaming.Field{
+	"dpt":                      {Name: "destination.port", Conversion: secnaming.AsInt},
+	"dst":                      {Name: "destination.address"},
+	"fname":                    {Name: "file.name"},
+	"filePath":                 {Name: "file.path"},
+	"fsize":                    {Name: "file.size", Conversion: secnaming.AsInt},
+	"proto":                    {Name: "network.transport", Conversion: secnaming.AsLower},
+	"request":                  {Name: "url.full"},
+	"requestClientApplication": {Name: "user_agent.original"},
+	"requestMethod":            {Name: "http.request.method"},
+	"spt":                      {Name: "source.port", Conversion: secnaming.AsInt},
+	"src":                      {Name: "source.address"},
+	"suser":                    {Name: "user.name"},
+}
+
+var (
+	ecsFields     = secnaming.Fields{Header: ecsHeader, Pairs: ecsExtensions}
+	semconvFields = secnaming.Fields{Header: semconvHeader, Pairs: semconvExtensions}
+)
+
+// rename maps the header fields and extensions of an event to the given naming. Extensions
+// without a well known name are kept under "cef.extensions.<key>".
+func rename(naming string, header []string, extensions map[string]string) map[string]any {
+	fields := ecsFields
+	if naming == NamingSemconv {
+		fields = semconvFields
+	}
+	return fields.Rename(headerKeys, header, extensions, "cef.extensions.")
+}
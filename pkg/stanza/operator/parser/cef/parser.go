@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/cef"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const cefPrefix = "CEF:"
+
+// headerKeys are the names of the fields of a CEF header, in order
+var headerKeys = []string{"version", "device_vendor", "device_product", "device_version", "signature_id", "name", "severity"}
+
+// Parser is an operator that parses ArcSight Common Event Format messages.
+type Parser struct {
+	helper.ParserOperator
+	naming string
+}
+
+func (p *Parser) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return p.ProcessBatchWith(ctx, entries, p.parse)
+}
+
+// Process will parse an entry for a CEF message.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value as a CEF message.
+func (p *Parser) parse(value any) (any, error) {
+	switch m := value.(type) {
+	case string:
+		return p.parser(m)
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as CEF", value)
+	}
+}
+
+func (p *Parser) parser(input string) (map[string]any, error) {
+	if input == "" {
+		return nil, fmt.Errorf("parse from field %s is empty", p.ParseFrom.String())
+	}
+
+	// the message may be prefixed, e.g. by a syslog header
+	start := strings.Index(input, cefPrefix)
+	if start < 0 {
+		return nil, errors.New("missing CEF header")
+	}
+
+	header, extension, err := splitHeader(input[start+len(cefPrefix):])
+	if err != nil {
+		return nil, err
+	}
+	extensions := parseExtension(extension)
+
+	if p.naming != NamingRaw {
+		return rename(p.naming, header, extensions), nil
+	}
+
+	parsed := make(map[string]any, len(header)+1)
+	for i, value := range header {
+		parsed[headerKeys[i]] = value
+	}
+	if len(extensions) > 0 {
+		ext := make(map[string]any, len(extensions))
+		for key, value := range extensions {
+			ext[key] = value
+		}
+		parsed["extensions"] = ext
+	}
+	return parsed, nil
+}
+
+// splitHeader splits the pipe separated fields of a CEF header from the extension that follows
+// them. Pipes and backslashes are escaped with a backslash in the header fields.
+func splitHeader(s string) ([]string, string, error) {
+	fields := make([]string, 0, len(headerKeys))
+	var field strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s) && (s[i+1] == '|' || s[i+1] == '\\'):
+			field.WriteByte(s[i+1])
+			i++
+		case c == '|':
+			fields = append(fields, field.String())
+			field.Reset()
+			if len(fields) == len(headerKeys) {
+				return fields, s[i+1:], nil
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+	if len(fields) == len(headerKeys)-1 {
+		// the trailing pipe may be omitted when there is no extension
+		return append(fields, field.String()), "", nil
+	}
+	return nil, "", fmt.Errorf("expected %d CEF header fields, got %d", len(headerKeys), len(fields)+1)
+}
+
+// parseExtension parses the space separated key=value pairs of a CEF extension. Values may
+// contain spaces, so a value ends where the next key begins. In values, equal signs and
+// backslashes are escaped with a backslash, and \n and \r stand for line breaks.
+func parseExtension(s string) map[string]string {
+	type pair struct{ keyStart, equal int }
+	var pairs []pair
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '=':
+			keyStart := strings.LastIndexByte(s[:i], ' ') + 1
+			if len(pairs) > 0 && keyStart <= pairs[len(pairs)-1].equal+1 {
+				// no space since the previous key, so this is part of its value
+				continue
+			}
+			if !isKey(s[keyStart:i]) {
+				continue
+			}
+			pairs = append(pairs, pair{keyStart: keyStart, equal: i})
+		}
+	}
+
+	extensions := make(map[string]string, len(pairs))
+	for i, p := range pairs {
+		end := len(s)
+		if i+1 < len(pairs) {
+			end = pairs[i+1].keyStart
+		}
+		value := strings.TrimRight(s[p.equal+1:end], " ")
+		extensions[s[p.keyStart:p.equal]] = unescapeValue(value)
+	}
+	return extensions
+}
+
+func isKey(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '_', c == '.', c == '-', c == '[', c == ']':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func unescapeValue(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		switch s[i+1] {
+		case '=', '\\', '|':
+			b.WriteByte(s[i+1])
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(s[i+1])
+		}
+		i++
+	}
+	return b.String()
+}
@@ -0,0 +1,355 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cef
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := config.Build(set)
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("cef_parser")
+	require.True(t, ok, "expected cef_parser to be registered")
+	require.Equal(t, "cef_parser", builder().Type())
+}
+
+func TestConfigBuild(t *testing.T) {
+	config := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := config.Build(set)
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.OnError = "invalid_on_error"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := config.Build(set)
+	require.ErrorContains(t, err, "invalid `on_error` field")
+}
+
+func TestConfigBuildInvalidNaming(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.Naming = "invalid"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := config.Build(set)
+	require.ErrorContains(t, err, `invalid 'naming' "invalid"`)
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.ErrorContains(t, err, "type []int cannot be parsed as CEF")
+}
+
+func TestParserEmptyInput(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("")
+	require.ErrorContains(t, err, "parse from field body is empty")
+}
+
+func TestParserMissingHeader(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|")
+	require.ErrorContains(t, err, "missing CEF header")
+}
+
+func TestParserIncompleteHeader(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("CEF:0|Security|threatmanager|1.0")
+	require.ErrorContains(t, err, "expected 7 CEF header fields, got 4")
+}
+
+func TestCEFImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestParseExtension(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		expect map[string]string
+	}{
+		{
+			"empty",
+			"",
+			map[string]string{},
+		},
+		{
+			"simple",
+			"src=10.0.0.1 dst=10.0.0.2 spt=1232",
+			map[string]string{"src": "10.0.0.1", "dst": "10.0.0.2", "spt": "1232"},
+		},
+		{
+			"spaces-in-value",
+			"msg=Detected a threat. No action needed cs1Label=Rule Name",
+			map[string]string{"msg": "Detected a threat. No action needed", "cs1Label": "Rule Name"},
+		},
+		{
+			"escaped-equal",
+			`msg=a\=b c=d`,
+			map[string]string{"msg": "a=b", "c": "d"},
+		},
+		{
+			"unescaped-equal-in-value",
+			"request=http://example.com/?q=1&r=2 act=blocked",
+			map[string]string{"request": "http://example.com/?q=1&r=2", "act": "blocked"},
+		},
+		{
+			"escaped-backslash",
+			`filePath=C:\\Windows\\system32 act=quarantined`,
+			map[string]string{"filePath": `C:\Windows\system32`, "act": "quarantined"},
+		},
+		{
+			"line-breaks",
+			`msg=first\nsecond\rthird`,
+			map[string]string{"msg": "first\nsecond\rthird"},
+		},
+		{
+			"empty-value",
+			"suser= duser=bob",
+			map[string]string{"suser": "", "duser": "bob"},
+		},
+		{
+			"trailing-spaces",
+			"src=10.0.0.1   dst=10.0.0.2  ",
+			map[string]string{"src": "10.0.0.1", "dst": "10.0.0.2"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, parseExtension(tc.input))
+		})
+	}
+}
+
+func TestParser(t *testing.T) {
+	cases := []struct {
+		name        string
+		configure   func(*Config)
+		input       *entry.Entry
+		expect      *entry.Entry
+		expectError bool
+	}{
+		{
+			"simple",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 spt=1232",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":        "0",
+					"device_vendor":  "Security",
+					"device_product": "threatmanager",
+					"device_version": "1.0",
+					"signature_id":   "100",
+					"name":           "worm successfully stopped",
+					"severity":       "10",
+					"extensions": map[string]any{
+						"src": "10.0.0.1",
+						"dst": "2.1.2.2",
+						"spt": "1232",
+					},
+				},
+				Body: "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 spt=1232",
+			},
+			false,
+		},
+		{
+			"escaped-header",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: `CEF:0|security|threat\|manager|1.0|100|detected a \\ in packet|10|`,
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":        "0",
+					"device_vendor":  "security",
+					"device_product": "threat|manager",
+					"device_version": "1.0",
+					"signature_id":   "100",
+					"name":           `detected a \ in packet`,
+					"severity":       "10",
+				},
+				Body: `CEF:0|security|threat\|manager|1.0|100|detected a \\ in packet|10|`,
+			},
+			false,
+		},
+		{
+			"missing-trailing-pipe",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: "CEF:1|Security|threatmanager|1.0|100|stopped|Low",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":        "1",
+					"device_vendor":  "Security",
+					"device_product": "threatmanager",
+					"device_version": "1.0",
+					"signature_id":   "100",
+					"name":           "stopped",
+					"severity":       "Low",
+				},
+				Body: "CEF:1|Security|threatmanager|1.0|100|stopped|Low",
+			},
+			false,
+		},
+		{
+			"syslog-prefix",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: "Sep 19 08:26:10 host CEF:0|Security|threatmanager|1.0|100|stopped|10|act=blocked",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":        "0",
+					"device_vendor":  "Security",
+					"device_product": "threatmanager",
+					"device_version": "1.0",
+					"signature_id":   "100",
+					"name":           "stopped",
+					"severity":       "10",
+					"extensions": map[string]any{
+						"act": "blocked",
+					},
+				},
+				Body: "Sep 19 08:26:10 host CEF:0|Security|threatmanager|1.0|100|stopped|10|act=blocked",
+			},
+			false,
+		},
+		{
+			"parse-to",
+			func(cfg *Config) {
+				cfg.ParseFrom = entry.NewBodyField("message")
+				cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField("cef")}
+			},
+			&entry.Entry{
+				Body: map[string]any{"message": "CEF:0|Security|threatmanager|1.0|100|stopped|10|"},
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"message": "CEF:0|Security|threatmanager|1.0|100|stopped|10|",
+					"cef": map[string]any{
+						"version":        "0",
+						"device_vendor":  "Security",
+						"device_product": "threatmanager",
+						"device_version": "1.0",
+						"signature_id":   "100",
+						"name":           "stopped",
+						"severity":       "10",
+					},
+				},
+			},
+			false,
+		},
+		{
+			"naming-ecs",
+			func(cfg *Config) {
+				cfg.Naming = NamingECS
+			},
+			&entry.Entry{
+				Body: "CEF:0|Security|threatmanager|1.0|100|stopped|10|src=10.0.0.1 spt=1232 proto=TCP cs1=custom",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"cef.version":        "0",
+					"observer.vendor":    "Security",
+					"observer.product":   "threatmanager",
+					"observer.version":   "1.0",
+					"event.code":         "100",
+					"message":            "stopped",
+					"event.severity":     int64(10),
+					"source.ip":          "10.0.0.1",
+					"source.port":        int64(1232),
+					"network.transport":  "tcp",
+					"cef.extensions.cs1": "custom",
+				},
+				Body: "CEF:0|Security|threatmanager|1.0|100|stopped|10|src=10.0.0.1 spt=1232 proto=TCP cs1=custom",
+			},
+			false,
+		},
+		{
+			"naming-semconv",
+			func(cfg *Config) {
+				cfg.Naming = NamingSemconv
+			},
+			&entry.Entry{
+				Body: "CEF:0|Security|threatmanager|1.0|100|stopped|High|dst=10.0.0.2 dpt=443 requestMethod=GET act=blocked",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"cef.version":         "0",
+					"cef.device_vendor":   "Security",
+					"cef.device_product":  "threatmanager",
+					"cef.device_version":  "1.0",
+					"cef.signature_id":    "100",
+					"cef.name":            "stopped",
+					"cef.severity":        "High",
+					"destination.address": "10.0.0.2",
+					"destination.port":    int64(443),
+					"http.request.method": "GET",
+					"cef.extensions.act":  "blocked",
+				},
+				Body: "CEF:0|Security|threatmanager|1.0|100|stopped|High|dst=10.0.0.2 dpt=443 requestMethod=GET act=blocked",
+			},
+			false,
+		},
+		{
+			"invalid",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: "not a CEF message",
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			set := componenttest.NewNopTelemetrySettings()
+			op, err := cfg.Build(set)
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			tc.input.ObservedTimestamp = ots
+
+			err = op.Process(t.Context(), tc.input)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			tc.expect.ObservedTimestamp = ots
+			fake.ExpectEntry(t, tc.expect)
+		})
+	}
+}
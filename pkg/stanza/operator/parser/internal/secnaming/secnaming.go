@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package secnaming maps the fields of security event formats, such as CEF and LEEF, to
+// the names of well known schemas.
+package secnaming // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/internal/secnaming"
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// Raw keeps the names of the header fields and key-value pairs of the format
+	Raw = "raw"
+	// ECS maps the header fields and well known key-value pairs to Elastic Common Schema fields
+	ECS = "ecs"
+	// Semconv maps well known key-value pairs to OpenTelemetry semantic conventions attributes
+	Semconv = "semconv"
+)
+
+// Conversion is the conversion applied to the value of a field.
+type Conversion int
+
+const (
+	AsString Conversion = iota
+	// AsInt converts the value to an int64 when it is a valid integer
+	AsInt
+	// AsLower converts the value to lower case
+	AsLower
+)
+
+// Field is the name and the conversion of a field of a naming.
+type Field struct {
+	Name       string
+	Conversion Conversion
+}
+
+// Convert applies the conversion of the field to the value.
+func (f Field) Convert(value string) any {
+	switch f.Conversion {
+	case AsInt:
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i
+		}
+	case AsLower:
+		return strings.ToLower(value)
+	}
+	return value
+}
+
+// Fields maps the header fields and the key-value pairs of a format to the fields of a naming.
+type Fields struct {
+	Header map[string]Field
+	Pairs  map[string]Field
+}
+
+// Rename maps the header values, whose keys are given by headerKeys, and the key-value pairs
+// of an event to the fields. Pairs without a well known name are kept under unknownPrefix+key.
+func (f Fields) Rename(headerKeys, header []string, pairs map[string]string, unknownPrefix string) map[string]any {
+	parsed := make(map[string]any, len(header)+len(pairs))
+	for i, value := range header {
+		field := f.Header[headerKeys[i]]
+		parsed[field.Name] = field.Convert(value)
+	}
+	for key, value := range pairs {
+		if field, ok := f.Pairs[key]; ok {
+			parsed[field.Name] = field.Convert(value)
+			continue
+		}
+		parsed[unknownPrefix+key] = value
+	}
+	return parsed
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secnaming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldConvert(t *testing.T) {
+	assert.Equal(t, "TCP", Field{Name: "a"}.Convert("TCP"))
+	assert.Equal(t, "tcp", Field{Name: "a", Conversion: AsLower}.Convert("TCP"))
+	assert.Equal(t, int64(443), Field{Name: "a", Conversion: AsInt}.Convert("443"))
+	// values which are not valid integers are kept as is
+	assert.Equal(t, "https", Field{Name: "a", Conversion: AsInt}.Convert("https"))
+}
+
+func TestFieldsRename(t *testing.T) {
+	fields := Fields{
+		Header: map[string]Field{
+			"version":  {Name: "test.version"},
+			"severity": {Name: "event.severity", Conversion: AsInt},
+		},
+		Pairs: map[string]Field{
+			"proto": {Name: "network.transport", Conversion: AsLower},
+		},
+	}
+	parsed := fields.Rename(
+		[]string{"version", "severity"},
+		[]string{"1", "7"},
+		map[string]string{"proto": "TCP", "custom": "value"},
+		"test.pairs.",
+	)
+	assert.Equal(t, map[string]any{
+		"test.version":      "1",
+		"event.severity":    int64(7),
+		"network.transport": "tcp",
+		"test.pairs.custom": "value",
+	}, parsed)
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/leef"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "leef_parser"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new LEEF parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new LEEF parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig: helper.NewParserConfig(operatorID, operatorType),
+		Naming:       NamingRaw,
+	}
+}
+
+// Config is the configuration of a LEEF parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+
+	// Delimiter overrides the attribute delimiter found in the header of LEEF 2.0 events,
+	// or the tab used by LEEF 1.0 events.
+	Delimiter string `mapstructure:"delimiter"`
+	Naming    string `mapstructure:"naming"`
+}
+
+// Build will build a LEEF parser operator.
+func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(set)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Naming {
+	case NamingRaw, NamingECS, NamingSemconv:
+	default:
+		return nil, fmt.Errorf("invalid 'naming' %q, must be one of '%s', '%s' or '%s'", c.Naming, NamingRaw, NamingECS, NamingSemconv)
+	}
+
+	var delimiter string
+	if c.Delimiter != "" {
+		if delimiter, err = parseDelimiter(c.Delimiter); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Parser{
+		ParserOperator: parserOperator,
+		delimiter:      delimiter,
+		naming:         c.Naming,
+	}, nil
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/leef"
+
+import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/internal/secnaming"
+
+const (
+	// NamingRaw keeps the LEEF names of the header fields and attributes
+	NamingRaw = secnaming.Raw
+	// NamingECS maps the header fields and well known attributes to Elastic Common Schema fields
+	NamingECS = secnaming.ECS
+	// NamingSemconv maps well known attributes to OpenTelemetry semantic conventions attributes
+	NamingSemconv = secnaming.Semconv
+)
+
+var ecsHeader = map[string]secnaming.Field{
+	"version":         {Name: "leef.version"},
+	"vendor":          {Name: "observer.vendor"},
+	"product":         {Name: "observer.product"},
+	"product_version": {Name: "observer.version"},
+	"event_id":        {Name: "event.code"},
+}
+
+var ecsAttributes = map[string]secnaming.Field{
+	"dst":            {Name: "destination.ip"},
+	"dstBytes":       {Name: "destination.bytes", Conversion: secnaming.AsInt},
+	"dstMAC":         {Name: "destination.mac"},
+	"dstPort":        {Name: "destination.port", Conversion: secnaming.AsInt},
+	"dstPostNAT":     {Name: "destination.nat.ip"},
+	"dstPostNATPort": {Name: "destination.nat.port", Conversion: secnaming.AsInt},
+	"identHostName":  {Name: "host.name"},
+	"proto":          {Name: "network.transport", Conversion: secnaming.AsLower},
+	"sev":            {Name: "event.severity", Conversion: secnaming.AsInt},
+	"src":            {Name: "source.ip"},
+	"srcBytes":       {Name: "source.bytes", Conversion: secnaming.AsInt},
+	"srcMAC":         {Name: "source.mac"},
+	"srcPort":        {Name: "source.port", Conversion: secnaming.AsInt},
+	"srcPostNAT":     {Name: "source.nat.ip"},
+	"srcPostNATPort": {Name: "source.nat.port", Conversion: secnaming.AsInt},
+	"url":            {Name: "url.original"},
+	"usrName":        {Name: "user.name"},
+}
+
+var semconvHeader = map[string]secnaming.Field{
+	"version":         {Name: "leef.version"},
+	"vendor":          {Name: "leef.vendor"},
+	"product":         {Name: "leef.product"},
+	"product_version": {Name: "leef.product_version"},
+	"event_id":        {Name: "leef.event_id"},
+}
+
+var semconvAttributes = map[string]secnaming.Field{
+	"dst":           {Name: "destination.address"},
+	"dstPort":       {Name: "destination.port", Conversion: secnaming.AsInt},
+	"identHostName": {Name: "host.name"},
+	"proto":         {Name: "network.transport", Conversion: secnaming.AsLower},
+	"src":           {Name: "source.address"},
+	"srcPort":       {Name: "source.port", Conversion: secnaming.AsInt},
+	"url":           {Name: "url.full"},
+	"usrName":       {Name: "user.name"},
+}
+
+var (
+	ecsFields     = secnaming.Fields{Header: ecsHeader, Pairs: ecsAttributes}
+	semconvFields = secnaming.Fields{Header: semconvHeader, Pairs: semconvAttributes}
+)
+
+// rename maps the header fields and attributes of an event to the given naming. Attributes
+// without a well known name are kept under "leef.attributes.<key>".
+func rename(naming string, header []string, attributes map[string]string) map[string]any {
+	fields := ecsFields
+	if naming == NamingSemconv {
+		fields = semconvFields
+	}
+	return fields.Rename(headerKeys, header, attributes, "leef.attributes.")
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/leef"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	leefPrefix = "LEEF:"
+	// defaultDelimiter separates the attributes of LEEF 1.0 events, and of LEEF 2.0 events
+	// not specifying a delimiter
+	defaultDelimiter = "\t"
+)
+
+// headerKeys are the names of the fields of a LEEF header, in order
+var headerKeys = []string{"version", "vendor", "product", "product_version", "event_id"}
+
+// Parser is an operator that parses IBM Log Event Extended Format messages.
+type Parser struct {
+	helper.ParserOperator
+	delimiter string
+	naming    string
+}
+
+func (p *Parser) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return p.ProcessBatchWith(ctx, entries, p.parse)
+}
+
+// Process will parse an entry for a LEEF message.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value as a LEEF message.
+func (p *Parser) parse(value any) (any, error) {
+	switch m := value.(type) {
+	case string:
+		return p.parser(m)
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as LEEF", value)
+	}
+}
+
+func (p *Parser) parser(input string) (map[string]any, error) {
+	if input == "" {
+		return nil, fmt.Errorf("parse from field %s is empty", p.ParseFrom.String())
+	}
+
+	// the message may be prefixed, e.g. by a syslog header
+	start := strings.Index(input, leefPrefix)
+	if start < 0 {
+		return nil, errors.New("missing LEEF header")
+	}
+
+	header, rest, err := splitHeader(input[start+len(leefPrefix):])
+	if err != nil {
+		return nil, err
+	}
+
+	delimiter := defaultDelimiter
+	switch header[0] {
+	case "1.0", "1":
+	case "2.0", "2":
+		// the delimiter field is optional, so it is only found if it is not an attribute
+		if i := strings.IndexByte(rest, '|'); i >= 0 && !strings.Contains(rest[:i], "=") {
+			if rest[:i] != "" {
+				if delimiter, err = parseDelimiter(rest[:i]); err != nil {
+					return nil, err
+				}
+			}
+			rest = rest[i+1:]
+		}
+	default:
+		return nil, fmt.Errorf("unsupported LEEF version %q", header[0])
+	}
+	if p.delimiter != "" {
+		delimiter = p.delimiter
+	}
+
+	attributes, err := parseAttributes(rest, delimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.naming != NamingRaw {
+		return rename(p.naming, header, attributes), nil
+	}
+
+	parsed := make(map[string]any, len(header)+1)
+	for i, value := range header {
+		parsed[headerKeys[i]] = value
+	}
+	if len(attributes) > 0 {
+		attrs := make(map[string]any, len(attributes))
+		for key, value := range attributes {
+			attrs[key] = value
+		}
+		parsed["attributes"] = attrs
+	}
+	return parsed, nil
+}
+
+// splitHeader splits the pipe separated fields of a LEEF header from the rest of the event.
+// Pipes may be escaped with a backslash in the header fields.
+func splitHeader(s string) ([]string, string, error) {
+	fields := make([]string, 0, len(headerKeys))
+	var field strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '|':
+			field.WriteByte('|')
+			i++
+		case c == '|':
+			fields = append(fields, field.String())
+			field.Reset()
+			if len(fields) == len(headerKeys) {
+				return fields, s[i+1:], nil
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+	if len(fields) == len(headerKeys)-1 {
+		// the trailing pipe may be omitted when there are no attributes
+		return append(fields, field.String()), "", nil
+	}
+	return nil, "", fmt.Errorf("expected %d LEEF header fields, got %d", len(headerKeys), len(fields)+1)
+}
+
+// parseDelimiter parses an attribute delimiter, given either as a single character or as the
+// hexadecimal code of a character, e.g. "x09" or "0x09" for a tab.
+func parseDelimiter(s string) (string, error) {
+	if len(s) == 1 {
+		return s, nil
+	}
+	if s == `\t` {
+		return "\t", nil
+	}
+	lower := strings.ToLower(s)
+	hex, ok := strings.CutPrefix(lower, "0x")
+	if !ok {
+		if hex, ok = strings.CutPrefix(lower, "x"); !ok {
+			return "", fmt.Errorf("invalid LEEF delimiter %q", s)
+		}
+	}
+	c, err := strconv.ParseUint(hex, 16, 8)
+	if err != nil {
+		return "", fmt.Errorf("invalid LEEF delimiter %q", s)
+	}
+	return string(rune(c)), nil
+}
+
+// parseAttributes parses the key=value pairs of a LEEF event, separated by the delimiter.
+func parseAttributes(s, delimiter string) (map[string]string, error) {
+	attributes := make(map[string]string)
+	for _, pair := range strings.Split(s, delimiter) {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("cannot parse LEEF attribute %q", pair)
+		}
+		attributes[strings.TrimSpace(key)] = value
+	}
+	return attributes, nil
+}
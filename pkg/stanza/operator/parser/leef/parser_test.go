@@ -0,0 +1,384 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leef
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := config.Build(set)
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("leef_parser")
+	require.True(t, ok, "expected leef_parser to be registered")
+	require.Equal(t, "leef_parser", builder().Type())
+}
+
+func TestConfigBuild(t *testing.T) {
+	config := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := config.Build(set)
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.OnError = "invalid_on_error"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := config.Build(set)
+	require.ErrorContains(t, err, "invalid `on_error` field")
+}
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		expectErr bool
+	}{
+		{
+			"default",
+			func(_ *Config) {},
+			false,
+		},
+		{
+			"delimiter",
+			func(cfg *Config) {
+				cfg.Delimiter = "^"
+			},
+			false,
+		},
+		{
+			"hex-delimiter",
+			func(cfg *Config) {
+				cfg.Delimiter = "0x09"
+			},
+			false,
+		},
+		{
+			"invalid-delimiter",
+			func(cfg *Config) {
+				cfg.Delimiter = "^^"
+			},
+			true,
+		},
+		{
+			"naming",
+			func(cfg *Config) {
+				cfg.Naming = NamingSemconv
+			},
+			false,
+		},
+		{
+			"invalid-naming",
+			func(cfg *Config) {
+				cfg.Naming = "invalid"
+			},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_operator_id")
+			tc.configure(cfg)
+			set := componenttest.NewNopTelemetrySettings()
+			_, err := cfg.Build(set)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.ErrorContains(t, err, "type []int cannot be parsed as LEEF")
+}
+
+func TestParserEmptyInput(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("")
+	require.ErrorContains(t, err, "parse from field body is empty")
+}
+
+func TestParserMissingHeader(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("CEF:0|Security|threatmanager|1.0|100|stopped|10|")
+	require.ErrorContains(t, err, "missing LEEF header")
+}
+
+func TestParserUnsupportedVersion(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("LEEF:3.0|Microsoft|MSExchange|4.0 SP1|15345|")
+	require.ErrorContains(t, err, `unsupported LEEF version "3.0"`)
+}
+
+func TestParserInvalidAttribute(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=10.0.0.1\tinvalid")
+	require.ErrorContains(t, err, `cannot parse LEEF attribute "invalid"`)
+}
+
+func TestLEEFImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestParseDelimiter(t *testing.T) {
+	cases := []struct {
+		input     string
+		expect    string
+		expectErr bool
+	}{
+		{"^", "^", false},
+		{"x09", "\t", false},
+		{"0x7C", "|", false},
+		{"X5E", "^", false},
+		{`\t`, "\t", false},
+		{"^^", "", true},
+		{"xZZ", "", true},
+		{"09", "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			delimiter, err := parseDelimiter(tc.input)
+			if tc.expectErr {
+				require.ErrorContains(t, err, "invalid LEEF delimiter")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, delimiter)
+		})
+	}
+}
+
+func TestParser(t *testing.T) {
+	cases := []struct {
+		name        string
+		configure   func(*Config)
+		input       *entry.Entry
+		expect      *entry.Entry
+		expectError bool
+	}{
+		{
+			"leef-1",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1\tsev=5\tusrName=joe.black",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":         "1.0",
+					"vendor":          "Microsoft",
+					"product":         "MSExchange",
+					"product_version": "4.0 SP1",
+					"event_id":        "15345",
+					"attributes": map[string]any{
+						"src":     "192.0.2.0",
+						"dst":     "172.50.123.1",
+						"sev":     "5",
+						"usrName": "joe.black",
+					},
+				},
+				Body: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1\tsev=5\tusrName=joe.black",
+			},
+			false,
+		},
+		{
+			"leef-2-delimiter",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^msg=a=b",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":         "2.0",
+					"vendor":          "Lancope",
+					"product":         "StealthWatch",
+					"product_version": "1.0",
+					"event_id":        "41",
+					"attributes": map[string]any{
+						"src": "10.0.1.8",
+						"dst": "10.0.0.5",
+						"msg": "a=b",
+					},
+				},
+				Body: "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^msg=a=b",
+			},
+			false,
+		},
+		{
+			"leef-2-hex-delimiter",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: "LEEF:2.0|Lancope|StealthWatch|1.0|41|x7C|src=10.0.1.8|dst=10.0.0.5",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":         "2.0",
+					"vendor":          "Lancope",
+					"product":         "StealthWatch",
+					"product_version": "1.0",
+					"event_id":        "41",
+					"attributes": map[string]any{
+						"src": "10.0.1.8",
+						"dst": "10.0.0.5",
+					},
+				},
+				Body: "LEEF:2.0|Lancope|StealthWatch|1.0|41|x7C|src=10.0.1.8|dst=10.0.0.5",
+			},
+			false,
+		},
+		{
+			"leef-2-without-delimiter",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: "LEEF:2.0|Lancope|StealthWatch|1.0|41|src=10.0.1.8\tdst=10.0.0.5",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":         "2.0",
+					"vendor":          "Lancope",
+					"product":         "StealthWatch",
+					"product_version": "1.0",
+					"event_id":        "41",
+					"attributes": map[string]any{
+						"src": "10.0.1.8",
+						"dst": "10.0.0.5",
+					},
+				},
+				Body: "LEEF:2.0|Lancope|StealthWatch|1.0|41|src=10.0.1.8\tdst=10.0.0.5",
+			},
+			false,
+		},
+		{
+			"configured-delimiter",
+			func(cfg *Config) {
+				cfg.Delimiter = "^"
+			},
+			&entry.Entry{
+				Body: "<13>Sep 19 08:26:10 host LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0^dst=172.50.123.1",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":         "1.0",
+					"vendor":          "Microsoft",
+					"product":         "MSExchange",
+					"product_version": "4.0 SP1",
+					"event_id":        "15345",
+					"attributes": map[string]any{
+						"src": "192.0.2.0",
+						"dst": "172.50.123.1",
+					},
+				},
+				Body: "<13>Sep 19 08:26:10 host LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0^dst=172.50.123.1",
+			},
+			false,
+		},
+		{
+			"naming-ecs",
+			func(cfg *Config) {
+				cfg.Naming = NamingECS
+			},
+			&entry.Entry{
+				Body: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tsrcPort=1234\tproto=TCP\tsev=5\tcat=anomaly",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"leef.version":        "1.0",
+					"observer.vendor":     "Microsoft",
+					"observer.product":    "MSExchange",
+					"observer.version":    "4.0 SP1",
+					"event.code":          "15345",
+					"source.ip":           "192.0.2.0",
+					"source.port":         int64(1234),
+					"network.transport":   "tcp",
+					"event.severity":      int64(5),
+					"leef.attributes.cat": "anomaly",
+				},
+				Body: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tsrcPort=1234\tproto=TCP\tsev=5\tcat=anomaly",
+			},
+			false,
+		},
+		{
+			"naming-semconv",
+			func(cfg *Config) {
+				cfg.Naming = NamingSemconv
+			},
+			&entry.Entry{
+				Body: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|dst=172.50.123.1\tdstPort=443\tusrName=joe.black\tsev=5",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"leef.version":         "1.0",
+					"leef.vendor":          "Microsoft",
+					"leef.product":         "MSExchange",
+					"leef.product_version": "4.0 SP1",
+					"leef.event_id":        "15345",
+					"destination.address":  "172.50.123.1",
+					"destination.port":     int64(443),
+					"user.name":            "joe.black",
+					"leef.attributes.sev":  "5",
+				},
+				Body: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|dst=172.50.123.1\tdstPort=443\tusrName=joe.black\tsev=5",
+			},
+			false,
+		},
+		{
+			"invalid-delimiter",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: "LEEF:2.0|Lancope|StealthWatch|1.0|41|^^|src=10.0.1.8",
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			set := componenttest.NewNopTelemetrySettings()
+			op, err := cfg.Build(set)
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			tc.input.ObservedTimestamp = ots
+
+			err = op.Process(t.Context(), tc.input)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			tc.expect.ObservedTimestamp = ots
+			fake.ExpectEntry(t, tc.expect)
+		})
+	}
+}
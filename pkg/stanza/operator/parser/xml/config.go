@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/xml"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "xml_parser"
+
+	// ModeElements parses each element into a map of its tag, attributes, content and children
+	ModeElements = "elements"
+	// ModeMap parses each element into a value keyed by its tag in the map of its parent
+	ModeMap = "map"
+
+	// NamespacesStrip drops the namespace prefixes of names and the namespace declarations
+	NamespacesStrip = "strip"
+	// NamespacesKeep keeps the namespace prefixes of names and the namespace declarations
+	NamespacesKeep = "keep"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new XML parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new XML parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig:    helper.NewParserConfig(operatorID, operatorType),
+		Mode:            ModeElements,
+		Namespaces:      NamespacesStrip,
+		AttributePrefix: "@",
+		TextKey:         "#text",
+	}
+}
+
+// Config is the configuration of an XML parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+
+	Mode       string `mapstructure:"mode"`
+	Namespaces string `mapstructure:"namespaces"`
+
+	// AttributePrefix and TextKey name the attributes and the content of elements in map mode
+	AttributePrefix string `mapstructure:"attribute_prefix"`
+	TextKey         string `mapstructure:"text_key"`
+}
+
+// Build will build an XML parser operator.
+func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(set)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Mode {
+	case ModeElements:
+	case ModeMap:
+		if c.TextKey == "" {
+			return nil, errors.New("text_key cannot be empty in map mode")
+		}
+	default:
+		return nil, fmt.Errorf("invalid 'mode' %q, must be one of '%s' or '%s'", c.Mode, ModeElements, ModeMap)
+	}
+
+	switch c.Namespaces {
+	case NamespacesStrip, NamespacesKeep:
+	default:
+		return nil, fmt.Errorf("invalid 'namespaces' %q, must be one of '%s' or '%s'", c.Namespaces, NamespacesStrip, NamespacesKeep)
+	}
+
+	return &Parser{
+		ParserOperator:  parserOperator,
+		mode:            c.Mode,
+		keepNamespaces:  c.Namespaces == NamespacesKeep,
+		attributePrefix: c.AttributePrefix,
+		textKey:         c.TextKey,
+	}, nil
+}
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/xml"
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// Parser is an operator that parses XML.
+type Parser struct {
+	helper.ParserOperator
+	mode            string
+	keepNamespaces  bool
+	attributePrefix string
+	textKey         string
+}
+
+func (p *Parser) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return p.ProcessBatchWith(ctx, entries, p.parse)
+}
+
+// Process will parse an entry for XML.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value as XML.
+func (p *Parser) parse(value any) (any, error) {
+	switch m := value.(type) {
+	case string:
+		return p.parser(m)
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as XML", value)
+	}
+}
+
+func (p *Parser) parser(input string) (map[string]any, error) {
+	if input == "" {
+		return nil, fmt.Errorf("parse from field %s is empty", p.ParseFrom.String())
+	}
+
+	root, err := p.decode(input)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal xml: %w", err)
+	}
+
+	if p.mode == ModeMap {
+		return map[string]any{root.tag: p.toValue(root)}, nil
+	}
+	return root.toMap(), nil
+}
+
+type element struct {
+	name       xml.Name
+	tag        string
+	attributes []attribute
+	text       string
+	children   []*element
+}
+
+type attribute struct {
+	name  string
+	value string
+}
+
+// decode decodes the single root element of the input. Raw tokens are used so that the
+// namespace prefixes are kept as written, which requires checking that elements are closed
+// by the matching end element.
+func (p *Parser) decode(input string) (*element, error) {
+	decoder := xml.NewDecoder(strings.NewReader(input))
+
+	var root *element
+	var open []*element
+	for {
+		tok, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if root != nil && len(open) == 0 {
+				return nil, errors.New("multiple root elements")
+			}
+			e := p.newElement(t)
+			if root == nil {
+				root = e
+			} else {
+				parent := open[len(open)-1]
+				parent.children = append(parent.children, e)
+			}
+			open = append(open, e)
+		case xml.EndElement:
+			if len(open) == 0 {
+				return nil, fmt.Errorf("unexpected end element </%s>", p.name(t.Name))
+			}
+			if e := open[len(open)-1]; e.name != t.Name {
+				return nil, fmt.Errorf("element <%s> closed by </%s>", e.tag, p.name(t.Name))
+			}
+			open = open[:len(open)-1]
+		case xml.CharData:
+			// Strip leading/trailing spaces to ignore newlines and
+			// indentation in formatted XML
+			text := bytes.TrimSpace(t)
+			if len(open) == 0 {
+				if len(text) > 0 {
+					return nil, errors.New("text outside of the root element")
+				}
+				continue
+			}
+			open[len(open)-1].text += string(text)
+		case xml.Comment, xml.ProcInst, xml.Directive:
+			// ignore comments, processing instructions and directives
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("no root element")
+	}
+	if len(open) > 0 {
+		return nil, fmt.Errorf("element <%s> is not closed", open[len(open)-1].tag)
+	}
+	return root, nil
+}
+
+func (p *Parser) newElement(start xml.StartElement) *element {
+	e := &element{
+		name: start.Name,
+		tag:  p.name(start.Name),
+	}
+	for _, attr := range start.Attr {
+		if !p.keepNamespaces && isNamespaceDeclaration(attr.Name) {
+			continue
+		}
+		e.attributes = append(e.attributes, attribute{name: p.name(attr.Name), value: attr.Value})
+	}
+	return e
+}
+
+// name returns the name of an element or attribute, with its namespace prefix when namespaces are kept
+func (p *Parser) name(n xml.Name) string {
+	if p.keepNamespaces && n.Space != "" {
+		return n.Space + ":" + n.Local
+	}
+	return n.Local
+}
+
+func isNamespaceDeclaration(n xml.Name) bool {
+	return n.Space == "xmlns" || (n.Space == "" && n.Local == "xmlns")
+}
+
+// toMap converts the element into a map of its tag, content, attributes and children,
+// the same way as the ParseXML OTTL converter.
+func (e *element) toMap() map[string]any {
+	m := map[string]any{"tag": e.tag}
+	if e.text != "" {
+		m["content"] = e.text
+	}
+	if len(e.attributes) > 0 {
+		attrs := make(map[string]any, len(e.attributes))
+		for _, attr := range e.attributes {
+			attrs[attr.name] = attr.value
+		}
+		m["attributes"] = attrs
+	}
+	if len(e.children) > 0 {
+		children := make([]any, 0, len(e.children))
+		for _, child := range e.children {
+			children = append(children, child.toMap())
+		}
+		m["children"] = children
+	}
+	return m
+}
+
+// toValue converts the element into its content when it has no attributes nor children.
+// Otherwise, it is converted into a map of its prefixed attributes, its content and its
+// children keyed by their tag, where repeated children are collected into a slice.
+func (p *Parser) toValue(e *element) any {
+	if len(e.attributes) == 0 && len(e.children) == 0 {
+		return e.text
+	}
+
+	m := make(map[string]any, len(e.attributes)+len(e.children)+1)
+	for _, attr := range e.attributes {
+		m[p.attributePrefix+attr.name] = attr.value
+	}
+	if e.text != "" {
+		m[p.textKey] = e.text
+	}
+
+	repeated := make(map[string]bool)
+	for _, child := range e.children {
+		value := p.toValue(child)
+		existing, ok := m[child.tag]
+		switch {
+		case !ok:
+			m[child.tag] = value
+		case repeated[child.tag]:
+			m[child.tag] = append(existing.([]any), value)
+		default:
+			m[child.tag] = []any{existing, value}
+			repeated[child.tag] = true
+		}
+	}
+	return m
+}
@@ -0,0 +1,351 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := config.Build(set)
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("xml_parser")
+	require.True(t, ok, "expected xml_parser to be registered")
+	require.Equal(t, "xml_parser", builder().Type())
+}
+
+func TestConfigBuild(t *testing.T) {
+	config := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := config.Build(set)
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.OnError = "invalid_on_error"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := config.Build(set)
+	require.ErrorContains(t, err, "invalid `on_error` field")
+}
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		expectErr string
+	}{
+		{
+			"default",
+			func(_ *Config) {},
+			"",
+		},
+		{
+			"map-mode",
+			func(cfg *Config) {
+				cfg.Mode = ModeMap
+			},
+			"",
+		},
+		{
+			"invalid-mode",
+			func(cfg *Config) {
+				cfg.Mode = "invalid"
+			},
+			`invalid 'mode' "invalid"`,
+		},
+		{
+			"empty-text-key",
+			func(cfg *Config) {
+				cfg.Mode = ModeMap
+				cfg.TextKey = ""
+			},
+			"text_key cannot be empty in map mode",
+		},
+		{
+			"invalid-namespaces",
+			func(cfg *Config) {
+				cfg.Namespaces = "invalid"
+			},
+			`invalid 'namespaces' "invalid"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_operator_id")
+			tc.configure(cfg)
+			set := componenttest.NewNopTelemetrySettings()
+			_, err := cfg.Build(set)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.ErrorContains(t, err, "type []int cannot be parsed as XML")
+}
+
+func TestParserEmptyInput(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("")
+	require.ErrorContains(t, err, "parse from field body is empty")
+}
+
+func TestParserInvalidXML(t *testing.T) {
+	cases := []struct {
+		name      string
+		input     string
+		expectErr string
+	}{
+		{
+			"not-xml",
+			"not xml",
+			"text outside of the root element",
+		},
+		{
+			"no-root",
+			`<?xml version="1.0"?><!-- comment -->`,
+			"no root element",
+		},
+		{
+			"multiple-roots",
+			"<a></a><b></b>",
+			"multiple root elements",
+		},
+		{
+			"trailing-text",
+			"<a></a>trailing",
+			"text outside of the root element",
+		},
+		{
+			"mismatched-end",
+			"<a><b></a></b>",
+			"element <b> closed by </a>",
+		},
+		{
+			"unexpected-end",
+			"</a>",
+			"unexpected end element </a>",
+		},
+		{
+			"unclosed",
+			"<a><b></b>",
+			"element <a> is not closed",
+		},
+		{
+			"syntax",
+			`<a b="c></a>`,
+			"XML syntax error",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := newTestParser(t)
+			_, err := parser.parse(tc.input)
+			require.ErrorContains(t, err, "unmarshal xml")
+			require.ErrorContains(t, err, tc.expectErr)
+		})
+	}
+}
+
+func TestXMLImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestParser(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     string
+		expect    map[string]any
+	}{
+		{
+			"elements",
+			func(_ *Config) {},
+			`<Log severity="info"><Message>started</Message><Retry/></Log>`,
+			map[string]any{
+				"tag":        "Log",
+				"attributes": map[string]any{"severity": "info"},
+				"children": []any{
+					map[string]any{"tag": "Message", "content": "started"},
+					map[string]any{"tag": "Retry"},
+				},
+			},
+		},
+		{
+			"elements-formatted",
+			func(_ *Config) {},
+			"<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<!-- a comment -->\n<Log>\n  <Message>\n    <![CDATA[a < b]]>\n  </Message>\n</Log>\n",
+			map[string]any{
+				"tag": "Log",
+				"children": []any{
+					map[string]any{"tag": "Message", "content": "a < b"},
+				},
+			},
+		},
+		{
+			"elements-namespaces-strip",
+			func(_ *Config) {},
+			`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><soap:Body xsi:type="t">ok</soap:Body></soap:Envelope>`,
+			map[string]any{
+				"tag": "Envelope",
+				"children": []any{
+					map[string]any{"tag": "Body", "content": "ok", "attributes": map[string]any{"type": "t"}},
+				},
+			},
+		},
+		{
+			"elements-namespaces-keep",
+			func(cfg *Config) {
+				cfg.Namespaces = NamespacesKeep
+			},
+			`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope" xmlns="urn:default"><soap:Body>ok</soap:Body></soap:Envelope>`,
+			map[string]any{
+				"tag": "soap:Envelope",
+				"attributes": map[string]any{
+					"xmlns:soap": "http://www.w3.org/2003/05/soap-envelope",
+					"xmlns":      "urn:default",
+				},
+				"children": []any{
+					map[string]any{"tag": "soap:Body", "content": "ok"},
+				},
+			},
+		},
+		{
+			"map",
+			func(cfg *Config) {
+				cfg.Mode = ModeMap
+			},
+			`<Event xmlns="http://schemas.microsoft.com/win/2004/08/events/event"><System><Provider Name="Service Control Manager"/><EventID Qualifiers="16384">7036</EventID><Level>4</Level></System><EventData><Data Name="param1">Windows Update</Data><Data Name="param2">running</Data></EventData></Event>`,
+			map[string]any{
+				"Event": map[string]any{
+					"System": map[string]any{
+						"Provider": map[string]any{"@Name": "Service Control Manager"},
+						"EventID":  map[string]any{"@Qualifiers": "16384", "#text": "7036"},
+						"Level":    "4",
+					},
+					"EventData": map[string]any{
+						"Data": []any{
+							map[string]any{"@Name": "param1", "#text": "Windows Update"},
+							map[string]any{"@Name": "param2", "#text": "running"},
+						},
+					},
+				},
+			},
+		},
+		{
+			"map-custom-keys",
+			func(cfg *Config) {
+				cfg.Mode = ModeMap
+				cfg.AttributePrefix = "_"
+				cfg.TextKey = "value"
+			},
+			`<items count="3"><item>a</item><item>b</item><item id="c">c</item><empty/></items>`,
+			map[string]any{
+				"items": map[string]any{
+					"_count": "3",
+					"item": []any{
+						"a",
+						"b",
+						map[string]any{"_id": "c", "value": "c"},
+					},
+					"empty": "",
+				},
+			},
+		},
+		{
+			"map-namespaces-keep",
+			func(cfg *Config) {
+				cfg.Mode = ModeMap
+				cfg.Namespaces = NamespacesKeep
+			},
+			`<a:root xmlns:a="urn:a"><a:child>text</a:child></a:root>`,
+			map[string]any{
+				"a:root": map[string]any{
+					"@xmlns:a": "urn:a",
+					"a:child":  "text",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			set := componenttest.NewNopTelemetrySettings()
+			op, err := cfg.Build(set)
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			input := &entry.Entry{
+				Body:              tc.input,
+				ObservedTimestamp: ots,
+			}
+			require.NoError(t, op.Process(t.Context(), input))
+
+			fake.ExpectEntry(t, &entry.Entry{
+				Attributes:        tc.expect,
+				Body:              tc.input,
+				ObservedTimestamp: ots,
+			})
+		})
+	}
+}
+
+func TestParserParseToAndOnError(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.ParseFrom = entry.NewBodyField("message")
+	cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField("parsed")}
+	cfg.OnError = "send_quiet"
+
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := cfg.Build(set)
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	valid := entry.New()
+	valid.Body = map[string]any{"message": "<a>b</a>"}
+	require.NoError(t, op.Process(t.Context(), valid))
+	fake.ExpectBody(t, map[string]any{
+		"message": "<a>b</a>",
+		"parsed":  map[string]any{"tag": "a", "content": "b"},
+	})
+
+	invalid := entry.New()
+	invalid.Body = map[string]any{"message": "<a>"}
+	require.NoError(t, op.Process(t.Context(), invalid))
+	fake.ExpectBody(t, map[string]any{"message": "<a>"})
+}
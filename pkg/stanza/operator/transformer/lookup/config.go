@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lookup // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/lookup"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/lookuptable"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "lookup"
+
+var errMissingField = errors.New("lookup: missing field")
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new lookup operator config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new lookup operator config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig: helper.NewTransformerConfig(operatorID, operatorType),
+		ReloadInterval:    lookuptable.DefaultReloadInterval,
+	}
+}
+
+// Config is the configuration of a lookup operator
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+	Field                    entry.Field   `mapstructure:"field"`
+	Path                     string        `mapstructure:"path"`
+	Format                   string        `mapstructure:"format"`
+	Key                      string        `mapstructure:"key"`
+	ReloadInterval           time.Duration `mapstructure:"reload_interval"`
+}
+
+// Build will build a lookup operator from the supplied configuration
+func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	transformerOperator, err := c.TransformerConfig.Build(set)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Field.IsEmpty() {
+		return nil, errMissingField
+	}
+
+	table, err := lookuptable.New(lookuptable.Config{
+		Path:           c.Path,
+		Format:         c.Format,
+		Key:            c.Key,
+		ReloadInterval: c.ReloadInterval,
+	}, set.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: load table: %w", err)
+	}
+
+	return &Transformer{
+		TransformerOperator: transformerOperator,
+		Field:               c.Field,
+		table:               table,
+	}, nil
+}
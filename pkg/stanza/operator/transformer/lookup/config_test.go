@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package lookup
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+// test unmarshalling of values into config struct
+func TestUnmarshal(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name: "csv",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Field = entry.NewAttributeField("error_id")
+					cfg.Path = "testdata/errors.csv"
+					cfg.Key = "id"
+					return cfg
+				}(),
+			},
+			{
+				Name: "json",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Field = entry.NewBodyField("tenant")
+					cfg.Path = "testdata/tenants.json"
+					cfg.Format = "json"
+					return cfg
+				}(),
+			},
+			{
+				Name: "reload_interval",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Field = entry.NewAttributeField("error_id")
+					cfg.Path = "testdata/errors.csv"
+					cfg.Key = "id"
+					cfg.ReloadInterval = time.Minute
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
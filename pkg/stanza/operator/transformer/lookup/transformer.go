@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lookup // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/lookup"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/lookuptable"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// Transformer is an operator that merges the row of a lookup table matching a field into the attributes
+type Transformer struct {
+	helper.TransformerOperator
+	Field entry.Field
+	table *lookuptable.Table
+}
+
+func (t *Transformer) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return t.ProcessBatchWithTransform(ctx, entries, t.Transform)
+}
+
+// Process will process an entry with a lookup transformation.
+func (t *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
+	return t.ProcessWith(ctx, entry, t.Transform)
+}
+
+// Transform will merge the row matching the field of an entry into its attributes.
+// Entries without the field or without a matching row are left unchanged.
+func (t *Transformer) Transform(e *entry.Entry) error {
+	val, exist := t.Field.Get(e)
+	if !exist {
+		return nil
+	}
+
+	var key string
+	switch v := val.(type) {
+	case string:
+		key = v
+	case []byte:
+		key = string(v)
+	case map[string]any, []any:
+		return fmt.Errorf("lookup: cannot look up a value of type %T: %s", val, t.Field.String())
+	default:
+		key = fmt.Sprint(v)
+	}
+
+	row, ok := t.table.Lookup(key)
+	if !ok {
+		return nil
+	}
+	if e.Attributes == nil {
+		e.Attributes = make(map[string]any, len(row))
+	}
+	for k, v := range row {
+		e.Attributes[k] = copyValue(v)
+	}
+	return nil
+}
+
+// copyValue copies the maps and slices of the values of a row, which are shared by the table
+func copyValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[k] = copyValue(item)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, item := range v {
+			s[i] = copyValue(item)
+		}
+		return s
+	default:
+		return v
+	}
+}
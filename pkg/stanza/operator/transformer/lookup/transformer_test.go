@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package lookup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("lookup")
+	require.True(t, ok, "expected lookup to be registered")
+	require.Equal(t, "lookup", builder().Type())
+}
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		expectErr string
+	}{
+		{
+			"csv",
+			func(_ *Config) {},
+			"",
+		},
+		{
+			"missing-field",
+			func(cfg *Config) {
+				cfg.Field = entry.Field{}
+			},
+			"lookup: missing field",
+		},
+		{
+			"missing-key",
+			func(cfg *Config) {
+				cfg.Key = ""
+			},
+			"key is required for CSV tables",
+		},
+		{
+			"missing-file",
+			func(cfg *Config) {
+				cfg.Path = filepath.Join("testdata", "missing.csv")
+			},
+			"lookup: load table",
+		},
+		{
+			"invalid-format",
+			func(cfg *Config) {
+				cfg.Format = "xml"
+			},
+			`invalid format "xml"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.Field = entry.NewAttributeField("error_id")
+			cfg.Path = filepath.Join("testdata", "errors.csv")
+			cfg.Key = "id"
+			tc.configure(cfg)
+
+			set := componenttest.NewNopTelemetrySettings()
+			_, err := cfg.Build(set)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// Test building and processing a Config
+func TestBuildAndProcess(t *testing.T) {
+	now := time.Now()
+	newTestEntry := func(attributes map[string]any, body any) func() *entry.Entry {
+		return func() *entry.Entry {
+			e := entry.New()
+			e.ObservedTimestamp = now
+			e.Timestamp = time.Unix(1586632809, 0)
+			e.Attributes = attributes
+			e.Body = body
+			return e
+		}
+	}
+
+	csvConfig := func() *Config {
+		cfg := NewConfig()
+		cfg.Field = entry.NewAttributeField("error_id")
+		cfg.Path = filepath.Join("testdata", "errors.csv")
+		cfg.Key = "id"
+		return cfg
+	}
+	jsonConfig := func() *Config {
+		cfg := NewConfig()
+		cfg.Field = entry.NewBodyField("tenant")
+		cfg.Path = filepath.Join("testdata", "tenants.json")
+		return cfg
+	}
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		op        *Config
+		input     func() *entry.Entry
+		output    func() *entry.Entry
+	}{
+		{
+			"csv_match",
+			false,
+			csvConfig(),
+			newTestEntry(map[string]any{"error_id": "E100", "error.severity": "info"}, "failed"),
+			newTestEntry(map[string]any{
+				"error_id":       "E100",
+				"error.message":  "disk full, retrying",
+				"error.severity": "error",
+			}, "failed"),
+		},
+		{
+			"csv_no_match",
+			false,
+			csvConfig(),
+			newTestEntry(map[string]any{"error_id": "E300"}, "failed"),
+			newTestEntry(map[string]any{"error_id": "E300"}, "failed"),
+		},
+		{
+			"missing_field",
+			false,
+			csvConfig(),
+			newTestEntry(nil, "failed"),
+			newTestEntry(nil, "failed"),
+		},
+		{
+			"json_match",
+			false,
+			jsonConfig(),
+			newTestEntry(nil, map[string]any{"tenant": "t1"}),
+			newTestEntry(map[string]any{
+				"tenant.name":   "acme",
+				"tenant.tier":   int64(2),
+				"tenant.owners": []any{"alice", "bob"},
+			}, map[string]any{"tenant": "t1"}),
+		},
+		{
+			"number_field",
+			false,
+			func() *Config {
+				cfg := jsonConfig()
+				cfg.Field = entry.NewBodyField("id")
+				cfg.Path = filepath.Join(t.TempDir(), "hosts.json")
+				require.NoError(t, os.WriteFile(cfg.Path, []byte(`[{"id": 42, "host.name": "web-1"}]`), 0o600))
+				cfg.Key = "id"
+				return cfg
+			}(),
+			newTestEntry(nil, map[string]any{"id": 42}),
+			newTestEntry(map[string]any{"host.name": "web-1"}, map[string]any{"id": 42}),
+		},
+		{
+			"invalid_field_type",
+			true,
+			jsonConfig(),
+			newTestEntry(nil, map[string]any{"tenant": map[string]any{"id": "t1"}}),
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run("BuildAndProcess/"+tc.name, func(t *testing.T) {
+			cfg := tc.op
+			cfg.OutputIDs = []string{"fake"}
+			cfg.OnError = "drop"
+			set := componenttest.NewNopTelemetrySettings()
+			op, err := cfg.Build(set)
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+			val := tc.input()
+			err = op.ProcessBatch(t.Context(), []*entry.Entry{val})
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				fake.ExpectEntry(t, tc.output())
+			}
+		})
+	}
+}
+
+func TestProcessDoesNotShareRows(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.Field = entry.NewBodyField("tenant")
+	cfg.Path = filepath.Join("testdata", "tenants.json")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := cfg.Build(set)
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	first := entry.New()
+	first.Body = map[string]any{"tenant": "t1"}
+	require.NoError(t, op.Process(t.Context(), first))
+	first.Attributes["tenant.owners"].([]any)[0] = "mallory"
+
+	second := entry.New()
+	second.Body = map[string]any{"tenant": "t1"}
+	require.NoError(t, op.Process(t.Context(), second))
+	require.Equal(t, []any{"alice", "bob"}, second.Attributes["tenant.owners"])
+}
+
+func TestProcessReloadedTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.csv")
+	require.NoError(t, os.WriteFile(path, []byte("id,error.message\nE100,disk full\n"), 0o600))
+
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.Field = entry.NewAttributeField("error_id")
+	cfg.Path = path
+	cfg.Key = "id"
+	cfg.ReloadInterval = time.Nanosecond
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := cfg.Build(set)
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	require.NoError(t, os.WriteFile(path, []byte("id,error.message\nE100,disk full\nE200,connection timeout\n"), 0o600))
+
+	e := entry.New()
+	e.Attributes = map[string]any{"error_id": "E200"}
+	require.NoError(t, op.Process(t.Context(), e))
+	require.Equal(t, map[string]any{"error_id": "E200", "error.message": "connection timeout"}, e.Attributes)
+}
@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/split"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+)
+
+var (
+	// javaExceptionRe matches the first line of a Java or .NET exception, e.g. "java.lang.IllegalStateException: boom"
+	javaExceptionRe = regexp.MustCompile(`^([a-zA-Z_$][\w$]*\.)+[\w$]*(Exception|Error|Throwable)(: .*)?$`)
+	// javaCauseRe matches the lines introducing the cause of a Java exception
+	javaCauseRe = regexp.MustCompile(`^(Caused by|Suppressed): `)
+	// pythonTracebackRe matches the first line of a Python traceback
+	pythonTracebackRe = regexp.MustCompile(`^Traceback \(most recent call last\):$`)
+	// pythonChainRe matches the lines separating chained Python tracebacks
+	pythonChainRe = regexp.MustCompile(`^(During handling of the above exception, another exception occurred:|The above exception was the direct cause of the following exception:)$`)
+	// pythonExceptionRe matches the last line of a Python traceback, e.g. "ValueError: boom"
+	pythonExceptionRe = regexp.MustCompile(`^[a-zA-Z_][\w.]*(: .*)?$`)
+	// goPanicRe matches the first line of a Go panic or fatal error
+	goPanicRe = regexp.MustCompile(`^(panic: |fatal error: )`)
+	// goroutineRe matches the header of a goroutine stack trace, e.g. "goroutine 1 [running]:"
+	goroutineRe = regexp.MustCompile(`^goroutine \d+ \[[^\]]+\]:$`)
+	// goFrameRe matches the function lines of a goroutine stack trace, e.g. "main.main()"
+	goFrameRe = regexp.MustCompile(`^(\S+\(.*\)|created by .+)$`)
+	// rubyFrameRe matches the frames of a Ruby backtrace, e.g. "from app.rb:5:in `<main>'"
+	rubyFrameRe = regexp.MustCompile("^from \\S+:\\d+:in ")
+)
+
+// AutoSplitFunc creates a bufio.SplitFunc that splits an incoming stream into entries made of
+// a first line followed by its continuation lines, as detected from common multi-line shapes:
+// indented lines, Java, .NET, Python, Go and Ruby stack traces, and JSON objects or arrays
+// spanning several lines. Lines are separated by '\n', so the encoding must be ASCII compatible.
+func AutoSplitFunc(flushAtEOF bool) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		lines := completeLines(data)
+		if len(lines) == 0 {
+			// Flush if no more data is expected
+			if atEOF && flushAtEOF {
+				return len(data), trimEntry(data), nil
+			}
+			return 0, nil, nil // read more data and try again
+		}
+		if isBlank(data[:lines[0]]) {
+			// a blank line that does not continue an entry is an empty entry
+			return lines[0] + 1, []byte{}, nil
+		}
+		if atEOF && flushAtEOF && lines[len(lines)-1] != len(data)-1 {
+			// the incomplete last line is not expected to be completed
+			lines = append(lines, len(data))
+		}
+
+		e := newEntry(data[:lines[0]])
+		for i := 1; i < len(lines); i++ {
+			line := data[lines[i-1]+1 : lines[i]]
+			if isBlank(line) && e.jsonDepth == 0 {
+				if i+1 == len(lines) {
+					// the blank line may continue the entry, depending on the next line
+					break
+				}
+				next := data[lines[i]+1 : lines[i+1]]
+				if e.resumes(next) {
+					continue
+				}
+				return lines[i-1] + 1, trimEntry(data[:lines[i-1]]), nil
+			}
+			if !e.continues(line) {
+				return lines[i-1] + 1, trimEntry(data[:lines[i-1]]), nil
+			}
+		}
+
+		// Flush if no more data is expected
+		if atEOF && flushAtEOF {
+			return len(data), trimEntry(data), nil
+		}
+		return 0, nil, nil // read more data to find out whether the entry continues
+	}
+}
+
+// completeLines returns the index of the newline ending each line of the data
+func completeLines(data []byte) []int {
+	var lines []int
+	for i := 0; i < len(data); {
+		j := bytes.IndexByte(data[i:], '\n')
+		if j < 0 {
+			break
+		}
+		lines = append(lines, i+j)
+		i += j + 1
+	}
+	return lines
+}
+
+func isBlank(line []byte) bool {
+	return len(bytes.TrimSpace(line)) == 0
+}
+
+func trimEntry(data []byte) []byte {
+	return bytes.TrimRight(data, "\r\n")
+}
+
+// entry tracks the multi-line shapes seen in the lines of an entry
+type entry struct {
+	// stackTrace is true once a stack trace is found, so that blank lines followed
+	// by a chained trace continue the entry
+	stackTrace bool
+	// pythonTraceback is true while the lines of a Python traceback are expected
+	pythonTraceback bool
+	// goroutine is true while the lines of a goroutine stack trace are expected
+	goroutine bool
+
+	// jsonDepth is the nesting depth of the JSON object or array the entry starts with
+	jsonDepth int
+	inString  bool
+	escaped   bool
+}
+
+func newEntry(firstLine []byte) *entry {
+	e := &entry{}
+	line := bytes.TrimRight(firstLine, "\r")
+	trimmed := bytes.TrimSpace(line)
+	if startsJSON(trimmed) || bytes.HasSuffix(trimmed, []byte("{")) || bytes.HasSuffix(trimmed, []byte("[")) {
+		e.scanJSON(line)
+	}
+	switch {
+	case pythonTracebackRe.Match(line):
+		e.stackTrace = true
+		e.pythonTraceback = true
+	case goPanicRe.Match(line):
+		e.stackTrace = true
+	case goroutineRe.Match(line):
+		e.stackTrace = true
+		e.goroutine = true
+	}
+	return e
+}
+
+// startsJSON returns whether the line is only the start of a JSON object or array, so that
+// lines such as "[2024-01-01 10:00:00] INFO msg" are not mistaken for JSON.
+func startsJSON(line []byte) bool {
+	if !bytes.HasPrefix(line, []byte("{")) && !bytes.HasPrefix(line, []byte("[")) {
+		return false
+	}
+	if json.Valid(line) {
+		return false
+	}
+	dec := json.NewDecoder(bytes.NewReader(line))
+	for {
+		if _, err := dec.Token(); err != nil {
+			// the line is a valid JSON prefix if it only lacks its end
+			return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+// continues returns whether the non blank line continues the entry
+func (e *entry) continues(line []byte) bool {
+	line = bytes.TrimRight(line, "\r")
+	if e.jsonDepth > 0 {
+		e.scanJSON(line)
+		return true
+	}
+
+	switch {
+	case line[0] == ' ' || line[0] == '\t':
+		return true
+	case javaExceptionRe.Match(line), javaCauseRe.Match(line):
+		e.stackTrace = true
+		return true
+	case pythonTracebackRe.Match(line):
+		e.stackTrace = true
+		e.pythonTraceback = true
+		return true
+	case e.stackTrace && pythonChainRe.Match(line):
+		return true
+	case e.pythonTraceback && pythonExceptionRe.Match(line):
+		// the exception ends the traceback
+		e.pythonTraceback = false
+		return true
+	case goroutineRe.Match(line):
+		e.stackTrace = true
+		e.goroutine = true
+		return true
+	case e.goroutine && goFrameRe.Match(line):
+		return true
+	case rubyFrameRe.Match(line):
+		e.stackTrace = true
+		return true
+	}
+	return false
+}
+
+// resumes returns whether the line following a blank line continues the entry
+func (e *entry) resumes(next []byte) bool {
+	if !e.stackTrace {
+		return false
+	}
+	next = bytes.TrimRight(next, "\r")
+	return pythonChainRe.Match(next) || pythonTracebackRe.Match(next) || goroutineRe.Match(next)
+}
+
+// scanJSON updates the nesting depth of the JSON object or array with the line
+func (e *entry) scanJSON(line []byte) {
+	for _, c := range line {
+		switch {
+		case e.escaped:
+			e.escaped = false
+		case e.inString:
+			switch c {
+			case '\\':
+				e.escaped = true
+			case '"':
+				e.inString = false
+			}
+		case c == '"':
+			e.inString = true
+		case c == '{' || c == '[':
+			e.jsonDepth++
+		case c == '}' || c == ']':
+			e.jsonDepth--
+		}
+	}
+	e.jsonDepth = max(e.jsonDepth, 0)
+}
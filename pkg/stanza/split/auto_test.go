@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/split/splittest"
+)
+
+func TestAutoSplitFunc(t *testing.T) {
+	javaTrace := `2024-01-01 12:00:00 ERROR failed to handle request
+java.lang.IllegalStateException: boom
+	at com.example.Handler.handle(Handler.java:42)
+	at com.example.Server.run(Server.java:10)
+Caused by: java.io.IOException: closed
+	at com.example.Conn.read(Conn.java:7)
+	... 2 more`
+	pythonTrace := `2024-01-01 12:00:00 ERROR failed
+Traceback (most recent call last):
+  File "app.py", line 3, in <module>
+    main()
+KeyError: 'a'
+
+During handling of the above exception, another exception occurred:
+
+Traceback (most recent call last):
+  File "app.py", line 5, in <module>
+    raise ValueError("b")
+ValueError: b`
+	goPanic := `panic: runtime error: index out of range [1] with length 1
+
+goroutine 1 [running]:
+main.(*Server).handle(0xc000010000, {0x0, 0x0})
+	/app/main.go:12 +0x1d
+main.main()
+	/app/main.go:5 +0x18
+created by main.start in goroutine 1
+	/app/main.go:20 +0x25`
+	dotnetTrace := `2024-01-01 12:00:00 ERROR failed
+System.InvalidOperationException: Operation is not valid
+   at App.Program.Main(String[] args) in C:\app\Program.cs:line 10
+ --- End of inner exception stack trace ---`
+	rubyTrace := "app.rb:2:in `foo': boom (RuntimeError)\nfrom app.rb:5:in `<main>'"
+	jsonObject := `{
+  "level": "info",
+  "msg": "a } in a string",
+  "tags": [
+    "a",
+    "b"
+  ]
+}`
+
+	testCases := []struct {
+		name       string
+		flushAtEOF bool
+		input      string
+		steps      []splittest.Step
+	}{
+		{
+			name:  "SingleLines",
+			input: "log1\nlog2\nlog3\n",
+			steps: []splittest.Step{
+				expectEntry("log1"),
+				expectEntry("log2"),
+			},
+		},
+		{
+			name:  "IndentedLines",
+			input: "log1\n  continued\n\tcontinued\nlog2\n",
+			steps: []splittest.Step{
+				expectEntry("log1\n  continued\n\tcontinued"),
+			},
+		},
+		{
+			name:  "CarriageReturn",
+			input: "log1\r\n  continued\r\nlog2\r\n",
+			steps: []splittest.Step{
+				splittest.ExpectAdvanceToken(len("log1\r\n  continued\r\n"), "log1\r\n  continued"),
+			},
+		},
+		{
+			name:  "Java",
+			input: javaTrace + "\nnext\n",
+			steps: []splittest.Step{
+				expectEntry(javaTrace),
+			},
+		},
+		{
+			name:  "Python",
+			input: pythonTrace + "\n2024-01-01 12:00:01 INFO next\n",
+			steps: []splittest.Step{
+				expectEntry(pythonTrace),
+			},
+		},
+		{
+			name:  "Go",
+			input: goPanic + "\nexit status 2\n",
+			steps: []splittest.Step{
+				expectEntry(goPanic),
+			},
+		},
+		{
+			name:  "DotNet",
+			input: dotnetTrace + "\nnext\n",
+			steps: []splittest.Step{
+				expectEntry(dotnetTrace),
+			},
+		},
+		{
+			name:  "Ruby",
+			input: rubyTrace + "\nnext\n",
+			steps: []splittest.Step{
+				expectEntry(rubyTrace),
+			},
+		},
+		{
+			name:  "JSON",
+			input: jsonObject + "\n" + jsonObject + "\nnext\n",
+			steps: []splittest.Step{
+				expectEntry(jsonObject),
+				expectEntry(jsonObject),
+			},
+		},
+		{
+			name:  "JSONAfterPrefix",
+			input: "2024-01-01 12:00:00 INFO payload: {\n\"a\": 1\n}\nnext\n",
+			steps: []splittest.Step{
+				expectEntry("2024-01-01 12:00:00 INFO payload: {\n\"a\": 1\n}"),
+			},
+		},
+		{
+			name:  "JSONArray",
+			input: "[\n  1,\n  2\n]\nnext\n",
+			steps: []splittest.Step{
+				expectEntry("[\n  1,\n  2\n]"),
+			},
+		},
+		{
+			name:  "BracketedTimestamp",
+			input: "[2024-01-01 10:00:00] INFO received list [1, 2\n[2024-01-01 10:00:01] INFO second\n[2024-01-01 10:00:02] INFO third\n",
+			steps: []splittest.Step{
+				expectEntry("[2024-01-01 10:00:00] INFO received list [1, 2"),
+				expectEntry("[2024-01-01 10:00:01] INFO second"),
+			},
+		},
+		{
+			name:  "BlankLineEndsEntry",
+			input: "log1\n  continued\n\nlog2\n",
+			steps: []splittest.Step{
+				expectEntry("log1\n  continued"),
+				splittest.ExpectAdvanceToken(1, ""),
+			},
+		},
+		{
+			name:  "WaitForContinuation",
+			input: "log1\n  continued\n",
+		},
+		{
+			name:       "FlushAtEOF",
+			flushAtEOF: true,
+			input:      "log1\nlog2\n  continued",
+			steps: []splittest.Step{
+				expectEntry("log1"),
+				splittest.ExpectAdvanceToken(len("log2\n  continued"), "log2\n  continued"),
+			},
+		},
+		{
+			name:       "FlushIncompleteLineAtEOF",
+			flushAtEOF: true,
+			input:      "log1\n  continued\nlog2",
+			steps: []splittest.Step{
+				expectEntry("log1\n  continued"),
+				splittest.ExpectAdvanceToken(len("log2"), "log2"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		splitFunc := AutoSplitFunc(tc.flushAtEOF)
+		t.Run(tc.name, splittest.New(splitFunc, []byte(tc.input), tc.steps...))
+	}
+}
+
+// expectEntry expects the entry followed by a newline to be read
+func expectEntry(entry string) splittest.Step {
+	return splittest.ExpectAdvanceToken(len(entry)+1, entry)
+}
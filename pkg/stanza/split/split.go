@@ -18,6 +18,8 @@ type Config struct {
 	LineStartPattern string `mapstructure:"line_start_pattern"`
 	LineEndPattern   string `mapstructure:"line_end_pattern"`
 	OmitPattern      bool   `mapstructure:"omit_pattern"`
+	// Auto groups lines into entries by detecting common multi-line shapes, such as stack traces
+	Auto bool `mapstructure:"auto"`
 }
 
 // Func will return a bufio.SplitFunc based on the config
@@ -29,9 +31,26 @@ func (c Config) Func(enc encoding.Encoding, flushAtEOF bool, maxLogSize int) (bu
 		if c.LineStartPattern != "" {
 			return nil, errors.New("line_start_pattern should not be set when using nop encoding")
 		}
+		if c.Auto {
+			return nil, errors.New("auto should not be set when using nop encoding")
+		}
 		return NoSplitFunc(maxLogSize), nil
 	}
 
+	if c.Auto {
+		if c.LineEndPattern != "" || c.LineStartPattern != "" {
+			return nil, errors.New("auto cannot be set with line_start_pattern or line_end_pattern")
+		}
+		newline, err := encodedNewline(enc)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(newline, []byte{'\n'}) {
+			return nil, errors.New("auto requires an ASCII compatible encoding")
+		}
+		return AutoSplitFunc(flushAtEOF), nil
+	}
+
 	if c.LineEndPattern == "" && c.LineStartPattern == "" {
 		return NewlineSplitFunc(enc, flushAtEOF)
 	}
@@ -58,6 +58,32 @@ func TestConfigFunc(t *testing.T) {
 		assert.Equal(t, []byte("foo"), token)
 	})
 
+	t.Run("Auto", func(t *testing.T) {
+		cfg := Config{Auto: true}
+		f, err := cfg.Func(unicode.UTF8, false, maxLogSize)
+		assert.NoError(t, err)
+
+		advance, token, err := f([]byte("foo\n  bar\nbaz\n"), false)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, advance)
+		assert.Equal(t, []byte("foo\n  bar"), token)
+	})
+
+	t.Run("AutoWithPattern", func(t *testing.T) {
+		cfg := Config{Auto: true, LineStartPattern: "foo"}
+		_, err := cfg.Func(unicode.UTF8, false, maxLogSize)
+		assert.EqualError(t, err, "auto cannot be set with line_start_pattern or line_end_pattern")
+	})
+
+	t.Run("AutoEncodingError", func(t *testing.T) {
+		cfg := Config{Auto: true}
+		_, err := cfg.Func(unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), false, maxLogSize)
+		assert.EqualError(t, err, "auto requires an ASCII compatible encoding")
+
+		_, err = cfg.Func(encoding.Nop, false, maxLogSize)
+		assert.EqualError(t, err, "auto should not be set when using nop encoding")
+	})
+
 	t.Run("InvalidStartRegex", func(t *testing.T) {
 		cfg := Config{LineStartPattern: "["}
 		_, err := cfg.Func(unicode.UTF8, false, maxLogSize)
@@ -5,6 +5,7 @@ package loki // import "github.com/open-telemetry/opentelemetry-collector-contri
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/grafana/loki/pkg/push"
 	"github.com/prometheus/common/model"
@@ -47,6 +48,13 @@ const (
 // to make this decision, as it includes all of the errors that were encountered,
 // as well as the number of items dropped and submitted.
 func LogsToLokiRequests(ld plog.Logs, defaultLabelsEnabled map[string]bool) map[string]PushRequest {
+	return LogsToLokiRequestsWithLabels(ld, defaultLabelsEnabled, nil, nil)
+}
+
+// LogsToLokiRequestsWithLabels is like LogsToLokiRequests, except that the given
+// resource and log attributes are promoted to labels in addition to the ones
+// selected by the "loki.resource.labels" and "loki.attribute.labels" hints.
+func LogsToLokiRequestsWithLabels(ld plog.Logs, defaultLabelsEnabled map[string]bool, resourceAttributes, logAttributes []string) map[string]PushRequest {
 	groups := map[string]pushRequestGroup{}
 
 	rls := ld.ResourceLogs()
@@ -69,7 +77,7 @@ func LogsToLokiRequests(ld plog.Logs, defaultLabelsEnabled map[string]bool) map[
 					groups[tenant] = group
 				}
 
-				entry, err := LogToLokiEntry(log, resource, scope, defaultLabelsEnabled)
+				entry, err := logToLokiEntry(log, resource, scope, defaultLabelsEnabled, resourceAttributes, logAttributes)
 				if err != nil {
 					// Couldn't convert so dropping log.
 					group.report.Errors = append(group.report.Errors, fmt.Errorf("failed to convert, dropping log: %w", err))
@@ -121,6 +129,10 @@ type PushEntry struct {
 
 // LogToLokiEntry converts LogRecord into Loki log entry enriched with normalized labels
 func LogToLokiEntry(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.InstrumentationScope, defaultLabelsEnabled map[string]bool) (*PushEntry, error) {
+	return logToLokiEntry(lr, rl, scope, defaultLabelsEnabled, nil, nil)
+}
+
+func logToLokiEntry(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.InstrumentationScope, defaultLabelsEnabled map[string]bool, resourceAttributes, logAttributes []string) (*PushEntry, error) {
 	// we may remove attributes, so change only our version
 	log := plog.NewLogRecord()
 	lr.CopyTo(log)
@@ -129,6 +141,10 @@ func LogToLokiEntry(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.Instru
 	resource := pcommon.NewResource()
 	rl.CopyTo(resource)
 
+	// the labels selected by the caller are handled as if they were hinted
+	appendHint(resource.Attributes(), hintResources, resourceAttributes...)
+	appendHint(log.Attributes(), hintAttributes, logAttributes...)
+
 	if enabled, ok := defaultLabelsEnabled[levelLabel]; !ok || enabled {
 		// adds level attribute from log.severityNumber
 		addLogLevelAttributeAndHint(log)
@@ -205,23 +221,29 @@ func addLogLevelAttributeAndHint(log plog.LogRecord) {
 	if log.SeverityNumber() == plog.SeverityNumberUnspecified {
 		return
 	}
-	addHint(log)
+	appendHint(log.Attributes(), hintAttributes, levelAttributeName)
 	if _, found := log.Attributes().Get(levelAttributeName); !found {
 		level := severityNumberToLevel[log.SeverityNumber().String()]
 		log.Attributes().PutStr(levelAttributeName, level)
 	}
 }
 
-func addHint(log plog.LogRecord) {
-	if value, found := log.Attributes().Get(hintAttributes); found {
+// appendHint appends the given attribute names to the hint, creating it if needed.
+func appendHint(attrs pcommon.Map, hint string, names ...string) {
+	if len(names) == 0 {
+		return
+	}
+	if value, found := attrs.Get(hint); found {
 		switch value.Type() {
 		case pcommon.ValueTypeSlice:
-			value.Slice().AppendEmpty().SetStr(levelAttributeName)
+			for _, name := range names {
+				value.Slice().AppendEmpty().SetStr(name)
+			}
 		case pcommon.ValueTypeStr:
-			log.Attributes().PutStr(hintAttributes, fmt.Sprintf("%s,%s", value.AsString(), levelAttributeName))
+			attrs.PutStr(hint, fmt.Sprintf("%s,%s", value.AsString(), strings.Join(names, ",")))
 		}
 	} else {
-		log.Attributes().PutStr(hintAttributes, levelAttributeName)
+		attrs.PutStr(hint, strings.Join(names, ","))
 	}
 }
 
@@ -494,6 +494,42 @@ func TestLogsToLokiRequestWithoutTenant(t *testing.T) {
 	}
 }
 
+func TestLogsToLokiRequestsWithLabels(t *testing.T) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "my-service")
+	rl.Resource().Attributes().PutStr("k8s.namespace.name", "default")
+	rl.Resource().Attributes().PutStr(hintResources, "k8s.namespace.name")
+	logs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, host := range []string{"guarana", "guarana", "acai"} {
+		lr := logs.AppendEmpty()
+		lr.Attributes().PutStr("host.name", host)
+		lr.Attributes().PutStr("http.method", "GET")
+	}
+
+	requests := LogsToLokiRequestsWithLabels(ld, map[string]bool{exporterLabel: false}, []string{"service.name"}, []string{"host.name"})
+	require.Len(t, requests, 1)
+	request := requests[""]
+	assert.Equal(t, 3, request.Report.NumSubmitted)
+
+	var labels []string
+	for _, stream := range request.Streams {
+		labels = append(labels, stream.Labels)
+		for _, entry := range stream.Entries {
+			// The promoted attributes are removed from the line.
+			assert.JSONEq(t, `{"attributes":{"http.method":"GET"}}`, entry.Line)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		`{host_name="guarana", job="my-service", k8s_namespace_name="default", service_name="my-service"}`,
+		`{host_name="acai", job="my-service", k8s_namespace_name="default", service_name="my-service"}`,
+	}, labels)
+
+	// The logs are left untouched.
+	_, ok := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get(hintAttributes)
+	assert.False(t, ok)
+}
+
 func TestLogToLokiEntry(t *testing.T) {
 	testCases := []struct {
 		name                 string
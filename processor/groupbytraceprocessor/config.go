@@ -5,6 +5,8 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
 // Config is the configuration for the processor.
@@ -27,9 +29,13 @@ type Config struct {
 	// Not yet implemented, and an error will be returned when this option is used.
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
-	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
+	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to
+	// the storage extension set by Storage. The traces held in the storage survive restarts.
 	// Useful when the duration to wait for traces to complete is high.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// Storage is the ID of the storage extension used when StoreOnDisk is set.
+	// Default: none.
+	Storage *component.ID `mapstructure:"storage"`
 }
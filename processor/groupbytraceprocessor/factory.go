@@ -24,7 +24,7 @@ const (
 )
 
 var (
-	errDiskStorageNotSupported    = errors.New("option 'disk storage' not supported in this release")
+	errDiskStorageNotConfigured   = errors.New("option 'store_on_disk' requires option 'storage' to be set")
 	errDiscardOrphansNotSupported = errors.New("option 'discard orphans' not supported in this release")
 )
 
@@ -58,17 +58,18 @@ func createTracesProcessor(
 ) (processor.Traces, error) {
 	oCfg := cfg.(*Config)
 
-	var st storage
-	if oCfg.StoreOnDisk {
-		return nil, errDiskStorageNotSupported
+	if oCfg.StoreOnDisk && oCfg.Storage == nil {
+		return nil, errDiskStorageNotConfigured
 	}
 	if oCfg.DiscardOrphans {
 		return nil, errDiscardOrphansNotSupported
 	}
 
 	processor := newGroupByTraceProcessor(params, nextConsumer, *oCfg)
-	// the only supported storage for now
-	st = newMemoryStorage(processor.telemetryBuilder)
-	processor.st = st
+	if oCfg.StoreOnDisk {
+		processor.st = newDiskStorage(*oCfg.Storage, params.ID, params.Logger)
+	} else {
+		processor.st = newMemoryStorage(processor.telemetryBuilder)
+	}
 	return processor, nil
 }
@@ -71,6 +71,7 @@ func newGroupByTraceProcessor(set processor.Settings, nextConsumer consumer.Trac
 	eventMachine.onTraceExpired = sp.onTraceExpired
 	eventMachine.onTraceReleased = sp.onTraceReleased
 	eventMachine.onTraceRemoved = sp.onTraceRemoved
+	eventMachine.onTraceRestored = sp.onTraceRestored
 
 	return sp
 }
@@ -88,19 +89,28 @@ func (*groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	sp.telemetryBuilder.ProcessorGroupbytraceTracesEvicted.Add(context.Background(), 0)
 	sp.telemetryBuilder.ProcessorGroupbytraceIncompleteReleases.Add(context.Background(), 0)
 	sp.telemetryBuilder.ProcessorGroupbytraceConfNumTraces.Record(context.Background(), (int64(sp.config.NumTraces)))
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+
+	restored, err := sp.st.start(ctx, host)
+	if err != nil {
+		return err
+	}
+	// the traces held by the storage from a previous run wait for the duration again
+	for _, traceID := range restored {
+		sp.eventMachine.restore(traceID)
+	}
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (sp *groupByTraceProcessor) Shutdown(_ context.Context) error {
+func (sp *groupByTraceProcessor) Shutdown(ctx context.Context) error {
 	sp.eventMachine.shutdown()
-	return sp.st.shutdown()
+	return sp.st.shutdown(ctx)
 }
 
 func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eventMachineWorker) error {
@@ -119,8 +129,31 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 
 	// at this point, we determined that we haven't seen the trace yet, so, record the
 	// traceID in the map and the spans to the storage
+	sp.putInBuffer(traceID, worker)
+
+	// we have the traceID in the memory, place the spans in the storage too
+	if err := sp.addSpans(traceID, trace.td); err != nil {
+		return fmt.Errorf("couldn't add spans to existing trace: %w", err)
+	}
 
-	// place the trace ID in the buffer, and check if an item had to be evicted
+	sp.scheduleRelease(traceID, worker)
+	return nil
+}
+
+func (sp *groupByTraceProcessor) onTraceRestored(traceID pcommon.TraceID, worker *eventMachineWorker) error {
+	if worker.buffer.contains(traceID) {
+		// spans for this trace were received before it got restored, it is scheduled already
+		return nil
+	}
+
+	// the spans are in the storage already, we only need to track the trace again
+	sp.putInBuffer(traceID, worker)
+	sp.scheduleRelease(traceID, worker)
+	return nil
+}
+
+// putInBuffer places the trace ID in the worker's buffer, removing the evicted trace from the storage, if any.
+func (sp *groupByTraceProcessor) putInBuffer(traceID pcommon.TraceID, worker *eventMachineWorker) {
 	evicted := worker.buffer.put(traceID)
 	if !evicted.IsEmpty() {
 		// delete from the storage
@@ -133,12 +166,10 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 		sp.logger.Info("trace evicted: in order to avoid this in the future, adjust the wait duration and/or number of traces to keep in memory",
 			zap.Stringer("traceID", evicted))
 	}
+}
 
-	// we have the traceID in the memory, place the spans in the storage too
-	if err := sp.addSpans(traceID, trace.td); err != nil {
-		return fmt.Errorf("couldn't add spans to existing trace: %w", err)
-	}
-
+// scheduleRelease fires the expiration of the trace once the wait duration is over.
+func (sp *groupByTraceProcessor) scheduleRelease(traceID pcommon.TraceID, worker *eventMachineWorker) {
 	sp.logger.Debug("scheduled to release trace", zap.Duration("duration", sp.config.WaitDuration))
 
 	time.AfterFunc(sp.config.WaitDuration, func() {
@@ -148,7 +179,6 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 			payload: traceID,
 		})
 	})
-	return nil
 }
 
 func (sp *groupByTraceProcessor) onTraceExpired(traceID pcommon.TraceID, worker *eventMachineWorker) error {
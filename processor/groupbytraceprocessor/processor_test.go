@@ -642,14 +642,14 @@ func (st *mockStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans,
 	return nil, nil
 }
 
-func (st *mockStorage) start() error {
+func (st *mockStorage) start(context.Context, component.Host) ([]pcommon.TraceID, error) {
 	if st.onStart != nil {
-		return st.onStart()
+		return nil, st.onStart()
 	}
-	return nil
+	return nil, nil
 }
 
-func (st *mockStorage) shutdown() error {
+func (st *mockStorage) shutdown(context.Context) error {
 	if st.onShutdown != nil {
 		return st.onShutdown()
 	}
@@ -0,0 +1,303 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	extensionstorage "go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	// diskStorageMetaKey holds the range of the log that may contain live entries.
+	diskStorageMetaKey = "wal"
+	// diskStorageEntryKeyPrefix is followed by the sequence number of the entry.
+	diskStorageEntryKeyPrefix = "wal/"
+	// diskStorageRestoreBatchSize is the number of entries read at once when restoring the traces.
+	diskStorageRestoreBatchSize = 256
+)
+
+var errInvalidDiskStorageValue = errors.New("invalid value in the disk storage")
+
+// diskStorage implements storage on top of a storage extension, keeping only the trace IDs in memory.
+// The spans are appended to a log: each call to createOrAppend writes a new entry holding the received
+// spans, and the entries of a trace are deleted with the trace. The range of the log that may hold live
+// entries is persisted along with the entries, so the traces can be restored after a restart.
+type diskStorage struct {
+	sync.Mutex
+	storageID   component.ID
+	componentID component.ID
+	logger      *zap.Logger
+	client      extensionstorage.Client
+
+	// entries holds, for each trace, the sequence numbers of the entries holding its spans.
+	entries map[pcommon.TraceID][]uint64
+	// live holds the sequence numbers of the entries that weren't deleted.
+	live map[uint64]struct{}
+	// head is the sequence number of the oldest live entry, tail the one of the next entry.
+	head, tail uint64
+
+	marshaler   ptrace.ProtoMarshaler
+	unmarshaler ptrace.ProtoUnmarshaler
+}
+
+var _ storage = (*diskStorage)(nil)
+
+func newDiskStorage(storageID, componentID component.ID, logger *zap.Logger) *diskStorage {
+	return &diskStorage{
+		storageID:   storageID,
+		componentID: componentID,
+		logger:      logger,
+		entries:     make(map[pcommon.TraceID][]uint64),
+		live:        make(map[uint64]struct{}),
+	}
+}
+
+func (st *diskStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	value, err := st.encodeEntry(traceID, td)
+	if err != nil {
+		return err
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	seq := st.tail
+	err = st.client.Batch(context.Background(),
+		extensionstorage.SetOperation(diskStorageEntryKey(seq), value),
+		extensionstorage.SetOperation(diskStorageMetaKey, encodeDiskStorageMeta(st.head, seq+1)),
+	)
+	if err != nil {
+		return err
+	}
+
+	st.tail++
+	st.entries[traceID] = append(st.entries[traceID], seq)
+	st.live[seq] = struct{}{}
+	return nil
+}
+
+func (st *diskStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	seqs, ok := st.entries[traceID]
+	if !ok {
+		return nil, nil
+	}
+	return st.readEntries(seqs)
+}
+
+func (st *diskStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	seqs, ok := st.entries[traceID]
+	if !ok {
+		return nil, nil
+	}
+	rss, err := st.readEntries(seqs)
+	if err != nil {
+		return nil, err
+	}
+
+	// the entries are only forgotten once deleted from the storage, so that memory doesn't
+	// disagree with the storage when the deletion fails
+	deleted := make(map[uint64]struct{}, len(seqs))
+	ops := make([]*extensionstorage.Operation, 0, len(seqs)+1)
+	for _, seq := range seqs {
+		deleted[seq] = struct{}{}
+		ops = append(ops, extensionstorage.DeleteOperation(diskStorageEntryKey(seq)))
+	}
+	head := st.nextHead(deleted)
+	ops = append(ops, extensionstorage.SetOperation(diskStorageMetaKey, encodeDiskStorageMeta(head, st.tail)))
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return nil, err
+	}
+
+	delete(st.entries, traceID)
+	for _, seq := range seqs {
+		delete(st.live, seq)
+	}
+	st.head = head
+	return rss, nil
+}
+
+// start gets a client of the storage extension and restores the traces held before the last shutdown.
+func (st *diskStorage) start(ctx context.Context, host component.Host) ([]pcommon.TraceID, error) {
+	client, err := getStorageClient(ctx, host, st.storageID, st.componentID)
+	if err != nil {
+		return nil, err
+	}
+	st.client = client
+	return st.restore(ctx)
+}
+
+func (st *diskStorage) shutdown(ctx context.Context) error {
+	if st.client == nil {
+		return nil
+	}
+	return st.client.Close(ctx)
+}
+
+// restore reads the live entries of the log, returning the IDs of the traces they hold.
+func (st *diskStorage) restore(ctx context.Context) ([]pcommon.TraceID, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	meta, err := st.client.Get(ctx, diskStorageMetaKey)
+	if err != nil {
+		return nil, err
+	}
+	if meta == nil {
+		return nil, nil
+	}
+	head, tail, err := decodeDiskStorageMeta(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	var traceIDs []pcommon.TraceID
+	for start := head; start < tail; start += diskStorageRestoreBatchSize {
+		end := min(start+diskStorageRestoreBatchSize, tail)
+		ops := make([]*extensionstorage.Operation, 0, end-start)
+		for seq := start; seq < end; seq++ {
+			ops = append(ops, extensionstorage.GetOperation(diskStorageEntryKey(seq)))
+		}
+		if err := st.client.Batch(ctx, ops...); err != nil {
+			return nil, err
+		}
+
+		for i, op := range ops {
+			if op.Value == nil {
+				// the entry was deleted along with its trace
+				continue
+			}
+			if len(op.Value) < len(pcommon.TraceID{}) {
+				return nil, fmt.Errorf("%w: entry %q", errInvalidDiskStorageValue, op.Key)
+			}
+			traceID := pcommon.TraceID(op.Value[:len(pcommon.TraceID{})])
+			if _, ok := st.entries[traceID]; !ok {
+				traceIDs = append(traceIDs, traceID)
+			}
+			seq := start + uint64(i)
+			st.entries[traceID] = append(st.entries[traceID], seq)
+			st.live[seq] = struct{}{}
+		}
+	}
+
+	st.head = head
+	st.tail = tail
+	st.advanceHead()
+
+	st.logger.Debug("restored traces from the disk storage", zap.Int("traces", len(traceIDs)))
+	return traceIDs, nil
+}
+
+// readEntries reads the spans held by the entries with the given sequence numbers.
+func (st *diskStorage) readEntries(seqs []uint64) ([]ptrace.ResourceSpans, error) {
+	ops := make([]*extensionstorage.Operation, len(seqs))
+	for i, seq := range seqs {
+		ops[i] = extensionstorage.GetOperation(diskStorageEntryKey(seq))
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return nil, err
+	}
+
+	var result []ptrace.ResourceSpans
+	for _, op := range ops {
+		if op.Value == nil {
+			return nil, fmt.Errorf("entry %q not found in the disk storage", op.Key)
+		}
+		td, err := st.decodeEntry(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode entry %q: %w", op.Key, err)
+		}
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			result = append(result, td.ResourceSpans().At(i))
+		}
+	}
+	return result, nil
+}
+
+// advanceHead moves the head of the log past the entries that were deleted.
+func (st *diskStorage) advanceHead() {
+	st.head = st.nextHead(nil)
+}
+
+// nextHead returns the head of the log past the entries that were deleted, and the entries
+// being deleted.
+func (st *diskStorage) nextHead(deleting map[uint64]struct{}) uint64 {
+	head := st.head
+	for ; head < st.tail; head++ {
+		_, live := st.live[head]
+		_, isDeleting := deleting[head]
+		if live && !isDeleting {
+			break
+		}
+	}
+	return head
+}
+
+// encodeEntry serializes the trace ID followed by the spans.
+func (st *diskStorage) encodeEntry(traceID pcommon.TraceID, td ptrace.Traces) ([]byte, error) {
+	data, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return nil, err
+	}
+	return append(traceID[:], data...), nil
+}
+
+func (st *diskStorage) decodeEntry(value []byte) (ptrace.Traces, error) {
+	if len(value) < len(pcommon.TraceID{}) {
+		return ptrace.Traces{}, errInvalidDiskStorageValue
+	}
+	return st.unmarshaler.UnmarshalTraces(value[len(pcommon.TraceID{}):])
+}
+
+func diskStorageEntryKey(seq uint64) string {
+	return fmt.Sprintf("%s%016x", diskStorageEntryKeyPrefix, seq)
+}
+
+func encodeDiskStorageMeta(head, tail uint64) []byte {
+	meta := make([]byte, 16)
+	binary.BigEndian.PutUint64(meta, head)
+	binary.BigEndian.PutUint64(meta[8:], tail)
+	return meta
+}
+
+func decodeDiskStorageMeta(meta []byte) (head, tail uint64, err error) {
+	if len(meta) != 16 {
+		return 0, 0, fmt.Errorf("%w: %q", errInvalidDiskStorageValue, diskStorageMetaKey)
+	}
+	head = binary.BigEndian.Uint64(meta)
+	tail = binary.BigEndian.Uint64(meta[8:])
+	if head > tail {
+		return 0, 0, fmt.Errorf("%w: %q", errInvalidDiskStorageValue, diskStorageMetaKey)
+	}
+	return head, tail, nil
+}
+
+// getStorageClient returns a client of the storage extension with the given ID.
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID) (extensionstorage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+
+	storageExt, ok := ext.(extensionstorage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindProcessor, componentID, "")
+}
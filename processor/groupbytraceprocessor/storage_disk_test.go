@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	extensionstorage "go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+func TestDiskCreateAndGetTrace(t *testing.T) {
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	st := newDiskStorage(storagetest.NewStorageID("test"), processortest.NewNopSettings(metadata.Type).ID, zap.NewNop())
+	restored, err := st.start(t.Context(), host)
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+	defer func() {
+		assert.NoError(t, st.shutdown(t.Context()))
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	first := simpleTracesWithID(traceID)
+	first.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("first")
+	second := simpleTracesWithID(traceID)
+	second.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("second")
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, first))
+	require.NoError(t, st.createOrAppend(traceID, second))
+
+	// verify
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{first.ResourceSpans().At(0), second.ResourceSpans().At(0)}, retrieved)
+
+	retrieved, err = st.get(pcommon.TraceID([16]byte{2, 3, 4, 5}))
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestDiskDeleteTrace(t *testing.T) {
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	st := newDiskStorage(storagetest.NewStorageID("test"), processortest.NewNopSettings(metadata.Type).ID, zap.NewNop())
+	_, err := st.start(t.Context(), host)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, st.shutdown(t.Context()))
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	trace := simpleTracesWithID(traceID)
+	require.NoError(t, st.createOrAppend(traceID, trace))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{trace.ResourceSpans().At(0)}, deleted)
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+	assert.Equal(t, st.tail, st.head)
+
+	deleted, err = st.delete(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+}
+
+func TestDiskDeleteTraceFailure(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+	componentID := processortest.NewNopSettings(metadata.Type).ID
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	st := newDiskStorage(storageID, componentID, zap.NewNop())
+	_, err := st.start(t.Context(), host)
+	require.NoError(t, err)
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	client := &failingWriteClient{Client: st.client}
+	st.client = client
+
+	// test
+	client.fail = true
+	_, err = st.delete(traceID)
+
+	// verify
+	require.ErrorIs(t, err, errFailingWrite)
+	assert.Equal(t, []uint64{0}, st.entries[traceID])
+	assert.Equal(t, uint64(0), st.head)
+
+	client.fail = false
+	deleted, err := st.delete(traceID)
+	require.NoError(t, err)
+	assert.Len(t, deleted, 1)
+	require.NoError(t, st.shutdown(t.Context()))
+
+	restarted := newDiskStorage(storageID, componentID, zap.NewNop())
+	restored, err := restarted.start(t.Context(), host)
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+	assert.NoError(t, restarted.shutdown(t.Context()))
+}
+
+func TestDiskRestoreTraces(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+	componentID := processortest.NewNopSettings(metadata.Type).ID
+
+	deletedID := pcommon.TraceID([16]byte{1})
+	keptID := pcommon.TraceID([16]byte{2})
+
+	st := newDiskStorage(storageID, componentID, zap.NewNop())
+	_, err := st.start(t.Context(), host)
+	require.NoError(t, err)
+	require.NoError(t, st.createOrAppend(deletedID, simpleTracesWithID(deletedID)))
+	require.NoError(t, st.createOrAppend(keptID, simpleTracesWithID(keptID)))
+	require.NoError(t, st.createOrAppend(deletedID, simpleTracesWithID(deletedID)))
+	require.NoError(t, st.createOrAppend(keptID, simpleTracesWithID(keptID)))
+	_, err = st.delete(deletedID)
+	require.NoError(t, err)
+	require.NoError(t, st.shutdown(t.Context()))
+
+	// test
+	restarted := newDiskStorage(storageID, componentID, zap.NewNop())
+	restored, err := restarted.start(t.Context(), host)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, restarted.shutdown(t.Context()))
+	}()
+
+	// verify
+	assert.Equal(t, []pcommon.TraceID{keptID}, restored)
+	assert.Equal(t, uint64(1), restarted.head)
+	assert.Equal(t, uint64(4), restarted.tail)
+
+	retrieved, err := restarted.get(keptID)
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	assert.Equal(t, keptID, retrieved[0].ScopeSpans().At(0).Spans().At(0).TraceID())
+
+	retrieved, err = restarted.get(deletedID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+
+	// new entries are appended after the restored ones
+	require.NoError(t, restarted.createOrAppend(keptID, simpleTracesWithID(keptID)))
+	assert.Equal(t, []uint64{1, 3, 4}, restarted.entries[keptID])
+}
+
+func TestDiskStorageExtensionNotFound(t *testing.T) {
+	st := newDiskStorage(storagetest.NewStorageID("test"), processortest.NewNopSettings(metadata.Type).ID, zap.NewNop())
+	_, err := st.start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, `storage extension "test_storage/test" not found`)
+	assert.NoError(t, st.shutdown(t.Context()))
+
+	host := storagetest.NewStorageHost().WithNonStorageExtension("test")
+	st = newDiskStorage(storagetest.NewNonStorageID("test"), processortest.NewNopSettings(metadata.Type).ID, zap.NewNop())
+	_, err = st.start(t.Context(), host)
+	assert.ErrorContains(t, err, `extension "non_storage/test" is not a storage extension`)
+}
+
+func TestProcessorReleasesTracesRestoredFromDisk(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	// the first processor holds the trace when it is shut down
+	cfg := &Config{
+		NumTraces:    10,
+		NumWorkers:   1,
+		WaitDuration: time.Hour,
+		StoreOnDisk:  true,
+		Storage:      &storageID,
+	}
+	firstSink := &consumertest.TracesSink{}
+	first, err := createTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, firstSink)
+	require.NoError(t, err)
+	require.NoError(t, first.Start(t.Context(), host))
+	require.NoError(t, first.ConsumeTraces(t.Context(), simpleTracesWithID(traceID)))
+	firstStorage := first.(*groupByTraceProcessor).st.(*diskStorage)
+	assert.Eventually(t, func() bool {
+		firstStorage.Lock()
+		defer firstStorage.Unlock()
+		return len(firstStorage.entries) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, first.Shutdown(t.Context()))
+	assert.Zero(t, firstSink.SpanCount())
+
+	// the second processor releases it after the wait duration
+	cfg.WaitDuration = time.Millisecond
+	secondSink := &consumertest.TracesSink{}
+	second, err := createTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, secondSink)
+	require.NoError(t, err)
+	require.NoError(t, second.Start(t.Context(), host))
+	defer func() {
+		assert.NoError(t, second.Shutdown(t.Context()))
+	}()
+
+	assert.Eventually(t, func() bool {
+		return secondSink.SpanCount() == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, traceID, secondSink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+
+	secondStorage := second.(*groupByTraceProcessor).st.(*diskStorage)
+	assert.Eventually(t, func() bool {
+		secondStorage.Lock()
+		defer secondStorage.Unlock()
+		return len(secondStorage.entries) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+var errFailingWrite = errors.New("failing write")
+
+// failingWriteClient is a storage client whose batches holding writes fail when fail is set.
+type failingWriteClient struct {
+	extensionstorage.Client
+	fail bool
+}
+
+func (c *failingWriteClient) Batch(ctx context.Context, ops ...*extensionstorage.Operation) error {
+	if c.fail && slices.ContainsFunc(ops, func(op *extensionstorage.Operation) bool {
+		return op.Type != extensionstorage.Get
+	}) {
+		return errFailingWrite
+	}
+	return c.Client.Batch(ctx, ops...)
+}
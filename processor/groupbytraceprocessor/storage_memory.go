@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
@@ -78,12 +79,12 @@ func (st *memoryStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans
 	return st.content[traceID], nil
 }
 
-func (st *memoryStorage) start() error {
+func (st *memoryStorage) start(context.Context, component.Host) ([]pcommon.TraceID, error) {
 	go st.periodicMetrics()
-	return nil
+	return nil, nil
 }
 
-func (st *memoryStorage) shutdown() error {
+func (st *memoryStorage) shutdown(context.Context) error {
 	st.stoppedLock.Lock()
 	defer st.stoppedLock.Unlock()
 	st.stopped = true
@@ -4,8 +4,13 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -246,6 +251,75 @@ type DecisionCacheConfig struct {
 	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
 }
 
+// DecisionStoreType indicates the implementation of the store sharing the sampling decisions.
+type DecisionStoreType string
+
+const (
+	// MemoryDecisionStore shares the decisions between the processors of a single collector.
+	MemoryDecisionStore DecisionStoreType = "memory"
+	// RedisDecisionStore shares the decisions between collectors through a server speaking the Redis protocol.
+	RedisDecisionStore DecisionStoreType = "redis"
+)
+
+// DecisionStoreConfig holds the configuration of the store sharing the sampling decisions
+// with the other instances of the processor.
+type DecisionStoreConfig struct {
+	// Type is the type of the store. If left empty, the decisions are not shared.
+	Type DecisionStoreType `mapstructure:"type"`
+	// TTL is how long a published decision is kept by the store. If 0, decisions never expire.
+	TTL time.Duration `mapstructure:"ttl"`
+	// Timeout is the maximum time spent publishing or looking up the decisions of a batch of traces.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Memory holds the configuration of the memory store.
+	Memory MemoryDecisionStoreConfig `mapstructure:"memory"`
+	// Redis holds the configuration of the Redis store.
+	Redis RedisDecisionStoreConfig `mapstructure:"redis"`
+}
+
+// MemoryDecisionStoreConfig holds the configuration of the memory decision store.
+// Processors of the same collector configured with the same memory store settings share the same store.
+type MemoryDecisionStoreConfig struct {
+	// MaxDecisions is the number of decisions the store holds before evicting the least recently used one.
+	MaxDecisions int `mapstructure:"max_decisions"`
+}
+
+// RedisDecisionStoreConfig holds the configuration of the Redis decision store.
+type RedisDecisionStoreConfig struct {
+	// Endpoint is the address of the server, in the host:port format.
+	Endpoint string `mapstructure:"endpoint"`
+	// Password is the password used to authenticate to the server.
+	Password configopaque.String `mapstructure:"password"`
+	// DB is the database selected after connecting to the server.
+	DB int `mapstructure:"db"`
+	// KeyPrefix is prepended to the trace IDs to build the keys of the decisions.
+	KeyPrefix string `mapstructure:"key_prefix"`
+}
+
+// Validate checks if the decision store configuration is valid.
+func (cfg *DecisionStoreConfig) Validate() error {
+	switch cfg.Type {
+	case "":
+		return nil
+	case MemoryDecisionStore:
+		if cfg.Memory.MaxDecisions <= 0 {
+			return errors.New("memory.max_decisions must be positive")
+		}
+	case RedisDecisionStore:
+		if cfg.Redis.Endpoint == "" {
+			return errors.New("redis.endpoint must be set")
+		}
+	default:
+		return fmt.Errorf("unknown decision store type %q", cfg.Type)
+	}
+	if cfg.TTL < 0 {
+		return errors.New("ttl must not be negative")
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	// DecisionWait is the desired wait time from the arrival of the first span of
@@ -269,4 +343,11 @@ type Config struct {
 	Options []Option `mapstructure:"-"`
 	// Make decision as soon as a policy matches
 	SampleOnFirstMatch bool `mapstructure:"sample_on_first_match"`
+	// Storage is the ID of a storage extension used to persist the pending traces and the
+	// sampling decisions, so they survive a restart of the collector.
+	// If left empty, they are only kept in memory.
+	Storage *component.ID `mapstructure:"storage"`
+	// DecisionStore configures sharing the sampling decisions with the other instances of the
+	// processor, so that the spans of a trace received by different instances get the same decision.
+	DecisionStore DecisionStoreConfig `mapstructure:"decision_store"`
 }
@@ -30,12 +30,22 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, sub.Unmarshal(cfg))
 
+	storageID := component.MustNewID("file_storage")
+
 	assert.Equal(t,
 		&Config{
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
 			DecisionCache:           DecisionCacheConfig{SampledCacheSize: 1_000, NonSampledCacheSize: 10_000},
+			Storage:                 &storageID,
+			DecisionStore: DecisionStoreConfig{
+				Type:    RedisDecisionStore,
+				TTL:     2 * time.Minute,
+				Timeout: time.Second,
+				Memory:  MemoryDecisionStoreConfig{MaxDecisions: 100_000},
+				Redis:   RedisDecisionStoreConfig{Endpoint: "localhost:6379", KeyPrefix: "tail_sampling:"},
+			},
 			PolicyCfgs: []PolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
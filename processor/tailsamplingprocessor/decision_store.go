@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/decisionstore"
+)
+
+// memoryDecisionStoreKey identifies the memory stores that can be shared between processors.
+type memoryDecisionStoreKey struct {
+	maxDecisions int
+	ttl          time.Duration
+}
+
+// sharedMemoryDecisionStore is a memory store shared by the processors of the collector
+// configured with the same settings. It is closed when the last processor closes it.
+type sharedMemoryDecisionStore struct {
+	decisionstore.Store
+	key  memoryDecisionStoreKey
+	refs int
+}
+
+var (
+	memoryDecisionStoresMu sync.Mutex
+	memoryDecisionStores   = map[memoryDecisionStoreKey]*sharedMemoryDecisionStore{}
+)
+
+// newDecisionStore returns the store configured by cfg, or nil if the decisions are not shared.
+func newDecisionStore(cfg DecisionStoreConfig) (decisionstore.Store, error) {
+	switch cfg.Type {
+	case MemoryDecisionStore:
+		return acquireMemoryDecisionStore(memoryDecisionStoreKey{maxDecisions: cfg.Memory.MaxDecisions, ttl: cfg.TTL})
+	case RedisDecisionStore:
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.Endpoint,
+			Password: string(cfg.Redis.Password),
+			DB:       cfg.Redis.DB,
+		})
+		return decisionstore.NewRedisStore(client, cfg.Redis.KeyPrefix, cfg.TTL), nil
+	default:
+		return nil, nil
+	}
+}
+
+func acquireMemoryDecisionStore(key memoryDecisionStoreKey) (decisionstore.Store, error) {
+	memoryDecisionStoresMu.Lock()
+	defer memoryDecisionStoresMu.Unlock()
+
+	if s, ok := memoryDecisionStores[key]; ok {
+		s.refs++
+		return s, nil
+	}
+	store, err := decisionstore.NewMemoryStore(key.maxDecisions, key.ttl)
+	if err != nil {
+		return nil, err
+	}
+	s := &sharedMemoryDecisionStore{Store: store, key: key, refs: 1}
+	memoryDecisionStores[key] = s
+	return s, nil
+}
+
+func (s *sharedMemoryDecisionStore) Close(ctx context.Context) error {
+	memoryDecisionStoresMu.Lock()
+	defer memoryDecisionStoresMu.Unlock()
+
+	s.refs--
+	if s.refs > 0 {
+		return nil
+	}
+	delete(memoryDecisionStores, s.key)
+	return s.Store.Close(ctx)
+}
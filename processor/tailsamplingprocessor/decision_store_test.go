@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/decisionstore"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/pkg/samplingpolicy"
+)
+
+func TestDecisionStoreSharedBetweenProcessors(t *testing.T) {
+	cfg := DecisionStoreConfig{
+		Type:    MemoryDecisionStore,
+		TTL:     time.Minute,
+		Timeout: time.Second,
+		Memory:  MemoryDecisionStoreConfig{MaxDecisions: 10},
+	}
+	traceID := uInt64ToTraceID(1)
+
+	first, firstEvaluator, firstSink := newDecisionStoreTestProcessor(t, cfg)
+	second, secondEvaluator, secondSink := newDecisionStoreTestProcessor(t, cfg)
+	require.NoError(t, first.Start(t.Context(), componenttest.NewNopHost()))
+	require.NoError(t, second.Start(t.Context(), componenttest.NewNopHost()))
+	assert.Same(t, first.decisionStore, second.decisionStore)
+
+	// The first processor samples the trace.
+	firstEvaluator.NextDecision = samplingpolicy.Sampled
+	require.NoError(t, first.ConsumeTraces(t.Context(), simpleTracesWithID(traceID)))
+	first.policyTicker.OnTick()
+	first.policyTicker.OnTick()
+	assert.Equal(t, 1, firstEvaluator.EvaluationCount)
+	assert.Len(t, firstSink.AllTraces(), 1)
+
+	// The second processor follows the decision of the first one instead of evaluating its policies.
+	secondEvaluator.NextDecision = samplingpolicy.NotSampled
+	require.NoError(t, second.ConsumeTraces(t.Context(), simpleTracesWithID(traceID)))
+	second.policyTicker.OnTick()
+	second.policyTicker.OnTick()
+	assert.Equal(t, 0, secondEvaluator.EvaluationCount)
+	require.Len(t, secondSink.AllTraces(), 1)
+	assert.Equal(t, traceID, secondSink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+
+	require.NoError(t, first.Shutdown(t.Context()))
+	memoryDecisionStoresMu.Lock()
+	assert.Len(t, memoryDecisionStores, 1)
+	memoryDecisionStoresMu.Unlock()
+
+	require.NoError(t, second.Shutdown(t.Context()))
+	memoryDecisionStoresMu.Lock()
+	assert.Empty(t, memoryDecisionStores)
+	memoryDecisionStoresMu.Unlock()
+}
+
+func TestWithDecisionStore(t *testing.T) {
+	store, err := decisionstore.NewMemoryStore(10, 0)
+	require.NoError(t, err)
+	notSampledID := uInt64ToTraceID(1)
+	sampledID := uInt64ToTraceID(2)
+	_, err = store.Publish(t.Context(), map[pcommon.TraceID]bool{notSampledID: false})
+	require.NoError(t, err)
+
+	p, evaluator, sink := newDecisionStoreTestProcessor(t, DecisionStoreConfig{}, WithDecisionStore(store))
+	require.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+
+	evaluator.NextDecision = samplingpolicy.Sampled
+	require.NoError(t, p.ConsumeTraces(t.Context(), simpleTracesWithID(notSampledID)))
+	require.NoError(t, p.ConsumeTraces(t.Context(), simpleTracesWithID(sampledID)))
+	p.policyTicker.OnTick()
+	p.policyTicker.OnTick()
+
+	// Only the trace without a shared decision was evaluated, and its decision was published.
+	assert.Equal(t, 1, evaluator.EvaluationCount)
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, sampledID, sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+
+	require.NoError(t, p.Shutdown(t.Context()))
+
+	// The store given as an option is not closed by the processor.
+	decisions, err := store.Lookup(t.Context(), []pcommon.TraceID{notSampledID, sampledID})
+	require.NoError(t, err)
+	assert.Equal(t, map[pcommon.TraceID]bool{notSampledID: false, sampledID: true}, decisions)
+}
+
+func TestDecisionStoreConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  DecisionStoreConfig
+		err  string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "memory",
+			cfg:  DecisionStoreConfig{Type: MemoryDecisionStore, Timeout: time.Second, Memory: MemoryDecisionStoreConfig{MaxDecisions: 10}},
+		},
+		{
+			name: "redis",
+			cfg:  DecisionStoreConfig{Type: RedisDecisionStore, Timeout: time.Second, Redis: RedisDecisionStoreConfig{Endpoint: "localhost:6379"}},
+		},
+		{
+			name: "unknown type",
+			cfg:  DecisionStoreConfig{Type: "etcd"},
+			err:  `unknown decision store type "etcd"`,
+		},
+		{
+			name: "memory without max decisions",
+			cfg:  DecisionStoreConfig{Type: MemoryDecisionStore, Timeout: time.Second},
+			err:  "memory.max_decisions must be positive",
+		},
+		{
+			name: "redis without endpoint",
+			cfg:  DecisionStoreConfig{Type: RedisDecisionStore, Timeout: time.Second},
+			err:  "redis.endpoint must be set",
+		},
+		{
+			name: "negative ttl",
+			cfg:  DecisionStoreConfig{Type: MemoryDecisionStore, TTL: -time.Second, Timeout: time.Second, Memory: MemoryDecisionStoreConfig{MaxDecisions: 10}},
+			err:  "ttl must not be negative",
+		},
+		{
+			name: "no timeout",
+			cfg:  DecisionStoreConfig{Type: MemoryDecisionStore, Memory: MemoryDecisionStoreConfig{MaxDecisions: 10}},
+			err:  "timeout must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func newDecisionStoreTestProcessor(t *testing.T, cfg DecisionStoreConfig, opts ...Option) (*tailSamplingSpanProcessor, *mockPolicyEvaluator, *consumertest.TracesSink) {
+	sink := new(consumertest.TracesSink)
+	evaluator := &mockPolicyEvaluator{}
+	p, err := newTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), sink, Config{
+		DecisionWait:  defaultTestDecisionWait,
+		NumTraces:     defaultNumTraces,
+		DecisionStore: cfg,
+		Options: append([]Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+			withPolicies([]*policy{
+				{name: "mock-policy", evaluator: evaluator, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy"))},
+			}),
+		}, opts...),
+	})
+	require.NoError(t, err)
+	return p.(*tailSamplingSpanProcessor), evaluator, sink
+}
+
+func TestDecisionStoreConflictingDecisions(t *testing.T) {
+	store, err := decisionstore.NewMemoryStore(10, 0)
+	require.NoError(t, err)
+	// Both processors evaluate the traces in the same tick: none of them sees the decision
+	// of the other one on lookup, and their decisions conflict when published.
+	racing := &lookupMissingStore{Store: store}
+	sampledFirstID := uInt64ToTraceID(1)
+	notSampledFirstID := uInt64ToTraceID(2)
+
+	first, firstEvaluator, firstSink := newDecisionStoreTestProcessor(t, DecisionStoreConfig{}, WithDecisionStore(racing))
+	second, secondEvaluator, secondSink := newDecisionStoreTestProcessor(t, DecisionStoreConfig{}, WithDecisionStore(racing))
+	require.NoError(t, first.Start(t.Context(), componenttest.NewNopHost()))
+	require.NoError(t, second.Start(t.Context(), componenttest.NewNopHost()))
+
+	firstEvaluator.NextDecision = samplingpolicy.Sampled
+	secondEvaluator.NextDecision = samplingpolicy.NotSampled
+	require.NoError(t, first.ConsumeTraces(t.Context(), simpleTracesWithID(sampledFirstID)))
+	require.NoError(t, second.ConsumeTraces(t.Context(), simpleTracesWithID(sampledFirstID)))
+	first.policyTicker.OnTick()
+	first.policyTicker.OnTick()
+	second.policyTicker.OnTick()
+	second.policyTicker.OnTick()
+
+	firstEvaluator.NextDecision = samplingpolicy.NotSampled
+	secondEvaluator.NextDecision = samplingpolicy.Sampled
+	require.NoError(t, first.ConsumeTraces(t.Context(), simpleTracesWithID(notSampledFirstID)))
+	require.NoError(t, second.ConsumeTraces(t.Context(), simpleTracesWithID(notSampledFirstID)))
+	first.policyTicker.OnTick()
+	first.policyTicker.OnTick()
+	second.policyTicker.OnTick()
+	second.policyTicker.OnTick()
+
+	// Both processors evaluated both traces, and acted on the decisions published first.
+	assert.Equal(t, 2, firstEvaluator.EvaluationCount)
+	assert.Equal(t, 2, secondEvaluator.EvaluationCount)
+	for _, sink := range []*consumertest.TracesSink{firstSink, secondSink} {
+		require.Len(t, sink.AllTraces(), 1)
+		assert.Equal(t, sampledFirstID, sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+	}
+
+	require.NoError(t, first.Shutdown(t.Context()))
+	require.NoError(t, second.Shutdown(t.Context()))
+}
+
+func TestDecisionStoreClosedOnStartFailure(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	p, err := newTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), sink, Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		Storage:      &testStorageID,
+		DecisionStore: DecisionStoreConfig{
+			Type:    MemoryDecisionStore,
+			Timeout: time.Second,
+			Memory:  MemoryDecisionStoreConfig{MaxDecisions: 10},
+		},
+		Options: []Option{withDecisionBatcher(newSyncIDBatcher())},
+	})
+	require.NoError(t, err)
+
+	err = p.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, `storage extension "test_storage" not found`)
+	memoryDecisionStoresMu.Lock()
+	assert.Empty(t, memoryDecisionStores)
+	memoryDecisionStoresMu.Unlock()
+	require.NoError(t, p.Shutdown(t.Context()))
+}
+
+// lookupMissingStore is a store whose lookups never find a decision, as if the
+// decisions were published by other instances after the lookup.
+type lookupMissingStore struct {
+	decisionstore.Store
+}
+
+func (*lookupMissingStore) Lookup(context.Context, []pcommon.TraceID) (map[pcommon.TraceID]bool, error) {
+	return map[pcommon.TraceID]bool{}, nil
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package decisionstore // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/decisionstore"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type memoryDecision struct {
+	sampled   bool
+	expiresAt time.Time
+}
+
+// memoryStore implements Store in memory, sharing the decisions between the processors
+// of a single collector using the same instance.
+type memoryStore struct {
+	mu    sync.Mutex
+	cache *lru.Cache[pcommon.TraceID, memoryDecision]
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemoryStore returns a Store keeping the decisions in memory.
+// The size parameter indicates the amount of decisions the store will hold before it
+// starts evicting the least recently used one. Decisions expire after ttl, or never if ttl is 0.
+func NewMemoryStore(size int, ttl time.Duration) (Store, error) {
+	c, err := lru.New[pcommon.TraceID, memoryDecision](size)
+	if err != nil {
+		return nil, err
+	}
+	return &memoryStore{cache: c, ttl: ttl, now: time.Now}, nil
+}
+
+func (s *memoryStore) Publish(_ context.Context, decisions map[pcommon.TraceID]bool) (map[pcommon.TraceID]bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	stored := make(map[pcommon.TraceID]bool, len(decisions))
+	for id, sampled := range decisions {
+		if existing, ok := s.get(id, now); ok {
+			stored[id] = existing
+			continue
+		}
+		d := memoryDecision{sampled: sampled}
+		if s.ttl > 0 {
+			d.expiresAt = now.Add(s.ttl)
+		}
+		s.cache.Add(id, d)
+		stored[id] = sampled
+	}
+	return stored, nil
+}
+
+func (s *memoryStore) Lookup(_ context.Context, ids []pcommon.TraceID) (map[pcommon.TraceID]bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	decisions := make(map[pcommon.TraceID]bool)
+	for _, id := range ids {
+		if sampled, ok := s.get(id, now); ok {
+			decisions[id] = sampled
+		}
+	}
+	return decisions, nil
+}
+
+// get returns the decision for the given id, removing it if it has expired.
+func (s *memoryStore) get(id pcommon.TraceID, now time.Time) (bool, bool) {
+	d, ok := s.cache.Get(id)
+	if !ok {
+		return false, false
+	}
+	if !d.expiresAt.IsZero() && !now.Before(d.expiresAt) {
+		s.cache.Remove(id)
+		return false, false
+	}
+	return d.sampled, true
+}
+
+func (s *memoryStore) Close(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.Purge()
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package decisionstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestMemoryStorePublishAndLookup(t *testing.T) {
+	s, err := NewMemoryStore(10, 0)
+	require.NoError(t, err)
+
+	sampled := pcommon.TraceID([16]byte{1})
+	notSampled := pcommon.TraceID([16]byte{2})
+	unknown := pcommon.TraceID([16]byte{3})
+
+	stored, err := s.Publish(t.Context(), map[pcommon.TraceID]bool{sampled: true, notSampled: false})
+	require.NoError(t, err)
+	assert.Equal(t, map[pcommon.TraceID]bool{sampled: true, notSampled: false}, stored)
+	// The first published decision wins, and is returned instead of the published one.
+	stored, err = s.Publish(t.Context(), map[pcommon.TraceID]bool{sampled: false, unknown: false})
+	require.NoError(t, err)
+	assert.Equal(t, map[pcommon.TraceID]bool{sampled: true, unknown: false}, stored)
+
+	decisions, err := s.Lookup(t.Context(), []pcommon.TraceID{sampled, notSampled, unknown})
+	require.NoError(t, err)
+	assert.Equal(t, map[pcommon.TraceID]bool{sampled: true, notSampled: false, unknown: false}, decisions)
+
+	require.NoError(t, s.Close(t.Context()))
+}
+
+func TestMemoryStoreExpiration(t *testing.T) {
+	s, err := NewMemoryStore(10, time.Minute)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	s.(*memoryStore).now = func() time.Time { return now }
+
+	id := pcommon.TraceID([16]byte{1})
+	_, err = s.Publish(t.Context(), map[pcommon.TraceID]bool{id: true})
+	require.NoError(t, err)
+
+	now = now.Add(59 * time.Second)
+	decisions, err := s.Lookup(t.Context(), []pcommon.TraceID{id})
+	require.NoError(t, err)
+	assert.Equal(t, map[pcommon.TraceID]bool{id: true}, decisions)
+
+	now = now.Add(time.Second)
+	decisions, err = s.Lookup(t.Context(), []pcommon.TraceID{id})
+	require.NoError(t, err)
+	assert.Empty(t, decisions)
+
+	// A new decision can be published once the previous one expired.
+	stored, err := s.Publish(t.Context(), map[pcommon.TraceID]bool{id: false})
+	require.NoError(t, err)
+	assert.Equal(t, map[pcommon.TraceID]bool{id: false}, stored)
+	decisions, err = s.Lookup(t.Context(), []pcommon.TraceID{id})
+	require.NoError(t, err)
+	assert.Equal(t, map[pcommon.TraceID]bool{id: false}, decisions)
+}
+
+func TestMemoryStoreExceedsSizeLimit(t *testing.T) {
+	s, err := NewMemoryStore(2, 0)
+	require.NoError(t, err)
+
+	ids := []pcommon.TraceID{{1}, {2}, {3}}
+	for _, id := range ids {
+		_, err = s.Publish(t.Context(), map[pcommon.TraceID]bool{id: true})
+		require.NoError(t, err)
+	}
+
+	decisions, err := s.Lookup(t.Context(), ids)
+	require.NoError(t, err)
+	assert.Equal(t, map[pcommon.TraceID]bool{ids[1]: true, ids[2]: true}, decisions)
+}
+
+func TestMemoryStoreInvalidSize(t *testing.T) {
+	_, err := NewMemoryStore(0, 0)
+	assert.Error(t, err)
+}
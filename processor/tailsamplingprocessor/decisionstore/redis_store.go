@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package decisionstore // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/decisionstore"
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	redisSampled    = "1"
+	redisNotSampled = "0"
+)
+
+// redisStore implements Store on top of a server speaking the Redis protocol,
+// sharing the decisions between collectors.
+type redisStore struct {
+	client    redis.UniversalClient
+	keyPrefix string
+	ttl       time.Duration
+}
+
+var _ Store = (*redisStore)(nil)
+
+// NewRedisStore returns a Store keeping the decisions in a server speaking the Redis protocol.
+// Each decision is stored under the key prefix followed by the hex encoded trace ID, and
+// expires after ttl, or never if ttl is 0. The store owns the client and closes it on Close.
+func NewRedisStore(client redis.UniversalClient, keyPrefix string, ttl time.Duration) Store {
+	return &redisStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+		ttl:       ttl,
+	}
+}
+
+// Publish claims each trace ID with SET NX GET, which requires Redis 7.0 or later: the
+// decision is only set if the key doesn't exist, and the existing decision is returned otherwise.
+func (s *redisStore) Publish(ctx context.Context, decisions map[pcommon.TraceID]bool) (map[pcommon.TraceID]bool, error) {
+	stored := make(map[pcommon.TraceID]bool, len(decisions))
+	if len(decisions) == 0 {
+		return stored, nil
+	}
+
+	ids := make([]pcommon.TraceID, 0, len(decisions))
+	cmds := make([]*redis.StatusCmd, 0, len(decisions))
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for id, sampled := range decisions {
+			value := redisNotSampled
+			if sampled {
+				value = redisSampled
+			}
+			ids = append(ids, id)
+			cmds = append(cmds, pipe.SetArgs(ctx, s.key(id), value, redis.SetArgs{Mode: "NX", TTL: s.ttl, Get: true}))
+		}
+		return nil
+	})
+	// The pipeline returns redis.Nil when a key was set, each command is checked below.
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	var errs error
+	for i, cmd := range cmds {
+		value, err := cmd.Result()
+		switch {
+		case errors.Is(err, redis.Nil):
+			// The key didn't exist, the published decision was stored.
+			stored[ids[i]] = decisions[ids[i]]
+		case err != nil:
+			errs = errors.Join(errs, err)
+		case value == redisSampled:
+			stored[ids[i]] = true
+		case value == redisNotSampled:
+			stored[ids[i]] = false
+		default:
+			errs = errors.Join(errs, fmt.Errorf("invalid decision for key %q: %v", s.key(ids[i]), value))
+		}
+	}
+	return stored, errs
+}
+
+func (s *redisStore) Lookup(ctx context.Context, ids []pcommon.TraceID) (map[pcommon.TraceID]bool, error) {
+	decisions := make(map[pcommon.TraceID]bool)
+	if len(ids) == 0 {
+		return decisions, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = s.key(id)
+	}
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(ids) {
+		return nil, fmt.Errorf("expected %d values, got %d", len(ids), len(values))
+	}
+
+	var errs error
+	for i, value := range values {
+		switch value {
+		case nil:
+		case redisSampled:
+			decisions[ids[i]] = true
+		case redisNotSampled:
+			decisions[ids[i]] = false
+		default:
+			errs = errors.Join(errs, fmt.Errorf("invalid decision for key %q: %v", keys[i], value))
+		}
+	}
+	return decisions, errs
+}
+
+func (s *redisStore) Close(context.Context) error {
+	return s.client.Close()
+}
+
+func (s *redisStore) key(id pcommon.TraceID) string {
+	return s.keyPrefix + hex.EncodeToString(id[:])
+}
@@ -0,0 +1,297 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package decisionstore
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestRedisStorePublishAndLookup(t *testing.T) {
+	server := newRedisStandIn(t)
+	s := NewRedisStore(server.client(), "tailsampling:", time.Minute)
+	defer func() {
+		require.NoError(t, s.Close(t.Context()))
+	}()
+
+	sampled := pcommon.TraceID([16]byte{1})
+	notSampled := pcommon.TraceID([16]byte{2})
+	unknown := pcommon.TraceID([16]byte{3})
+
+	stored, err := s.Publish(t.Context(), map[pcommon.TraceID]bool{sampled: true, notSampled: false})
+	require.NoError(t, err)
+	assert.Equal(t, map[pcommon.TraceID]bool{sampled: true, notSampled: false}, stored)
+	// The first published decision wins, and is returned instead of the published one.
+	stored, err = s.Publish(t.Context(), map[pcommon.TraceID]bool{sampled: false, notSampled: true})
+	require.NoError(t, err)
+	assert.Equal(t, map[pcommon.TraceID]bool{sampled: true, notSampled: false}, stored)
+
+	decisions, err := s.Lookup(t.Context(), []pcommon.TraceID{sampled, notSampled, unknown})
+	require.NoError(t, err)
+	assert.Equal(t, map[pcommon.TraceID]bool{sampled: true, notSampled: false}, decisions)
+
+	value, ttl := server.get("tailsampling:01000000000000000000000000000000")
+	assert.Equal(t, "1", value)
+	assert.Equal(t, time.Minute, ttl)
+}
+
+func TestRedisStoreSharedBetweenInstances(t *testing.T) {
+	server := newRedisStandIn(t)
+	first := NewRedisStore(server.client(), "", 0)
+	second := NewRedisStore(server.client(), "", 0)
+	defer func() {
+		require.NoError(t, first.Close(t.Context()))
+		require.NoError(t, second.Close(t.Context()))
+	}()
+
+	id := pcommon.TraceID([16]byte{1})
+	_, err := first.Publish(t.Context(), map[pcommon.TraceID]bool{id: false})
+	require.NoError(t, err)
+
+	decisions, err := second.Lookup(t.Context(), []pcommon.TraceID{id})
+	require.NoError(t, err)
+	assert.Equal(t, map[pcommon.TraceID]bool{id: false}, decisions)
+
+	// The second instance gets the decision of the first one when publishing a conflicting decision.
+	stored, err := second.Publish(t.Context(), map[pcommon.TraceID]bool{id: true})
+	require.NoError(t, err)
+	assert.Equal(t, map[pcommon.TraceID]bool{id: false}, stored)
+
+	_, ttl := server.get("01000000000000000000000000000000")
+	assert.Zero(t, ttl)
+}
+
+func TestRedisStoreInvalidValue(t *testing.T) {
+	server := newRedisStandIn(t)
+	s := NewRedisStore(server.client(), "", 0)
+	defer func() {
+		require.NoError(t, s.Close(t.Context()))
+	}()
+
+	id := pcommon.TraceID([16]byte{1})
+	server.set(hexTraceID(id), "1")
+	badID := pcommon.TraceID([16]byte{2})
+	server.set(hexTraceID(badID), "maybe")
+
+	decisions, err := s.Lookup(t.Context(), []pcommon.TraceID{id, badID})
+	assert.ErrorContains(t, err, `invalid decision for key "02000000000000000000000000000000": maybe`)
+	assert.Equal(t, map[pcommon.TraceID]bool{id: true}, decisions)
+}
+
+func TestRedisStoreUnavailable(t *testing.T) {
+	server := newRedisStandIn(t)
+	client := server.client()
+	require.NoError(t, server.listener.Close())
+	server.wg.Wait()
+
+	s := NewRedisStore(client, "", 0)
+	defer func() {
+		_ = s.Close(t.Context())
+	}()
+
+	_, err := s.Publish(t.Context(), map[pcommon.TraceID]bool{{1}: true})
+	assert.Error(t, err)
+	_, err = s.Lookup(t.Context(), []pcommon.TraceID{{1}})
+	assert.Error(t, err)
+}
+
+func hexTraceID(id pcommon.TraceID) string {
+	return fmt.Sprintf("%x", id[:])
+}
+
+type redisStandInValue struct {
+	value string
+	ttl   time.Duration
+}
+
+// redisStandIn is a local server speaking the subset of the Redis protocol used by the store.
+type redisStandIn struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu   sync.Mutex
+	data map[string]redisStandInValue
+}
+
+func newRedisStandIn(t *testing.T) *redisStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &redisStandIn{
+		listener: listener,
+		data:     make(map[string]redisStandInValue),
+	}
+	s.wg.Add(1)
+	go s.serve()
+	t.Cleanup(func() {
+		_ = listener.Close()
+		s.wg.Wait()
+	})
+	return s
+}
+
+func (s *redisStandIn) client() *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:            s.listener.Addr().String(),
+		DisableIdentity: true,
+		MaxRetries:      -1,
+	})
+}
+
+func (s *redisStandIn) get(key string) (string, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.data[key]
+	return v.value, v.ttl
+}
+
+func (s *redisStandIn) set(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = redisStandInValue{value: value}
+}
+
+func (s *redisStandIn) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *redisStandIn) handle(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readRedisCommand(r)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, s.execute(args)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *redisStandIn) execute(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "SETNX":
+		if _, ok := s.data[args[1]]; ok {
+			return ":0\r\n"
+		}
+		s.data[args[1]] = redisStandInValue{value: args[2]}
+		return ":1\r\n"
+	case "SET":
+		v := redisStandInValue{value: args[2]}
+		var nx, get bool
+		for i := 3; i < len(args); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "NX":
+				nx = true
+			case "GET":
+				get = true
+			case "EX", "PX":
+				n, _ := strconv.Atoi(args[i+1])
+				v.ttl = time.Duration(n) * time.Second
+				if strings.EqualFold(args[i], "PX") {
+					v.ttl = time.Duration(n) * time.Millisecond
+				}
+				i++
+			}
+		}
+		existing, ok := s.data[args[1]]
+		if !ok || !nx {
+			s.data[args[1]] = v
+		}
+		switch {
+		case get && ok:
+			return fmt.Sprintf("$%d\r\n%s\r\n", len(existing.value), existing.value)
+		case get, ok && nx:
+			return "$-1\r\n"
+		default:
+			return "+OK\r\n"
+		}
+	case "MGET":
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "*%d\r\n", len(args)-1)
+		for _, key := range args[1:] {
+			if v, ok := s.data[key]; ok {
+				fmt.Fprintf(&sb, "$%d\r\n%s\r\n", len(v.value), v.value)
+			} else {
+				sb.WriteString("$-1\r\n")
+			}
+		}
+		return sb.String()
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+// readRedisCommand reads a command sent as an array of bulk strings.
+func readRedisCommand(r *bufio.Reader) ([]string, error) {
+	line, err := readRedisLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 2 || line[0] != '*' {
+		return nil, errors.New("expected an array")
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n < 1 {
+		return nil, errors.New("invalid array length")
+	}
+
+	args := make([]string, n)
+	for i := range args {
+		line, err = readRedisLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(line) < 2 || line[0] != '$' {
+			return nil, errors.New("expected a bulk string")
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 {
+			return nil, errors.New("invalid bulk string length")
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func readRedisLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\r\n"), nil
+}
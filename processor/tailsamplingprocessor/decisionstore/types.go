@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package decisionstore // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/decisionstore"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Store shares sampling decisions between instances of the tail sampling processor,
+// so that the spans of a trace received by different instances get the same decision.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Publish records the decisions made for the given trace IDs, true meaning sampled,
+	// and returns the decisions stored for them. The first decision published for a
+	// trace ID wins: publishing a decision for a trace ID that already has one does not
+	// replace it, and the existing decision is returned instead. Claiming a trace ID and
+	// reading the decision of an existing claim is atomic, so that instances acting on
+	// the returned decisions agree with each other.
+	Publish(ctx context.Context, decisions map[pcommon.TraceID]bool) (map[pcommon.TraceID]bool, error)
+	// Lookup returns the decisions published for the given trace IDs, true meaning sampled.
+	// Trace IDs without a published decision are not present in the returned map.
+	Lookup(ctx context.Context, ids []pcommon.TraceID) (map[pcommon.TraceID]bool, error)
+	// Close releases the resources held by the store.
+	Close(ctx context.Context) error
+}
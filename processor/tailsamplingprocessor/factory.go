@@ -30,6 +30,16 @@ func createDefaultConfig() component.Config {
 		DecisionWait:       30 * time.Second,
 		NumTraces:          50000,
 		SampleOnFirstMatch: false,
+		DecisionStore: DecisionStoreConfig{
+			TTL:     5 * time.Minute,
+			Timeout: time.Second,
+			Memory: MemoryDecisionStoreConfig{
+				MaxDecisions: 100_000,
+			},
+			Redis: RedisDecisionStoreConfig{
+				KeyPrefix: "tail_sampling:",
+			},
+		},
 	}
 }
 
@@ -25,6 +25,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/decisionstore"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
@@ -68,6 +69,14 @@ type tailSamplingSpanProcessor struct {
 	setPolicyMux       sync.Mutex
 	pendingPolicy      []PolicyCfg
 	sampleOnFirstMatch bool
+	storageID          *component.ID
+	// persister is nil when no storage is configured.
+	persister    *persister
+	maxDecisions int
+	// decisionStore is nil when the decisions are not shared with other instances.
+	decisionStore     decisionstore.Store
+	decisionStoreCfg  DecisionStoreConfig
+	ownsDecisionStore bool
 }
 
 type traceLimiter interface {
@@ -136,6 +145,9 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 		logger:             telemetrySettings.Logger,
 		numTracesOnMap:     &atomic.Uint64{},
 		sampleOnFirstMatch: cfg.SampleOnFirstMatch,
+		storageID:          cfg.Storage,
+		maxDecisions:       cfg.DecisionCache.SampledCacheSize + cfg.DecisionCache.NonSampledCacheSize,
+		decisionStoreCfg:   cfg.DecisionStore,
 	}
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
 
@@ -211,6 +223,15 @@ func WithNonSampledDecisionCache(c cache.Cache[bool]) Option {
 	}
 }
 
+// WithDecisionStore sets the store which the processor uses to share its sampling decisions
+// with other instances, instead of the one configured by Config.DecisionStore.
+// The processor does not close the given store.
+func WithDecisionStore(s decisionstore.Store) Option {
+	return func(tsp *tailSamplingSpanProcessor) {
+		tsp.decisionStore = s
+	}
+}
+
 func withRecordPolicy() Option {
 	return func(tsp *tailSamplingSpanProcessor) {
 		tsp.recordPolicy = true
@@ -285,8 +306,8 @@ type policyDecisionMetrics struct {
 }
 
 type policyMetrics struct {
-	idNotFoundOnMapCount, evaluateErrorCount, decisionSampled, decisionNotSampled, decisionDropped int64
-	tracesSampledByPolicyDecision                                                                  []map[samplingpolicy.Decision]policyDecisionMetrics
+	idNotFoundOnMapCount, evaluateErrorCount, decisionSampled, decisionNotSampled, decisionDropped, decisionShared int64
+	tracesSampledByPolicyDecision                                                                                  []map[samplingpolicy.Decision]policyDecisionMetrics
 }
 
 func newPolicyMetrics(numPolicies int) *policyMetrics {
@@ -400,6 +421,13 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 	batch, _ := tsp.decisionBatcher.CloseCurrentAndTakeFirstBatch()
 	batchLen := len(batch)
 
+	sharedDecisions := tsp.lookupSharedDecisions(batch)
+	var decisionsToPublish map[pcommon.TraceID]bool
+	if tsp.decisionStore != nil {
+		decisionsToPublish = make(map[pcommon.TraceID]bool, batchLen)
+	}
+
+	decided := make([]decidedTrace, 0, batchLen)
 	for _, id := range batch {
 		d, ok := tsp.idToTrace.Load(id)
 		if !ok {
@@ -409,24 +437,51 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace := d.(*samplingpolicy.TraceData)
 		trace.DecisionTime = time.Now()
 
-		decision := tsp.makeDecision(id, trace, metrics)
+		var decision samplingpolicy.Decision
+		if sampled, ok := sharedDecisions[id]; ok {
+			// Another instance already decided, follow its decision.
+			decision = sharedDecision(sampled, metrics)
+		} else {
+			decision = tsp.makeDecision(id, trace, metrics)
+			if decisionsToPublish != nil {
+				decisionsToPublish[id] = decision == samplingpolicy.Sampled
+			}
+		}
+		decided = append(decided, decidedTrace{id: id, trace: trace, decision: decision})
+	}
+
+	// Another instance may have decided for the same traces since the lookup, the decisions
+	// are only acted on once claimed in the store, following the decisions stored first.
+	storedDecisions := tsp.publishDecisions(decisionsToPublish)
+
+	for _, dt := range decided {
+		decision := dt.decision
+		if sampled, ok := storedDecisions[dt.id]; ok && sampled != (decision == samplingpolicy.Sampled) {
+			decision = overriddenDecision(decision, sampled, metrics)
+		}
 
 		tsp.telemetry.ProcessorTailSamplingGlobalCountTracesSampled.Add(tsp.ctx, 1, decisionToAttributes[decision])
 
 		// Sampled or not, remove the batches
+		trace := dt.trace
 		trace.Lock()
 		allSpans := trace.ReceivedBatches
 		trace.FinalDecision = decision
 		trace.ReceivedBatches = ptrace.NewTraces()
 		trace.Unlock()
+		tsp.persister.recordDecision(dt.id, decision == samplingpolicy.Sampled)
 
 		if decision == samplingpolicy.Sampled {
-			tsp.releaseSampledTrace(ctx, id, allSpans)
+			tsp.releaseSampledTrace(ctx, dt.id, allSpans)
 		} else {
-			tsp.releaseNotSampledTrace(id)
+			tsp.releaseNotSampledTrace(dt.id)
 		}
 	}
 
+	if err := tsp.persister.flush(ctx, &tsp.idToTrace); err != nil {
+		tsp.logger.Warn("Failed to persist the sampling state", zap.Error(err))
+	}
+
 	tsp.telemetry.ProcessorTailSamplingSamplingDecisionTimerLatency.Record(tsp.ctx, int64(time.Since(startTime)/time.Millisecond))
 	tsp.telemetry.ProcessorTailSamplingSamplingTracesOnMemory.Record(tsp.ctx, int64(tsp.numTracesOnMap.Load()))
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceDroppedTooEarly.Add(tsp.ctx, metrics.idNotFoundOnMapCount)
@@ -446,11 +501,81 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		zap.Int64("sampled", metrics.decisionSampled),
 		zap.Int64("notSampled", metrics.decisionNotSampled),
 		zap.Int64("dropped", metrics.decisionDropped),
+		zap.Int64("shared", metrics.decisionShared),
 		zap.Int64("droppedPriorToEvaluation", metrics.idNotFoundOnMapCount),
 		zap.Int64("policyEvaluationErrors", metrics.evaluateErrorCount),
 	)
 }
 
+// lookupSharedDecisions returns the decisions published by other instances for the given trace IDs.
+func (tsp *tailSamplingSpanProcessor) lookupSharedDecisions(ids []pcommon.TraceID) map[pcommon.TraceID]bool {
+	if tsp.decisionStore == nil || len(ids) == 0 {
+		return nil
+	}
+	ctx, cancel := tsp.decisionStoreContext()
+	defer cancel()
+	decisions, err := tsp.decisionStore.Lookup(ctx, ids)
+	if err != nil {
+		tsp.logger.Warn("Failed to look up the shared sampling decisions", zap.Error(err))
+	}
+	return decisions
+}
+
+// publishDecisions shares the decisions made by this instance with the other instances, and
+// returns the decisions stored for them, which differ from the published ones when another
+// instance published its decision first. No decisions are returned when publishing fails.
+func (tsp *tailSamplingSpanProcessor) publishDecisions(decisions map[pcommon.TraceID]bool) map[pcommon.TraceID]bool {
+	if tsp.decisionStore == nil || len(decisions) == 0 {
+		return nil
+	}
+	ctx, cancel := tsp.decisionStoreContext()
+	defer cancel()
+	stored, err := tsp.decisionStore.Publish(ctx, decisions)
+	if err != nil {
+		tsp.logger.Warn("Failed to publish the sampling decisions", zap.Error(err))
+	}
+	return stored
+}
+
+// decisionStoreContext returns the context bounding the calls to the decision store.
+func (tsp *tailSamplingSpanProcessor) decisionStoreContext() (context.Context, context.CancelFunc) {
+	if tsp.decisionStoreCfg.Timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), tsp.decisionStoreCfg.Timeout)
+}
+
+// decidedTrace is a trace of the batch evaluated on tick, with the decision made for it.
+type decidedTrace struct {
+	id       pcommon.TraceID
+	trace    *samplingpolicy.TraceData
+	decision samplingpolicy.Decision
+}
+
+func sharedDecision(sampled bool, metrics *policyMetrics) samplingpolicy.Decision {
+	metrics.decisionShared++
+	if sampled {
+		metrics.decisionSampled++
+		return samplingpolicy.Sampled
+	}
+	metrics.decisionNotSampled++
+	return samplingpolicy.NotSampled
+}
+
+// overriddenDecision replaces the decision made by this instance with the decision another
+// instance published first, moving the trace from the count of its decision to the shared ones.
+func overriddenDecision(decision samplingpolicy.Decision, sampled bool, metrics *policyMetrics) samplingpolicy.Decision {
+	switch decision {
+	case samplingpolicy.Sampled:
+		metrics.decisionSampled--
+	case samplingpolicy.NotSampled:
+		metrics.decisionNotSampled--
+	case samplingpolicy.Dropped:
+		metrics.decisionDropped--
+	}
+	return sharedDecision(sampled, metrics)
+}
+
 func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *samplingpolicy.TraceData, metrics *policyMetrics) samplingpolicy.Decision {
 	finalDecision := samplingpolicy.NotSampled
 	samplingDecisions := map[samplingpolicy.Decision]*policy{
@@ -618,6 +743,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 			// If the final decision hasn't been made, add the new spans under the lock.
 			appendToTraces(actualData.ReceivedBatches, resourceSpans, spans)
 			actualData.Unlock()
+			tsp.persister.markDirty(id)
 			continue
 		}
 
@@ -647,16 +773,100 @@ func (*tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.decisionStore == nil {
+		store, err := newDecisionStore(tsp.decisionStoreCfg)
+		if err != nil {
+			return err
+		}
+		tsp.decisionStore = store
+		tsp.ownsDecisionStore = store != nil
+	}
+	if tsp.storageID != nil {
+		client, err := getStorageClient(ctx, host, *tsp.storageID, tsp.set.ID)
+		if err != nil {
+			return errors.Join(err, tsp.closeDecisionStore(ctx))
+		}
+		tsp.persister = newPersister(client, tsp.logger, tsp.maxDecisions)
+		if err := tsp.restoreState(ctx); err != nil {
+			// The client is closed here, the Shutdown following the failed Start must not use it.
+			tsp.persister = nil
+			return errors.Join(err, client.Close(ctx), tsp.closeDecisionStore(ctx))
+		}
+	}
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
+// restoreState loads the decisions and the pending traces persisted before the last shutdown.
+// Restored traces are evaluated after decision_wait, as if they had just arrived.
+func (tsp *tailSamplingSpanProcessor) restoreState(ctx context.Context) error {
+	traces, decisions, err := tsp.persister.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range decisions {
+		if d.sampled {
+			tsp.sampledIDCache.Put(d.id, true)
+		} else {
+			tsp.nonSampledIDCache.Put(d.id, true)
+		}
+	}
+
+	currTime := time.Now()
+	var restored int
+	for i, trace := range traces {
+		if tsp.numTracesOnMap.Load() >= tsp.maxNumTraces {
+			tsp.logger.Warn("Not restoring all the persisted traces, num_traces was reached",
+				zap.Int("restored", restored), zap.Int("persisted", len(traces)))
+			// Delete the traces that weren't restored from the storage on the next flush.
+			for _, skipped := range traces[i:] {
+				tsp.persister.markDirty(skipped.id)
+			}
+			break
+		}
+
+		spanCount := &atomic.Int64{}
+		spanCount.Store(int64(trace.receivedBatches.SpanCount()))
+		td := &samplingpolicy.TraceData{
+			ArrivalTime:     trace.arrivalTime,
+			SpanCount:       spanCount,
+			ReceivedBatches: trace.receivedBatches,
+		}
+		if _, loaded := tsp.idToTrace.LoadOrStore(trace.id, td); loaded {
+			continue
+		}
+		restored++
+		tsp.decisionBatcher.AddToCurrentBatch(trace.id)
+		tsp.numTracesOnMap.Add(1)
+		tsp.traceLimiter.AcceptTrace(ctx, trace.id, currTime)
+	}
+
+	tsp.logger.Debug("Restored the persisted sampling state",
+		zap.Int("traces", restored),
+		zap.Int("decisions", len(decisions)),
+	)
+	return nil
+}
+
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
-	return nil
+	return errors.Join(tsp.closeDecisionStore(ctx), tsp.persister.close(ctx, &tsp.idToTrace))
+}
+
+// closeDecisionStore closes the decision store created by the processor, leaving the stores
+// given with WithDecisionStore to their owner.
+func (tsp *tailSamplingSpanProcessor) closeDecisionStore(ctx context.Context) error {
+	if !tsp.ownsDecisionStore {
+		return nil
+	}
+	store := tsp.decisionStore
+	tsp.decisionStore = nil
+	tsp.ownsDecisionStore = false
+	return store.Close(ctx)
 }
 
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletionTime time.Time) {
@@ -667,6 +877,7 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 		// Subtract one from numTracesOnMap per https://godoc.org/sync/atomic#AddUint64
 		tsp.numTracesOnMap.Add(^uint64(0))
 		tsp.traceLimiter.OnDeleteTrace()
+		tsp.persister.markDirty(traceID)
 	}
 	if trace == nil {
 		tsp.logger.Debug("Attempt to delete trace ID not on table", zap.Stringer("id", traceID))
@@ -0,0 +1,401 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/pkg/samplingpolicy"
+)
+
+// Keys of the state persisted in the storage extension.
+const (
+	// storageTraceIndexKey holds the IDs of the persisted pending traces.
+	storageTraceIndexKey = "traces"
+	// storageTraceKeyPrefix prefixes the keys holding the spans of each pending trace.
+	storageTraceKeyPrefix = "trace/"
+	// storageDecisionsKey holds the list of the persisted decision segments.
+	storageDecisionsKey = "decisions"
+	// storageDecisionsKeyPrefix prefixes the keys holding a segment of decisions.
+	storageDecisionsKeyPrefix = "decisions/"
+)
+
+var errInvalidStorageValue = errors.New("invalid value in storage")
+
+// persistedTrace is a pending trace restored from the storage.
+type persistedTrace struct {
+	id              pcommon.TraceID
+	arrivalTime     time.Time
+	receivedBatches ptrace.Traces
+}
+
+// persistedDecision is a final sampling decision, restored in the decision caches on start.
+type persistedDecision struct {
+	id      pcommon.TraceID
+	sampled bool
+}
+
+// decisionSegment is a group of decisions persisted together, under a single key.
+type decisionSegment struct {
+	seq   uint64
+	count uint32
+}
+
+// persister spills the pending traces and the sampling decisions to a storage extension,
+// so they are not lost when the collector restarts.
+//
+// Changes are recorded as they happen and written in batches by flush, which is called after each
+// policy evaluation tick. Pending traces are stored under their own key, along with an index of their IDs
+// since the storage can't list its keys. Decisions are appended in segments, the oldest segments being
+// discarded once there are more decisions than the decision caches can hold.
+//
+// All the methods are no-ops on a nil persister, which is used when no storage is configured.
+type persister struct {
+	client storage.Client
+	logger *zap.Logger
+	// maxDecisions is the number of decisions to keep, 0 disables the persistence of decisions.
+	maxDecisions int
+
+	mu sync.Mutex
+	// dirty holds the traces whose spans or decision changed since the last flush.
+	dirty map[pcommon.TraceID]struct{}
+	// decisions holds the decisions made since the last flush.
+	decisions []persistedDecision
+
+	// flushMu serializes the flushes, it guards the fields below.
+	flushMu     sync.Mutex
+	persisted   map[pcommon.TraceID]struct{}
+	segments    []decisionSegment
+	nextSegment uint64
+}
+
+func newPersister(client storage.Client, logger *zap.Logger, maxDecisions int) *persister {
+	return &persister{
+		client:       client,
+		logger:       logger,
+		maxDecisions: maxDecisions,
+		dirty:        make(map[pcommon.TraceID]struct{}),
+		persisted:    make(map[pcommon.TraceID]struct{}),
+	}
+}
+
+// getStorageClient returns a client of the storage extension with the given ID.
+func getStorageClient(ctx context.Context, host component.Host, storageID component.ID, componentID component.ID) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+// markDirty records that the spans or the decision of the trace changed.
+func (p *persister) markDirty(id pcommon.TraceID) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.dirty[id] = struct{}{}
+	p.mu.Unlock()
+}
+
+// recordDecision records the final decision of the trace. The trace is marked dirty too,
+// so its spans are removed from the storage.
+func (p *persister) recordDecision(id pcommon.TraceID, sampled bool) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.dirty[id] = struct{}{}
+	if p.maxDecisions > 0 {
+		p.decisions = append(p.decisions, persistedDecision{id: id, sampled: sampled})
+	}
+	p.mu.Unlock()
+}
+
+// flush writes the changes recorded since the last flush. The traces still pending in idToTrace
+// are written, the others are deleted from the storage.
+func (p *persister) flush(ctx context.Context, idToTrace *sync.Map) error {
+	if p == nil {
+		return nil
+	}
+	p.flushMu.Lock()
+	defer p.flushMu.Unlock()
+
+	p.mu.Lock()
+	dirty := p.dirty
+	decisions := p.decisions
+	p.dirty = make(map[pcommon.TraceID]struct{})
+	p.decisions = nil
+	p.mu.Unlock()
+
+	ops := make([]*storage.Operation, 0, len(dirty)+3)
+	indexChanged := false
+	for id := range dirty {
+		if value, pending := p.pendingTraceValue(idToTrace, id); pending {
+			if value == nil {
+				continue
+			}
+			ops = append(ops, storage.SetOperation(traceKey(id), value))
+			if _, ok := p.persisted[id]; !ok {
+				p.persisted[id] = struct{}{}
+				indexChanged = true
+			}
+			continue
+		}
+		if _, ok := p.persisted[id]; ok {
+			delete(p.persisted, id)
+			ops = append(ops, storage.DeleteOperation(traceKey(id)))
+			indexChanged = true
+		}
+	}
+	if indexChanged {
+		ops = append(ops, storage.SetOperation(storageTraceIndexKey, encodeTraceIDs(p.persisted)))
+	}
+	if len(decisions) > 0 {
+		ops = append(ops, p.appendDecisionSegment(decisions)...)
+	}
+
+	if len(ops) == 0 {
+		return nil
+	}
+	return p.client.Batch(ctx, ops...)
+}
+
+// pendingTraceValue returns the encoded trace and true if the trace is still waiting for a decision.
+// The returned value is nil if the trace couldn't be encoded.
+func (p *persister) pendingTraceValue(idToTrace *sync.Map, id pcommon.TraceID) ([]byte, bool) {
+	d, ok := idToTrace.Load(id)
+	if !ok {
+		return nil, false
+	}
+	trace := d.(*samplingpolicy.TraceData)
+	trace.Lock()
+	defer trace.Unlock()
+	if trace.FinalDecision != samplingpolicy.Unspecified {
+		return nil, false
+	}
+	value, err := encodeTrace(trace)
+	if err != nil {
+		p.logger.Warn("Failed to persist a pending trace", zap.Stringer("id", id), zap.Error(err))
+		return nil, true
+	}
+	return value, true
+}
+
+// appendDecisionSegment returns the operations writing the decisions as a new segment,
+// and deleting the oldest segments exceeding maxDecisions.
+func (p *persister) appendDecisionSegment(decisions []persistedDecision) []*storage.Operation {
+	if len(decisions) > p.maxDecisions {
+		decisions = decisions[len(decisions)-p.maxDecisions:]
+	}
+	segment := decisionSegment{seq: p.nextSegment, count: uint32(len(decisions))}
+	p.nextSegment++
+	p.segments = append(p.segments, segment)
+	ops := []*storage.Operation{storage.SetOperation(decisionSegmentKey(segment.seq), encodeDecisions(decisions))}
+
+	total := 0
+	for _, s := range p.segments {
+		total += int(s.count)
+	}
+	for total > p.maxDecisions && len(p.segments) > 1 {
+		ops = append(ops, storage.DeleteOperation(decisionSegmentKey(p.segments[0].seq)))
+		total -= int(p.segments[0].count)
+		p.segments = p.segments[1:]
+	}
+	return append(ops, storage.SetOperation(storageDecisionsKey, encodeSegments(p.segments)))
+}
+
+// load reads the pending traces and the decisions persisted by a previous run.
+// Entries that can't be read are logged and skipped.
+func (p *persister) load(ctx context.Context) ([]persistedTrace, []persistedDecision, error) {
+	if p == nil {
+		return nil, nil, nil
+	}
+	p.flushMu.Lock()
+	defer p.flushMu.Unlock()
+
+	indexValue, err := p.client.Get(ctx, storageTraceIndexKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the persisted trace index: %w", err)
+	}
+	ids, err := decodeTraceIDs(indexValue)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode the persisted trace index: %w", err)
+	}
+
+	traces := make([]persistedTrace, 0, len(ids))
+	for _, id := range ids {
+		value, err := p.client.Get(ctx, traceKey(id))
+		if err == nil && value == nil {
+			continue
+		}
+		var trace persistedTrace
+		if err == nil {
+			trace, err = decodeTrace(id, value)
+		}
+		if err != nil {
+			p.logger.Warn("Failed to restore a persisted trace", zap.Stringer("id", id), zap.Error(err))
+			continue
+		}
+		p.persisted[id] = struct{}{}
+		traces = append(traces, trace)
+	}
+
+	segmentsValue, err := p.client.Get(ctx, storageDecisionsKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the persisted decision segments: %w", err)
+	}
+	segments, err := decodeSegments(segmentsValue)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode the persisted decision segments: %w", err)
+	}
+
+	var decisions []persistedDecision
+	for _, segment := range segments {
+		value, err := p.client.Get(ctx, decisionSegmentKey(segment.seq))
+		var segmentDecisions []persistedDecision
+		if err == nil {
+			segmentDecisions, err = decodeDecisions(value)
+		}
+		if err != nil {
+			p.logger.Warn("Failed to restore persisted decisions", zap.Uint64("segment", segment.seq), zap.Error(err))
+			continue
+		}
+		decisions = append(decisions, segmentDecisions...)
+		p.segments = append(p.segments, segment)
+		p.nextSegment = segment.seq + 1
+	}
+
+	return traces, decisions, nil
+}
+
+// close flushes the pending changes and closes the storage client.
+func (p *persister) close(ctx context.Context, idToTrace *sync.Map) error {
+	if p == nil {
+		return nil
+	}
+	return errors.Join(p.flush(ctx, idToTrace), p.client.Close(ctx))
+}
+
+func traceKey(id pcommon.TraceID) string {
+	return storageTraceKeyPrefix + id.String()
+}
+
+func decisionSegmentKey(seq uint64) string {
+	return fmt.Sprintf("%s%d", storageDecisionsKeyPrefix, seq)
+}
+
+// encodeTrace encodes the arrival time of the trace (Unix nanoseconds), followed by its spans in protobuf.
+func encodeTrace(trace *samplingpolicy.TraceData) ([]byte, error) {
+	marshaler := ptrace.ProtoMarshaler{}
+	spans, err := marshaler.MarshalTraces(trace.ReceivedBatches)
+	if err != nil {
+		return nil, err
+	}
+	value := binary.BigEndian.AppendUint64(make([]byte, 0, 8+len(spans)), uint64(trace.ArrivalTime.UnixNano()))
+	return append(value, spans...), nil
+}
+
+func decodeTrace(id pcommon.TraceID, value []byte) (persistedTrace, error) {
+	if len(value) < 8 {
+		return persistedTrace{}, errInvalidStorageValue
+	}
+	unmarshaler := ptrace.ProtoUnmarshaler{}
+	receivedBatches, err := unmarshaler.UnmarshalTraces(value[8:])
+	if err != nil {
+		return persistedTrace{}, err
+	}
+	return persistedTrace{
+		id:              id,
+		arrivalTime:     time.Unix(0, int64(binary.BigEndian.Uint64(value))),
+		receivedBatches: receivedBatches,
+	}, nil
+}
+
+func encodeTraceIDs(ids map[pcommon.TraceID]struct{}) []byte {
+	value := make([]byte, 0, len(ids)*16)
+	for id := range ids {
+		value = append(value, id[:]...)
+	}
+	return value
+}
+
+func decodeTraceIDs(value []byte) ([]pcommon.TraceID, error) {
+	if len(value)%16 != 0 {
+		return nil, errInvalidStorageValue
+	}
+	ids := make([]pcommon.TraceID, 0, len(value)/16)
+	for i := 0; i < len(value); i += 16 {
+		ids = append(ids, pcommon.TraceID(value[i:i+16]))
+	}
+	return ids, nil
+}
+
+// encodeDecisions encodes each decision as the trace ID followed by a byte set to 1 if the trace was sampled.
+func encodeDecisions(decisions []persistedDecision) []byte {
+	value := make([]byte, 0, len(decisions)*17)
+	for _, d := range decisions {
+		value = append(value, d.id[:]...)
+		if d.sampled {
+			value = append(value, 1)
+		} else {
+			value = append(value, 0)
+		}
+	}
+	return value
+}
+
+func decodeDecisions(value []byte) ([]persistedDecision, error) {
+	if len(value)%17 != 0 {
+		return nil, errInvalidStorageValue
+	}
+	decisions := make([]persistedDecision, 0, len(value)/17)
+	for i := 0; i < len(value); i += 17 {
+		decisions = append(decisions, persistedDecision{
+			id:      pcommon.TraceID(value[i : i+16]),
+			sampled: value[i+16] == 1,
+		})
+	}
+	return decisions, nil
+}
+
+func encodeSegments(segments []decisionSegment) []byte {
+	value := make([]byte, 0, len(segments)*12)
+	for _, s := range segments {
+		value = binary.BigEndian.AppendUint64(value, s.seq)
+		value = binary.BigEndian.AppendUint32(value, s.count)
+	}
+	return value
+}
+
+func decodeSegments(value []byte) ([]decisionSegment, error) {
+	if len(value)%12 != 0 {
+		return nil, errInvalidStorageValue
+	}
+	segments := make([]decisionSegment, 0, len(value)/12)
+	for i := 0; i < len(value); i += 12 {
+		segments = append(segments, decisionSegment{
+			seq:   binary.BigEndian.Uint64(value[i:]),
+			count: binary.BigEndian.Uint32(value[i+8:]),
+		})
+	}
+	return segments, nil
+}
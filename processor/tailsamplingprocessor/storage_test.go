@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/pkg/samplingpolicy"
+)
+
+var testStorageID = component.MustNewID("test_storage")
+
+func TestStorageRestoresPendingTraces(t *testing.T) {
+	host := newStorageHost()
+	traceID := uInt64ToTraceID(1)
+
+	// The first processor receives the trace and is shut down before making a decision.
+	first, firstEvaluator, firstSink := newStorageTestProcessor(t, DecisionCacheConfig{})
+	require.NoError(t, first.Start(t.Context(), host))
+	require.NoError(t, first.ConsumeTraces(t.Context(), simpleTracesWithID(traceID)))
+	first.policyTicker.OnTick()
+	require.NoError(t, first.Shutdown(t.Context()))
+	assert.Equal(t, 0, firstEvaluator.EvaluationCount)
+	assert.Equal(t, 0, firstSink.SpanCount())
+	assert.Contains(t, host.storage.keys(), traceKey(traceID))
+
+	// The second processor restores the trace and samples it.
+	second, secondEvaluator, secondSink := newStorageTestProcessor(t, DecisionCacheConfig{})
+	secondEvaluator.NextDecision = samplingpolicy.Sampled
+	require.NoError(t, second.Start(t.Context(), host))
+	defer func() {
+		require.NoError(t, second.Shutdown(t.Context()))
+	}()
+	assert.Equal(t, uint64(1), second.numTracesOnMap.Load())
+
+	second.policyTicker.OnTick()
+	second.policyTicker.OnTick()
+	assert.Equal(t, 1, secondEvaluator.EvaluationCount)
+	require.Len(t, secondSink.AllTraces(), 1)
+	assert.Equal(t, traceID, secondSink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+
+	// The trace was decided, it is not persisted anymore.
+	assert.NotContains(t, host.storage.keys(), traceKey(traceID))
+}
+
+func TestStorageRestoresDecisions(t *testing.T) {
+	host := newStorageHost()
+	decisionCache := DecisionCacheConfig{SampledCacheSize: 10, NonSampledCacheSize: 10}
+	sampledID := uInt64ToTraceID(1)
+	notSampledID := uInt64ToTraceID(2)
+
+	first, firstEvaluator, _ := newStorageTestProcessor(t, decisionCache)
+	require.NoError(t, first.Start(t.Context(), host))
+	firstEvaluator.NextDecision = samplingpolicy.Sampled
+	require.NoError(t, first.ConsumeTraces(t.Context(), simpleTracesWithID(sampledID)))
+	first.policyTicker.OnTick()
+	first.policyTicker.OnTick()
+	firstEvaluator.NextDecision = samplingpolicy.NotSampled
+	require.NoError(t, first.ConsumeTraces(t.Context(), simpleTracesWithID(notSampledID)))
+	first.policyTicker.OnTick()
+	first.policyTicker.OnTick()
+	require.NoError(t, first.Shutdown(t.Context()))
+	require.Equal(t, 2, firstEvaluator.EvaluationCount)
+
+	// Late spans received by the second processor use the restored decisions.
+	second, secondEvaluator, secondSink := newStorageTestProcessor(t, decisionCache)
+	require.NoError(t, second.Start(t.Context(), host))
+	defer func() {
+		require.NoError(t, second.Shutdown(t.Context()))
+	}()
+	require.NoError(t, second.ConsumeTraces(t.Context(), simpleTracesWithID(sampledID)))
+	require.NoError(t, second.ConsumeTraces(t.Context(), simpleTracesWithID(notSampledID)))
+
+	assert.Equal(t, 0, secondEvaluator.EvaluationCount)
+	assert.Equal(t, uint64(0), second.numTracesOnMap.Load())
+	require.Len(t, secondSink.AllTraces(), 1)
+	assert.Equal(t, sampledID, secondSink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+}
+
+func TestStorageDecisionsAreBounded(t *testing.T) {
+	client := newMemoryStorage()
+	p := newPersister(client, zap.NewNop(), 3)
+	idToTrace := &sync.Map{}
+
+	for i := range 5 {
+		p.recordDecision(uInt64ToTraceID(uint64(i)), true)
+		require.NoError(t, p.flush(t.Context(), idToTrace))
+	}
+
+	restored := newPersister(client, zap.NewNop(), 3)
+	traces, decisions, err := restored.load(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, traces)
+	assert.Equal(t, []persistedDecision{
+		{id: uInt64ToTraceID(2), sampled: true},
+		{id: uInt64ToTraceID(3), sampled: true},
+		{id: uInt64ToTraceID(4), sampled: true},
+	}, decisions)
+	assert.Len(t, client.keys(), 4) // 3 segments and the list of segments
+}
+
+func TestStorageEncodeTrace(t *testing.T) {
+	spanCount := &atomic.Int64{}
+	spanCount.Store(1)
+	trace := &samplingpolicy.TraceData{
+		ArrivalTime:     time.Unix(0, 1234567890),
+		SpanCount:       spanCount,
+		ReceivedBatches: simpleTracesWithID(uInt64ToTraceID(1)),
+	}
+
+	value, err := encodeTrace(trace)
+	require.NoError(t, err)
+	decoded, err := decodeTrace(uInt64ToTraceID(1), value)
+	require.NoError(t, err)
+	assert.Equal(t, uInt64ToTraceID(1), decoded.id)
+	assert.True(t, trace.ArrivalTime.Equal(decoded.arrivalTime))
+	assert.Equal(t, trace.ReceivedBatches, decoded.receivedBatches)
+
+	_, err = decodeTrace(uInt64ToTraceID(1), []byte{1, 2})
+	assert.ErrorIs(t, err, errInvalidStorageValue)
+}
+
+func TestStorageExtensionNotFound(t *testing.T) {
+	p, _, _ := newStorageTestProcessor(t, DecisionCacheConfig{})
+	err := p.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, `storage extension "test_storage" not found`)
+	require.NoError(t, p.Shutdown(t.Context()))
+}
+
+func TestStorageRestoreFailure(t *testing.T) {
+	host := newStorageHost()
+	invalidIndex := []byte{1, 2}
+	require.NoError(t, host.storage.Set(t.Context(), storageTraceIndexKey, invalidIndex))
+
+	p, _, _ := newStorageTestProcessor(t, DecisionCacheConfig{})
+	err := p.Start(t.Context(), host)
+	assert.ErrorContains(t, err, "failed to decode the persisted trace index")
+	require.NoError(t, p.Shutdown(t.Context()))
+
+	// The client is closed once, by the failed Start, and nothing is written to it afterwards.
+	assert.Equal(t, 1, host.storage.closeCount())
+	value, err := host.storage.Get(t.Context(), storageTraceIndexKey)
+	require.NoError(t, err)
+	assert.Equal(t, invalidIndex, value)
+}
+
+func newStorageTestProcessor(t *testing.T, decisionCache DecisionCacheConfig) (*tailSamplingSpanProcessor, *mockPolicyEvaluator, *consumertest.TracesSink) {
+	sink := new(consumertest.TracesSink)
+	evaluator := &mockPolicyEvaluator{}
+	cfg := Config{
+		DecisionWait:  defaultTestDecisionWait,
+		NumTraces:     defaultNumTraces,
+		DecisionCache: decisionCache,
+		Storage:       &testStorageID,
+		Options: []Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+			withPolicies([]*policy{
+				{name: "mock-policy", evaluator: evaluator, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy"))},
+			}),
+		},
+	}
+	p, err := newTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), sink, cfg)
+	require.NoError(t, err)
+	return p.(*tailSamplingSpanProcessor), evaluator, sink
+}
+
+// storageHost is a host holding a single in-memory storage extension.
+type storageHost struct {
+	component.Host
+	storage *memoryStorage
+}
+
+func newStorageHost() *storageHost {
+	return &storageHost{
+		Host:    componenttest.NewNopHost(),
+		storage: newMemoryStorage(),
+	}
+}
+
+func (h *storageHost) GetExtensions() map[component.ID]component.Component {
+	return map[component.ID]component.Component{testStorageID: h.storage}
+}
+
+// memoryStorage is an in-memory storage extension whose data outlives its clients,
+// so it can be shared by successive processors.
+type memoryStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mu     sync.Mutex
+	data   map[string][]byte
+	closes int
+}
+
+var (
+	_ storage.Extension = (*memoryStorage)(nil)
+	_ storage.Client    = (*memoryStorage)(nil)
+)
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{data: make(map[string][]byte)}
+}
+
+func (s *memoryStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return s, nil
+}
+
+func (s *memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data[key], nil
+}
+
+func (s *memoryStorage) Set(_ context.Context, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value
+	return nil
+}
+
+func (s *memoryStorage) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, key)
+	return nil
+}
+
+func (s *memoryStorage) Batch(_ context.Context, ops ...*storage.Operation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = s.data[op.Key]
+		case storage.Set:
+			s.data[op.Key] = op.Value
+		case storage.Delete:
+			delete(s.data, op.Key)
+		}
+	}
+	return nil
+}
+
+func (s *memoryStorage) Close(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closes++
+	return nil
+}
+
+func (s *memoryStorage) closeCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closes
+}
+
+func (s *memoryStorage) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.data))
+	for key := range s.data {
+		keys = append(keys, key)
+	}
+	return keys
+}
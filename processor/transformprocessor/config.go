@@ -22,6 +22,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
 )
 
 var (
@@ -42,6 +43,22 @@ type Config struct {
 	// The default value is `propagate`.
 	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
 
+	// Macros are user-defined statements and conditions templates that can be invoked by the
+	// statements and conditions of all the signals.
+	Macros []ottl.Macro `mapstructure:"macros"`
+
+	// State enables a keyed state store, shared by the statements of a signal, which is
+	// accessed with the GetState, SetState, Previous and Delta converters.
+	State *state.Config `mapstructure:"state"`
+
+	// StatementTelemetry enables recording, for each statement, the number of executions, condition matches
+	// and errors, and the cumulative execution duration as internal metrics of the collector.
+	StatementTelemetry bool `mapstructure:"statement_telemetry"`
+
+	// StatementTrace enables the trace mode, which logs, for sampled records, the statements that matched
+	// and the changes each statement made to the record.
+	StatementTrace *StatementTraceConfig `mapstructure:"statement_trace"`
+
 	TraceStatements   []common.ContextStatements `mapstructure:"trace_statements"`
 	MetricStatements  []common.ContextStatements `mapstructure:"metric_statements"`
 	LogStatements     []common.ContextStatements `mapstructure:"log_statements"`
@@ -58,6 +75,21 @@ type Config struct {
 	profileFunctions   map[string]ottl.Factory[ottlprofile.TransformContext]
 }
 
+// StatementTraceConfig configures the trace mode of the statements.
+type StatementTraceConfig struct {
+	// SampleEvery is the sampling interval of the traced records: one out of every SampleEvery records
+	// processed by a group of statements is traced. The default value is 1, tracing all the records.
+	SampleEvery int `mapstructure:"sample_every"`
+}
+
+// Validate checks that the trace mode configuration is valid.
+func (c *StatementTraceConfig) Validate() error {
+	if c.SampleEvery < 0 {
+		return errors.New("'sample_every' must not be negative")
+	}
+	return nil
+}
+
 // Unmarshal is used internally by mapstructure to parse the transformprocessor configuration (Config),
 // adding support to structured and flat configuration styles.
 // When the flat configuration style is used, all statements are grouped into a common.ContextStatements
@@ -141,11 +173,25 @@ func (c *Config) Unmarshal(conf *confmap.Conf) error {
 
 var _ component.Config = (*Config)(nil)
 
+// contextStatements returns the groups of statements of a signal, with the per-statement telemetry
+// and the trace mode enabled when configured.
+func (c *Config) contextStatements(contextStatements []common.ContextStatements, componentID component.ID, signal string) []common.ContextStatements {
+	if c.StatementTelemetry {
+		contextStatements = common.WithStatementTelemetry(contextStatements, componentID, signal)
+	}
+	if c.StatementTrace != nil {
+		contextStatements = common.WithStatementTrace(contextStatements, max(c.StatementTrace.SampleEvery, 1))
+	}
+	return contextStatements
+}
+
 func (c *Config) Validate() error {
 	var errors error
+	// The store is only used to register the state converters, so statements using them can be validated.
+	store := state.NewStore(c.State, component.ID{}, "", zap.NewNop())
 
 	if len(c.TraceStatements) > 0 {
-		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(c.spanFunctions), common.WithSpanEventParser(c.spanEventFunctions))
+		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(state.WithFunctions(c.spanFunctions, store)), common.WithSpanEventParser(state.WithFunctions(c.spanEventFunctions, store)), common.WithTraceMacros(c.Macros))
 		if err != nil {
 			return err
 		}
@@ -158,7 +204,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.MetricStatements) > 0 {
-		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithMetricParser(c.metricFunctions), common.WithDataPointParser(c.dataPointFunctions))
+		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithMetricParser(state.WithFunctions(c.metricFunctions, store)), common.WithDataPointParser(state.WithFunctions(c.dataPointFunctions, store)), common.WithMetricMacros(c.Macros))
 		if err != nil {
 			return err
 		}
@@ -171,7 +217,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.LogStatements) > 0 {
-		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithLogParser(c.logFunctions))
+		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithLogParser(state.WithFunctions(c.logFunctions, store)), common.WithLogMacros(c.Macros))
 		if err != nil {
 			return err
 		}
@@ -184,7 +230,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.ProfileStatements) > 0 {
-		pc, err := common.NewProfileParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithProfileParser(c.profileFunctions))
+		pc, err := common.NewProfileParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithProfileParser(state.WithFunctions(c.profileFunctions, store)), common.WithProfileMacros(c.Macros))
 		if err != nil {
 			return err
 		}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
@@ -16,11 +17,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
 )
 
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	fileStorageID := component.MustNewID("file_storage")
+
 	tests := []struct {
 		id       component.ID
 		expected component.Config
@@ -334,6 +338,121 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "macros"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Macros: []ottl.Macro{
+					{
+						Name:       "set_team",
+						Parameters: []ottl.MacroParameter{{Name: "team", Type: ottl.MacroParameterString}},
+						Statements: []string{
+							`set(resource.attributes["team"], team)`,
+							`set(resource.attributes["team.managed"], true)`,
+						},
+					},
+					{
+						Name:       "IsAnimal",
+						Parameters: []ottl.MacroParameter{{Name: "path"}},
+						Condition:  `path == "/animal" or path == "/animals"`,
+					},
+				},
+				TraceStatements: []common.ContextStatements{
+					{
+						Statements: []string{`set_team("bears") where IsAnimal(span.attributes["http.path"])`},
+					},
+				},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Context:    "log",
+						Conditions: []string{`IsAnimal(attributes["http.path"])`},
+						Statements: []string{`set_team("bears")`},
+					},
+				},
+				ProfileStatements: []common.ContextStatements{},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_macro_invocation"),
+			errors: []error{
+				errors.New(`macro "set_team": invalid argument for parameter "team": expected a string but got "span.name"`),
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "state"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				State: &state.Config{
+					MaxEntries: 1000,
+					TTL:        10 * time.Minute,
+					Storage:    &fileStorageID,
+				},
+				TraceStatements: []common.ContextStatements{},
+				MetricStatements: []common.ContextStatements{
+					{
+						Context:    "datapoint",
+						Statements: []string{`set(attributes["counter.reset"], true) where Delta(metric.name, value_int) < 0`},
+					},
+				},
+				LogStatements: []common.ContextStatements{
+					{
+						Context:    "log",
+						Statements: []string{`set(attributes["previous.sequence"], Previous(resource.attributes["service.name"], attributes["sequence"]))`},
+					},
+				},
+				ProfileStatements: []common.ContextStatements{},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "statement_telemetry"),
+			expected: &Config{
+				ErrorMode:          ottl.PropagateError,
+				StatementTelemetry: true,
+				TraceStatements:    []common.ContextStatements{},
+				MetricStatements:   []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Statements: []string{`set(attributes["name"], "bear")`},
+					},
+				},
+				ProfileStatements: []common.ContextStatements{},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "statement_trace"),
+			expected: &Config{
+				ErrorMode:        ottl.PropagateError,
+				StatementTrace:   &StatementTraceConfig{SampleEvery: 100},
+				TraceStatements:  []common.ContextStatements{},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Statements: []string{`set(attributes["name"], "bear")`},
+					},
+				},
+				ProfileStatements: []common.ContextStatements{},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_statement_trace"),
+			errors: []error{
+				errors.New("'sample_every' must not be negative"),
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "state_disabled"),
+			errors: []error{
+				errors.New(`undefined function "Previous"`),
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_state"),
+			errors: []error{
+				errors.New("'max_entries' must be positive"),
+				errors.New("'ttl' must be positive"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.Name(), func(t *testing.T) {
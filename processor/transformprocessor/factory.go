@@ -28,6 +28,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/profiles"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/traces"
 )
 
@@ -177,7 +178,8 @@ func (f *transformProcessorFactory) createLogsProcessor(
 	if f.defaultLogFunctionsOverridden {
 		set.Logger.Debug("non-default OTTL log functions have been registered in the \"transform\" processor", zap.Bool("log", f.defaultLogFunctionsOverridden))
 	}
-	proc, err := logs.NewProcessor(oCfg.LogStatements, oCfg.ErrorMode, oCfg.FlattenData, set.TelemetrySettings, f.logFunctions)
+	store := state.NewStore(oCfg.State, set.ID, "logs", set.Logger)
+	proc, err := logs.NewProcessor(oCfg.contextStatements(oCfg.LogStatements, set.ID, "logs"), oCfg.ErrorMode, oCfg.FlattenData, set.TelemetrySettings, state.WithFunctions(f.logFunctions, store), oCfg.Macros)
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
@@ -187,7 +189,9 @@ func (f *transformProcessorFactory) createLogsProcessor(
 		cfg,
 		nextConsumer,
 		proc.ProcessLogs,
-		processorhelper.WithCapabilities(processorCapabilities))
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(store.Start),
+		processorhelper.WithShutdown(store.Shutdown))
 }
 
 func (f *transformProcessorFactory) createTracesProcessor(
@@ -203,7 +207,8 @@ func (f *transformProcessorFactory) createTracesProcessor(
 			zap.Bool("spanevent", f.defaultSpanEventFunctionsOverridden),
 		)
 	}
-	proc, err := traces.NewProcessor(oCfg.TraceStatements, oCfg.ErrorMode, set.TelemetrySettings, f.spanFunctions, f.spanEventFunctions)
+	store := state.NewStore(oCfg.State, set.ID, "traces", set.Logger)
+	proc, err := traces.NewProcessor(oCfg.contextStatements(oCfg.TraceStatements, set.ID, "traces"), oCfg.ErrorMode, set.TelemetrySettings, state.WithFunctions(f.spanFunctions, store), state.WithFunctions(f.spanEventFunctions, store), oCfg.Macros)
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
@@ -213,7 +218,9 @@ func (f *transformProcessorFactory) createTracesProcessor(
 		cfg,
 		nextConsumer,
 		proc.ProcessTraces,
-		processorhelper.WithCapabilities(processorCapabilities))
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(store.Start),
+		processorhelper.WithShutdown(store.Shutdown))
 }
 
 func (f *transformProcessorFactory) createMetricsProcessor(
@@ -230,7 +237,8 @@ func (f *transformProcessorFactory) createMetricsProcessor(
 			zap.Bool("metric", f.defaultMetricFunctionsOverridden),
 		)
 	}
-	proc, err := metrics.NewProcessor(oCfg.MetricStatements, oCfg.ErrorMode, set.TelemetrySettings, f.metricFunctions, f.dataPointFunctions)
+	store := state.NewStore(oCfg.State, set.ID, "metrics", set.Logger)
+	proc, err := metrics.NewProcessor(oCfg.contextStatements(oCfg.MetricStatements, set.ID, "metrics"), oCfg.ErrorMode, set.TelemetrySettings, state.WithFunctions(f.metricFunctions, store), state.WithFunctions(f.dataPointFunctions, store), oCfg.Macros)
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
@@ -240,7 +248,9 @@ func (f *transformProcessorFactory) createMetricsProcessor(
 		cfg,
 		nextConsumer,
 		proc.ProcessMetrics,
-		processorhelper.WithCapabilities(processorCapabilities))
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(store.Start),
+		processorhelper.WithShutdown(store.Shutdown))
 }
 
 func (f *transformProcessorFactory) createProfilesProcessor(
@@ -255,7 +265,8 @@ func (f *transformProcessorFactory) createProfilesProcessor(
 	if f.defaultProfileFunctionsOverridden {
 		set.Logger.Debug("non-default OTTL profile functions have been registered in the \"transform\" processor", zap.Bool("profile", f.defaultProfileFunctionsOverridden))
 	}
-	proc, err := profiles.NewProcessor(oCfg.ProfileStatements, oCfg.ErrorMode, set.TelemetrySettings, f.profileFunctions)
+	store := state.NewStore(oCfg.State, set.ID, "profiles", set.Logger)
+	proc, err := profiles.NewProcessor(oCfg.contextStatements(oCfg.ProfileStatements, set.ID, "profiles"), oCfg.ErrorMode, set.TelemetrySettings, state.WithFunctions(f.profileFunctions, store), oCfg.Macros)
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
@@ -265,5 +276,7 @@ func (f *transformProcessorFactory) createProfilesProcessor(
 		cfg,
 		nextConsumer,
 		proc.ProcessProfiles,
-		xprocessorhelper.WithCapabilities(processorCapabilities))
+		xprocessorhelper.WithCapabilities(processorCapabilities),
+		xprocessorhelper.WithStart(store.Start),
+		xprocessorhelper.WithShutdown(store.Shutdown))
 }
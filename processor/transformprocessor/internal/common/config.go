@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"strings"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -43,6 +46,29 @@ type ContextStatements struct {
 	// ErrorMode determines how the processor reacts to errors that occur while processing
 	// this group of statements. When provided, it overrides the default Config ErrorMode.
 	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
+
+	// telemetryAttributes, when set, enables the per-statement and per-condition telemetry of
+	// this group of statements, labelled with these attributes.
+	telemetryAttributes []attribute.KeyValue
+	// traceSampleEvery, when positive, enables the trace mode of this group of statements,
+	// tracing one out of every traceSampleEvery executions.
+	traceSampleEvery int
+}
+
+// WithStatementTelemetry returns a copy of the groups of statements of a signal with the per-statement
+// and per-condition telemetry enabled. The statements and conditions are labelled with the component ID,
+// the signal, the index of their group and their index within the group.
+func WithStatementTelemetry(contextStatements []ContextStatements, componentID component.ID, signal string) []ContextStatements {
+	result := make([]ContextStatements, len(contextStatements))
+	for i, cs := range contextStatements {
+		cs.telemetryAttributes = []attribute.KeyValue{
+			attribute.String("otelcol.component.id", componentID.String()),
+			attribute.String("otelcol.signal", signal),
+			attribute.Int("ottl.group.index", i),
+		}
+		result[i] = cs
+	}
+	return result
 }
 
 func (c ContextStatements) GetStatements() []string {
@@ -56,3 +82,14 @@ func toContextStatements(statements any) (*ContextStatements, error) {
 	}
 	return &contextStatements, nil
 }
+
+// WithStatementTrace returns a copy of the groups of statements of a signal with the trace mode enabled,
+// tracing one out of every sampleEvery executions of each group.
+func WithStatementTrace(contextStatements []ContextStatements, sampleEvery int) []ContextStatements {
+	result := make([]ContextStatements, len(contextStatements))
+	for i, cs := range contextStatements {
+		cs.traceSampleEvery = sampleEvery
+		result[i] = cs
+	}
+	return result
+}
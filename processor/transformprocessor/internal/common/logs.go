@@ -71,6 +71,10 @@ func WithLogErrorMode(errorMode ottl.ErrorMode) LogParserCollectionOption {
 	return LogParserCollectionOption(ottl.WithParserCollectionErrorMode[LogsConsumer](errorMode))
 }
 
+func WithLogMacros(macros []ottl.Macro) LogParserCollectionOption {
+	return LogParserCollectionOption(ottl.WithParserCollectionMacros[LogsConsumer](macros))
+}
+
 func NewLogParserCollection(settings component.TelemetrySettings, options ...LogParserCollectionOption) (*LogParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[LogsConsumer]{
 		withCommonContextParsers[LogsConsumer](),
@@ -103,16 +107,28 @@ func convertLogStatements(pc *ottl.ParserCollection[LogsConsumer], statements ot
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottllog.EnablePathContextNames())
 	}
-	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForLogWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardLogFuncs(), parserOptions)
+	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForLogWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardLogFuncs(), parserOptions, contextStatements.telemetryAttributes)
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	lStatements := ottllog.NewStatementSequence(parsedStatements, pc.Settings, ottllog.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottllog.StatementSequenceOption{ottllog.WithStatementSequenceErrorMode(errorMode)}
+	if contextStatements.telemetryAttributes != nil {
+		sequenceOptions = append(sequenceOptions, ottllog.WithStatementSequenceTelemetry(contextStatements.telemetryAttributes...))
+	}
+	if contextStatements.traceSampleEvery > 0 {
+		sequenceOptions = append(sequenceOptions, ottllog.WithStatementSequenceTracing(contextStatements.traceSampleEvery, nil))
+	}
+	lStatements := ottllog.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return logStatements{lStatements, globalExpr}, nil
 }
 
 func (lpc *LogParserCollection) ParseContextStatements(contextStatements ContextStatements) (LogsConsumer, error) {
 	pc := ottl.ParserCollection[LogsConsumer](*lpc)
+	conditions, err := pc.ExpandConditionsMacros(contextStatements.Conditions)
+	if err != nil {
+		return nil, err
+	}
+	contextStatements.Conditions = conditions
 	if contextStatements.Context != "" {
 		return pc.ParseStatementsWithContext(string(contextStatements.Context), contextStatements, true)
 	}
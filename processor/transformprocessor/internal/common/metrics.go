@@ -191,6 +191,10 @@ func WithMetricErrorMode(errorMode ottl.ErrorMode) MetricParserCollectionOption
 	return MetricParserCollectionOption(ottl.WithParserCollectionErrorMode[MetricsConsumer](errorMode))
 }
 
+func WithMetricMacros(macros []ottl.Macro) MetricParserCollectionOption {
+	return MetricParserCollectionOption(ottl.WithParserCollectionMacros[MetricsConsumer](macros))
+}
+
 func NewMetricParserCollection(settings component.TelemetrySettings, options ...MetricParserCollectionOption) (*MetricParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[MetricsConsumer]{
 		withCommonContextParsers[MetricsConsumer](),
@@ -223,11 +227,18 @@ func convertMetricStatements(pc *ottl.ParserCollection[MetricsConsumer], stateme
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottlmetric.EnablePathContextNames())
 	}
-	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForMetricWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardMetricFuncs(), parserOptions)
+	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForMetricWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardMetricFuncs(), parserOptions, contextStatements.telemetryAttributes)
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	mStatements := ottlmetric.NewStatementSequence(parsedStatements, pc.Settings, ottlmetric.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlmetric.StatementSequenceOption{ottlmetric.WithStatementSequenceErrorMode(errorMode)}
+	if contextStatements.telemetryAttributes != nil {
+		sequenceOptions = append(sequenceOptions, ottlmetric.WithStatementSequenceTelemetry(contextStatements.telemetryAttributes...))
+	}
+	if contextStatements.traceSampleEvery > 0 {
+		sequenceOptions = append(sequenceOptions, ottlmetric.WithStatementSequenceTracing(contextStatements.traceSampleEvery, nil))
+	}
+	mStatements := ottlmetric.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return metricStatements{mStatements, globalExpr}, nil
 }
 
@@ -244,16 +255,28 @@ func convertDataPointStatements(pc *ottl.ParserCollection[MetricsConsumer], stat
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottldatapoint.EnablePathContextNames())
 	}
-	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForDataPointWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardDataPointFuncs(), parserOptions)
+	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForDataPointWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardDataPointFuncs(), parserOptions, contextStatements.telemetryAttributes)
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	dpStatements := ottldatapoint.NewStatementSequence(parsedStatements, pc.Settings, ottldatapoint.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottldatapoint.StatementSequenceOption{ottldatapoint.WithStatementSequenceErrorMode(errorMode)}
+	if contextStatements.telemetryAttributes != nil {
+		sequenceOptions = append(sequenceOptions, ottldatapoint.WithStatementSequenceTelemetry(contextStatements.telemetryAttributes...))
+	}
+	if contextStatements.traceSampleEvery > 0 {
+		sequenceOptions = append(sequenceOptions, ottldatapoint.WithStatementSequenceTracing(contextStatements.traceSampleEvery, nil))
+	}
+	dpStatements := ottldatapoint.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return dataPointStatements{dpStatements, globalExpr}, nil
 }
 
 func (mpc *MetricParserCollection) ParseContextStatements(contextStatements ContextStatements) (MetricsConsumer, error) {
 	pc := ottl.ParserCollection[MetricsConsumer](*mpc)
+	conditions, err := pc.ExpandConditionsMacros(contextStatements.Conditions)
+	if err != nil {
+		return nil, err
+	}
+	contextStatements.Conditions = conditions
 	if contextStatements.Context != "" {
 		return pc.ParseStatementsWithContext(string(contextStatements.Context), contextStatements, true)
 	}
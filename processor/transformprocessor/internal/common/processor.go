@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
@@ -234,11 +235,18 @@ func parseResourceContextStatements[R any](
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottlresource.EnablePathContextNames())
 	}
-	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForResourceWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardResourceFuncs(), parserOptions)
+	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForResourceWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardResourceFuncs(), parserOptions, contextStatements.telemetryAttributes)
 	if errGlobalBoolExpr != nil {
 		return *new(R), errGlobalBoolExpr
 	}
-	rStatements := ottlresource.NewStatementSequence(parsedStatements, pc.Settings, ottlresource.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlresource.StatementSequenceOption{ottlresource.WithStatementSequenceErrorMode(errorMode)}
+	if contextStatements.telemetryAttributes != nil {
+		sequenceOptions = append(sequenceOptions, ottlresource.WithStatementSequenceTelemetry(contextStatements.telemetryAttributes...))
+	}
+	if contextStatements.traceSampleEvery > 0 {
+		sequenceOptions = append(sequenceOptions, ottlresource.WithStatementSequenceTracing(contextStatements.traceSampleEvery, nil))
+	}
+	rStatements := ottlresource.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	result := baseContext(resourceStatements{rStatements, globalExpr})
 	return result.(R), nil
 }
@@ -260,11 +268,18 @@ func parseScopeContextStatements[R any](
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottlscope.EnablePathContextNames())
 	}
-	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForScopeWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardScopeFuncs(), parserOptions)
+	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForScopeWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardScopeFuncs(), parserOptions, contextStatements.telemetryAttributes)
 	if errGlobalBoolExpr != nil {
 		return *new(R), errGlobalBoolExpr
 	}
-	sStatements := ottlscope.NewStatementSequence(parsedStatements, pc.Settings, ottlscope.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlscope.StatementSequenceOption{ottlscope.WithStatementSequenceErrorMode(errorMode)}
+	if contextStatements.telemetryAttributes != nil {
+		sequenceOptions = append(sequenceOptions, ottlscope.WithStatementSequenceTelemetry(contextStatements.telemetryAttributes...))
+	}
+	if contextStatements.traceSampleEvery > 0 {
+		sequenceOptions = append(sequenceOptions, ottlscope.WithStatementSequenceTracing(contextStatements.traceSampleEvery, nil))
+	}
+	sStatements := ottlscope.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	result := baseContext(scopeStatements{sStatements, globalExpr})
 	return result.(R), nil
 }
@@ -276,9 +291,17 @@ func parseGlobalExpr[K, O any](
 	settings component.TelemetrySettings,
 	standardFuncs map[string]ottl.Factory[K],
 	parserOptions []O,
+	telemetryAttributes []attribute.KeyValue,
 ) (expr.BoolExpr[K], error) {
 	if len(conditions) > 0 {
-		return boolExprFunc(conditions, standardFuncs, errorMode, settings, parserOptions)
+		conditionSequence, err := boolExprFunc(conditions, standardFuncs, errorMode, settings, parserOptions)
+		if err != nil {
+			return nil, err
+		}
+		if telemetryAttributes != nil {
+			ottl.WithConditionSequenceTelemetry[K](telemetryAttributes...)(conditionSequence)
+		}
+		return conditionSequence, nil
 	}
 	// By default, set the global expression to always true unless conditions are specified.
 	return expr.AlwaysTrue[K](), nil
@@ -69,6 +69,10 @@ func WithProfileErrorMode(errorMode ottl.ErrorMode) ProfileParserCollectionOptio
 	return ProfileParserCollectionOption(ottl.WithParserCollectionErrorMode[ProfilesConsumer](errorMode))
 }
 
+func WithProfileMacros(macros []ottl.Macro) ProfileParserCollectionOption {
+	return ProfileParserCollectionOption(ottl.WithParserCollectionMacros[ProfilesConsumer](macros))
+}
+
 func NewProfileParserCollection(settings component.TelemetrySettings, options ...ProfileParserCollectionOption) (*ProfileParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[ProfilesConsumer]{
 		withCommonContextParsers[ProfilesConsumer](),
@@ -101,16 +105,28 @@ func convertProfileStatements(pc *ottl.ParserCollection[ProfilesConsumer], state
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottlprofile.EnablePathContextNames())
 	}
-	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForProfileWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardProfileFuncs(), parserOptions)
+	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForProfileWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardProfileFuncs(), parserOptions, contextStatements.telemetryAttributes)
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	lStatements := ottlprofile.NewStatementSequence(parsedStatements, pc.Settings, ottlprofile.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlprofile.StatementSequenceOption{ottlprofile.WithStatementSequenceErrorMode(errorMode)}
+	if contextStatements.telemetryAttributes != nil {
+		sequenceOptions = append(sequenceOptions, ottlprofile.WithStatementSequenceTelemetry(contextStatements.telemetryAttributes...))
+	}
+	if contextStatements.traceSampleEvery > 0 {
+		sequenceOptions = append(sequenceOptions, ottlprofile.WithStatementSequenceTracing(contextStatements.traceSampleEvery, nil))
+	}
+	lStatements := ottlprofile.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return profileStatements{lStatements, globalExpr}, nil
 }
 
 func (ppc *ProfileParserCollection) ParseContextStatements(contextStatements ContextStatements) (ProfilesConsumer, error) {
 	pc := ottl.ParserCollection[ProfilesConsumer](*ppc)
+	conditions, err := pc.ExpandConditionsMacros(contextStatements.Conditions)
+	if err != nil {
+		return nil, err
+	}
+	contextStatements.Conditions = conditions
 	if contextStatements.Context != "" {
 		return pc.ParseStatementsWithContext(string(contextStatements.Context), contextStatements, true)
 	}
@@ -119,6 +119,10 @@ func WithTraceErrorMode(errorMode ottl.ErrorMode) TraceParserCollectionOption {
 	return TraceParserCollectionOption(ottl.WithParserCollectionErrorMode[TracesConsumer](errorMode))
 }
 
+func WithTraceMacros(macros []ottl.Macro) TraceParserCollectionOption {
+	return TraceParserCollectionOption(ottl.WithParserCollectionMacros[TracesConsumer](macros))
+}
+
 func NewTraceParserCollection(settings component.TelemetrySettings, options ...TraceParserCollectionOption) (*TraceParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[TracesConsumer]{
 		withCommonContextParsers[TracesConsumer](),
@@ -151,11 +155,18 @@ func convertSpanStatements(pc *ottl.ParserCollection[TracesConsumer], statements
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottlspan.EnablePathContextNames())
 	}
-	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForSpanWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardSpanFuncs(), parserOptions)
+	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForSpanWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardSpanFuncs(), parserOptions, contextStatements.telemetryAttributes)
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	sStatements := ottlspan.NewStatementSequence(parsedStatements, pc.Settings, ottlspan.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlspan.StatementSequenceOption{ottlspan.WithStatementSequenceErrorMode(errorMode)}
+	if contextStatements.telemetryAttributes != nil {
+		sequenceOptions = append(sequenceOptions, ottlspan.WithStatementSequenceTelemetry(contextStatements.telemetryAttributes...))
+	}
+	if contextStatements.traceSampleEvery > 0 {
+		sequenceOptions = append(sequenceOptions, ottlspan.WithStatementSequenceTracing(contextStatements.traceSampleEvery, nil))
+	}
+	sStatements := ottlspan.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return traceStatements{sStatements, globalExpr}, nil
 }
 
@@ -172,16 +183,28 @@ func convertSpanEventStatements(pc *ottl.ParserCollection[TracesConsumer], state
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottlspanevent.EnablePathContextNames())
 	}
-	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForSpanEventWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardSpanEventFuncs(), parserOptions)
+	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForSpanEventWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardSpanEventFuncs(), parserOptions, contextStatements.telemetryAttributes)
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	seStatements := ottlspanevent.NewStatementSequence(parsedStatements, pc.Settings, ottlspanevent.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlspanevent.StatementSequenceOption{ottlspanevent.WithStatementSequenceErrorMode(errorMode)}
+	if contextStatements.telemetryAttributes != nil {
+		sequenceOptions = append(sequenceOptions, ottlspanevent.WithStatementSequenceTelemetry(contextStatements.telemetryAttributes...))
+	}
+	if contextStatements.traceSampleEvery > 0 {
+		sequenceOptions = append(sequenceOptions, ottlspanevent.WithStatementSequenceTracing(contextStatements.traceSampleEvery, nil))
+	}
+	seStatements := ottlspanevent.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return spanEventStatements{seStatements, globalExpr}, nil
 }
 
 func (tpc *TraceParserCollection) ParseContextStatements(contextStatements ContextStatements) (TracesConsumer, error) {
 	pc := ottl.ParserCollection[TracesConsumer](*tpc)
+	conditions, err := pc.ExpandConditionsMacros(contextStatements.Conditions)
+	if err != nil {
+		return nil, err
+	}
+	contextStatements.Conditions = conditions
 	if contextStatements.Context != "" {
 		return pc.ParseStatementsWithContext(string(contextStatements.Context), contextStatements, true)
 	}
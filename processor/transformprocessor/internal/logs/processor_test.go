@@ -11,9 +11,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
@@ -60,7 +66,7 @@ func Test_ProcessLogs_ResourceContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, nil)
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(t.Context(), td)
@@ -101,7 +107,7 @@ func Test_ProcessLogs_InferredResourceContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, nil)
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(t.Context(), td)
@@ -142,7 +148,7 @@ func Test_ProcessLogs_ScopeContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "scope", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "scope", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, nil)
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(t.Context(), td)
@@ -183,7 +189,7 @@ func Test_ProcessLogs_InferredScopeContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, nil)
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(t.Context(), td)
@@ -437,7 +443,7 @@ func Test_ProcessLogs_LogContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "log", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "log", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, nil)
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(t.Context(), td)
@@ -691,7 +697,7 @@ func Test_ProcessLogs_InferredLogContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, nil)
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(t.Context(), td)
@@ -808,7 +814,7 @@ func Test_ProcessLogs_MixContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions)
+			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, nil)
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(t.Context(), td)
@@ -898,7 +904,7 @@ func Test_ProcessLogs_InferredMixContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions)
+			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, nil)
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(t.Context(), td)
@@ -931,7 +937,7 @@ func Test_ProcessLogs_ErrorMode(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(string(tt.context), func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{`set(attributes["test"], ParseJSON(1))`}}}, ottl.PropagateError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{`set(attributes["test"], ParseJSON(1))`}}}, ottl.PropagateError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, nil)
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(t.Context(), td)
@@ -1013,7 +1019,7 @@ func Test_ProcessLogs_StatementsErrorMode(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor(tt.statements, tt.errorMode, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions)
+			processor, err := NewProcessor(tt.statements, tt.errorMode, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, nil)
 			assert.NoError(t, err)
 			_, err = processor.ProcessLogs(t.Context(), td)
 			if tt.wantErrorWith != "" {
@@ -1140,7 +1146,7 @@ func Test_ProcessLogs_CacheAccess(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor(tt.statements, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions)
+			processor, err := NewProcessor(tt.statements, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, nil)
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(t.Context(), td)
@@ -1190,7 +1196,7 @@ func Test_ProcessLogs_InferredContextFromConditions(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions)
+			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, nil)
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(t.Context(), td)
@@ -1260,7 +1266,7 @@ func Test_NewProcessor_ConditionsParse(t *testing.T) {
 		t.Run(ctx, func(t *testing.T) {
 			for _, tt := range tests {
 				t.Run(tt.name, func(t *testing.T) {
-					_, err := NewProcessor(tt.statements, ottl.PropagateError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions)
+					_, err := NewProcessor(tt.statements, ottl.PropagateError, false, componenttest.NewNopTelemetrySettings(), DefaultLogFunctions, nil)
 					if tt.wantErrorWith != "" {
 						if err == nil {
 							t.Errorf("expected error containing '%s', got: <nil>", tt.wantErrorWith)
@@ -1324,7 +1330,7 @@ func Test_NewProcessor_NonDefaultFunctions(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NewProcessor(tt.statements, ottl.PropagateError, false, componenttest.NewNopTelemetrySettings(), tt.logFunctions)
+			_, err := NewProcessor(tt.statements, ottl.PropagateError, false, componenttest.NewNopTelemetrySettings(), tt.logFunctions, nil)
 			if tt.wantErrorWith != "" {
 				if err == nil {
 					t.Errorf("expected error containing '%s', got: <nil>", tt.wantErrorWith)
@@ -1337,6 +1343,113 @@ func Test_NewProcessor_NonDefaultFunctions(t *testing.T) {
 	}
 }
 
+func Test_ProcessLogs_StatementTelemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) }) //nolint:usetesting
+
+	componentID := component.MustNewIDWithName("transform", "test")
+	contextStatements := common.WithStatementTelemetry([]common.ContextStatements{
+		{
+			Context:    "resource",
+			Statements: []string{`set(attributes["test"], "pass")`},
+		},
+		{
+			Context:    "log",
+			Conditions: []string{`body == "operationB"`, `body != nil`},
+			Statements: []string{
+				`set(attributes["test"], "pass") where body == "operationA"`,
+				`set(attributes["test"], ParseJSON(1))`,
+			},
+		},
+	}, componentID, "logs")
+
+	processor, err := NewProcessor(contextStatements, ottl.IgnoreError, false, tel.NewTelemetrySettings(), DefaultLogFunctions, nil)
+	require.NoError(t, err)
+	_, err = processor.ProcessLogs(t.Context(), constructLogs())
+	require.NoError(t, err)
+
+	dataPoint := func(group, index int, value int64) metricdata.DataPoint[int64] {
+		return metricdata.DataPoint[int64]{
+			Attributes: attribute.NewSet(
+				attribute.String("otelcol.component.id", "transform/test"),
+				attribute.String("otelcol.signal", "logs"),
+				attribute.Int("ottl.group.index", group),
+				attribute.Int("ottl.statement.index", index),
+			),
+			Value: value,
+		}
+	}
+	expected := map[string][]metricdata.DataPoint[int64]{
+		"otelcol_ottl_statement_executions": {dataPoint(0, 0, 1), dataPoint(1, 0, 2), dataPoint(1, 1, 2)},
+		"otelcol_ottl_statement_matches":    {dataPoint(0, 0, 1), dataPoint(1, 0, 1), dataPoint(1, 1, 2)},
+		"otelcol_ottl_statement_errors":     {dataPoint(1, 1, 2)},
+	}
+	// the second condition is only evaluated when the first one does not match
+	conditionDataPoint := func(index int, value int64) metricdata.DataPoint[int64] {
+		return metricdata.DataPoint[int64]{
+			Attributes: attribute.NewSet(
+				attribute.String("otelcol.component.id", "transform/test"),
+				attribute.String("otelcol.signal", "logs"),
+				attribute.Int("ottl.group.index", 1),
+				attribute.Int("ottl.condition.index", index),
+			),
+			Value: value,
+		}
+	}
+	expected["otelcol_ottl_condition_evaluations"] = []metricdata.DataPoint[int64]{conditionDataPoint(0, 2), conditionDataPoint(1, 1)}
+	expected["otelcol_ottl_condition_matches"] = []metricdata.DataPoint[int64]{conditionDataPoint(0, 1), conditionDataPoint(1, 1)}
+	for name, dataPoints := range expected {
+		got, err := tel.GetMetric(name)
+		require.NoError(t, err)
+		metricdatatest.AssertAggregationsEqual(t, metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints:  dataPoints,
+		}, got.Data, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+	}
+}
+
+func Test_ProcessLogs_StatementTrace(t *testing.T) {
+	core, observed := observer.New(zap.InfoLevel)
+	settings := componenttest.NewNopTelemetrySettings()
+	settings.Logger = zap.New(core)
+
+	contextStatements := common.WithStatementTrace([]common.ContextStatements{
+		{
+			Context: "log",
+			Statements: []string{
+				`set(attributes["test"], "pass") where body == "operationA"`,
+				`set(attributes["test"], "fail") where body == "operationB"`,
+			},
+		},
+	}, 2)
+
+	processor, err := NewProcessor(contextStatements, ottl.IgnoreError, false, settings, DefaultLogFunctions, nil)
+	require.NoError(t, err)
+	_, err = processor.ProcessLogs(t.Context(), constructLogs())
+	require.NoError(t, err)
+
+	// only the first log record is traced
+	require.Equal(t, 1, observed.Len())
+	entry := observed.All()[0]
+	assert.Equal(t, "OTTL statement sequence trace", entry.Message)
+	assert.Equal(t, []any{
+		map[string]any{
+			"index":     int64(0),
+			"statement": `set(log.attributes["test"], "pass") where log.body == "operationA"`,
+			"matched":   true,
+			"changes": []any{
+				map[string]any{"path": "log_record.attributes.test", "before": nil, "after": "pass"},
+			},
+		},
+		map[string]any{
+			"index":     int64(1),
+			"statement": `set(log.attributes["test"], "fail") where log.body == "operationB"`,
+			"matched":   false,
+		},
+	}, entry.ContextMap()["statements"])
+}
+
 func constructLogs() plog.Logs {
 	td := plog.NewLogs()
 	rs0 := td.ResourceLogs().AppendEmpty()
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config configures the keyed state store available to the state converters.
+type Config struct {
+	// MaxEntries is the maximum number of keys kept in the store. When exceeded,
+	// the least recently updated keys are evicted.
+	MaxEntries int `mapstructure:"max_entries"`
+	// TTL is the duration after which a key that hasn't been updated is forgotten.
+	TTL time.Duration `mapstructure:"ttl"`
+	// Storage is the ID of an optional storage extension used to persist the
+	// state across restarts.
+	Storage *component.ID `mapstructure:"storage"`
+}
+
+// Validate checks that the state store configuration is valid.
+func (c *Config) Validate() error {
+	var errs error
+	if c.MaxEntries <= 0 {
+		errs = errors.Join(errs, errors.New("'max_entries' must be positive"))
+	}
+	if c.TTL <= 0 {
+		errs = errors.Join(errs, errors.New("'ttl' must be positive"))
+	}
+	return errs
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// WithFunctions returns a copy of the functions with the state converters added. Functions
+// with the same name as a state converter are kept. The functions are returned unchanged
+// when the store is nil.
+func WithFunctions[K any](functions map[string]ottl.Factory[K], store *Store) map[string]ottl.Factory[K] {
+	if store == nil {
+		return functions
+	}
+	result := maps.Clone(functions)
+	if result == nil {
+		result = map[string]ottl.Factory[K]{}
+	}
+	for name, factory := range ottl.CreateFactoryMap(
+		NewGetStateFactory[K](store),
+		NewSetStateFactory[K](store),
+		NewPreviousFactory[K](store),
+		NewDeltaFactory[K](store),
+	) {
+		if _, ok := result[name]; !ok {
+			result[name] = factory
+		}
+	}
+	return result
+}
+
+type GetStateArguments[K any] struct {
+	Key ottl.StringGetter[K]
+}
+
+func NewGetStateFactory[K any](store *Store) ottl.Factory[K] {
+	return ottl.NewFactory("GetState", &GetStateArguments[K]{}, func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*GetStateArguments[K])
+		if !ok {
+			return nil, errors.New("GetStateFactory args must be of type *GetStateArguments[K]")
+		}
+		return getState(store, args.Key), nil
+	})
+}
+
+func getState[K any](store *Store, key ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		k, err := key.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		value, _ := store.Get(k)
+		return value, nil
+	}
+}
+
+type SetStateArguments[K any] struct {
+	Key   ottl.StringGetter[K]
+	Value ottl.Getter[K]
+}
+
+func NewSetStateFactory[K any](store *Store) ottl.Factory[K] {
+	return ottl.NewFactory("SetState", &SetStateArguments[K]{}, func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*SetStateArguments[K])
+		if !ok {
+			return nil, errors.New("SetStateFactory args must be of type *SetStateArguments[K]")
+		}
+		return setState(store, args.Key, args.Value), nil
+	})
+}
+
+func setState[K any](store *Store, key ottl.StringGetter[K], value ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		_, current, err := swap(ctx, tCtx, store, key, value)
+		return current, err
+	}
+}
+
+type PreviousArguments[K any] struct {
+	Key   ottl.StringGetter[K]
+	Value ottl.Getter[K]
+}
+
+func NewPreviousFactory[K any](store *Store) ottl.Factory[K] {
+	return ottl.NewFactory("Previous", &PreviousArguments[K]{}, func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*PreviousArguments[K])
+		if !ok {
+			return nil, errors.New("PreviousFactory args must be of type *PreviousArguments[K]")
+		}
+		return previous(store, args.Key, args.Value), nil
+	})
+}
+
+func previous[K any](store *Store, key ottl.StringGetter[K], value ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		prev, _, err := swap(ctx, tCtx, store, key, value)
+		return prev, err
+	}
+}
+
+type DeltaArguments[K any] struct {
+	Key   ottl.StringGetter[K]
+	Value ottl.Getter[K]
+}
+
+func NewDeltaFactory[K any](store *Store) ottl.Factory[K] {
+	return ottl.NewFactory("Delta", &DeltaArguments[K]{}, func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*DeltaArguments[K])
+		if !ok {
+			return nil, errors.New("DeltaFactory args must be of type *DeltaArguments[K]")
+		}
+		return delta(store, args.Key, args.Value), nil
+	})
+}
+
+func delta[K any](store *Store, key ottl.StringGetter[K], value ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		k, current, err := evaluate(ctx, tCtx, key, value)
+		if err != nil || current == nil {
+			return nil, err
+		}
+		switch current.(type) {
+		case int64, float64:
+		default:
+			return nil, fmt.Errorf("delta value must be an int or a double but got %T", current)
+		}
+		prev, _, err := store.Swap(k, current)
+		if err != nil || prev == nil {
+			return nil, err
+		}
+		return subtract(current, prev)
+	}
+}
+
+// subtract returns current - prev as an int when both are ints, and as a double otherwise.
+func subtract(current, prev any) (any, error) {
+	switch p := prev.(type) {
+	case int64:
+		if c, ok := current.(int64); ok {
+			return c - p, nil
+		}
+		return current.(float64) - float64(p), nil
+	case float64:
+		if c, ok := current.(int64); ok {
+			return float64(c) - p, nil
+		}
+		return current.(float64) - p, nil
+	default:
+		return nil, fmt.Errorf("previous value must be an int or a double but got %T", prev)
+	}
+}
+
+// swap stores the value for the key and returns both the previous and the new value.
+// Nil values are not stored and leave the state of the key unchanged.
+func swap[K any](ctx context.Context, tCtx K, store *Store, key ottl.StringGetter[K], value ottl.Getter[K]) (prev, current any, err error) {
+	k, current, err := evaluate(ctx, tCtx, key, value)
+	if err != nil || current == nil {
+		return nil, nil, err
+	}
+	prev, _, err = store.Swap(k, current)
+	if err != nil {
+		return nil, nil, err
+	}
+	return prev, current, nil
+}
+
+func evaluate[K any](ctx context.Context, tCtx K, key ottl.StringGetter[K], value ottl.Getter[K]) (string, any, error) {
+	k, err := key.Get(ctx, tCtx)
+	if err != nil {
+		return "", nil, err
+	}
+	v, err := value.Get(ctx, tCtx)
+	if err != nil {
+		return "", nil, err
+	}
+	return k, v, nil
+}
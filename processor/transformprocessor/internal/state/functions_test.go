@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+func keyGetter(key string) ottl.StringGetter[any] {
+	return &ottl.StandardStringGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return key, nil
+		},
+	}
+}
+
+func valueGetter(value any) ottl.Getter[any] {
+	return &ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return value, nil
+		},
+	}
+}
+
+func newFunctionsTestStore() *Store {
+	return NewStore(&Config{MaxEntries: 10, TTL: time.Minute}, testComponentID, "logs", zap.NewNop())
+}
+
+func Test_previous(t *testing.T) {
+	store := newFunctionsTestStore()
+
+	tests := []struct {
+		name     string
+		key      string
+		value    any
+		expected any
+	}{
+		{
+			name:     "first value",
+			key:      "a",
+			value:    int64(1),
+			expected: nil,
+		},
+		{
+			name:     "second value",
+			key:      "a",
+			value:    int64(2),
+			expected: int64(1),
+		},
+		{
+			name:     "nil value is ignored",
+			key:      "a",
+			value:    nil,
+			expected: nil,
+		},
+		{
+			name:     "third value",
+			key:      "a",
+			value:    "three",
+			expected: int64(2),
+		},
+		{
+			name:     "other key",
+			key:      "b",
+			value:    true,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := previous(store, keyGetter(tt.key), valueGetter(tt.value))(t.Context(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_delta(t *testing.T) {
+	store := newFunctionsTestStore()
+
+	tests := []struct {
+		name     string
+		key      string
+		value    any
+		expected any
+	}{
+		{
+			name:     "no previous value",
+			key:      "a",
+			value:    int64(10),
+			expected: nil,
+		},
+		{
+			name:     "ints",
+			key:      "a",
+			value:    int64(15),
+			expected: int64(5),
+		},
+		{
+			name:     "int and double",
+			key:      "a",
+			value:    17.5,
+			expected: 2.5,
+		},
+		{
+			name:     "doubles",
+			key:      "a",
+			value:    12.5,
+			expected: -5.0,
+		},
+		{
+			name:     "double and int",
+			key:      "a",
+			value:    int64(13),
+			expected: 0.5,
+		},
+		{
+			name:     "nil value is ignored",
+			key:      "a",
+			value:    nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := delta(store, keyGetter(tt.key), valueGetter(tt.value))(t.Context(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_delta_error(t *testing.T) {
+	store := newFunctionsTestStore()
+
+	_, err := delta(store, keyGetter("a"), valueGetter("1"))(t.Context(), nil)
+	assert.EqualError(t, err, "delta value must be an int or a double but got string")
+	_, found := store.Get("a")
+	assert.False(t, found)
+
+	_, err = setState(store, keyGetter("a"), valueGetter("1"))(t.Context(), nil)
+	require.NoError(t, err)
+	_, err = delta(store, keyGetter("a"), valueGetter(int64(1)))(t.Context(), nil)
+	assert.EqualError(t, err, "previous value must be an int or a double but got string")
+}
+
+func Test_setState_getState(t *testing.T) {
+	store := newFunctionsTestStore()
+
+	result, err := getState(store, keyGetter("a"))(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+
+	result, err = setState(store, keyGetter("a"), valueGetter("value"))(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "value", result)
+
+	result, err = getState(store, keyGetter("a"))(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "value", result)
+
+	_, err = setState(store, keyGetter("a"), valueGetter(map[string]any{}))(t.Context(), nil)
+	assert.EqualError(t, err, "unsupported state value type map[string]interface {}")
+}
+
+func TestWithFunctions(t *testing.T) {
+	custom := ottl.NewFactory("Delta", &GetStateArguments[any]{}, func(ottl.FunctionContext, ottl.Arguments) (ottl.ExprFunc[any], error) {
+		return nil, nil
+	})
+	functions := ottl.CreateFactoryMap(custom, ottlfuncs.NewIsStringFactory[any]())
+
+	assert.Equal(t, functions, WithFunctions(functions, nil))
+
+	result := WithFunctions(functions, newFunctionsTestStore())
+	assert.Len(t, functions, 2)
+	assert.Len(t, result, 5)
+	assert.Contains(t, result, "IsString")
+	assert.Contains(t, result, "GetState")
+	assert.Contains(t, result, "SetState")
+	assert.Contains(t, result, "Previous")
+	// user-defined functions take precedence over the state converters
+	assert.IsType(t, &GetStateArguments[any]{}, result["Delta"].CreateDefaultArguments())
+}
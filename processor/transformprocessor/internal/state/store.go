@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.uber.org/zap"
+)
+
+// snapshotKey is the key under which the state is persisted in the storage extension.
+const snapshotKey = "state"
+
+type entry struct {
+	key     string
+	value   any
+	updated time.Time
+}
+
+// Store is a bounded, in-memory, key-value store whose entries expire when they
+// haven't been updated for the configured TTL. When a storage extension is
+// configured, the entries are loaded on start and persisted on shutdown.
+type Store struct {
+	maxEntries  int
+	ttl         time.Duration
+	storageID   *component.ID
+	componentID component.ID
+	signal      string
+	logger      *zap.Logger
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// order holds the entries from the most to the least recently updated.
+	order  *list.List
+	client storage.Client
+}
+
+// NewStore creates a Store for the given signal of a processor. It returns nil
+// when the configuration is nil, meaning that the state is disabled.
+func NewStore(cfg *Config, componentID component.ID, signal string, logger *zap.Logger) *Store {
+	if cfg == nil {
+		return nil
+	}
+	return &Store{
+		maxEntries:  cfg.MaxEntries,
+		ttl:         cfg.TTL,
+		storageID:   cfg.Storage,
+		componentID: componentID,
+		signal:      signal,
+		logger:      logger,
+		now:         time.Now,
+		entries:     map[string]*list.Element{},
+		order:       list.New(),
+	}
+}
+
+// Get returns the value of the key, if it is set and hasn't expired.
+func (s *Store) Get(key string) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := elem.Value.(*entry)
+	if s.expired(e, s.now()) {
+		s.remove(elem)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Swap sets the value of the key and returns its previous value, if it was set and
+// hadn't expired. Only string, int64, float64 and bool values are supported.
+func (s *Store) Swap(key string, value any) (any, bool, error) {
+	switch value.(type) {
+	case string, int64, float64, bool:
+	default:
+		return nil, false, fmt.Errorf("unsupported state value type %T", value)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var previous any
+	var found bool
+	if elem, ok := s.entries[key]; ok {
+		e := elem.Value.(*entry)
+		if !s.expired(e, now) {
+			previous, found = e.value, true
+		}
+		e.value = value
+		e.updated = now
+		s.order.MoveToFront(elem)
+	} else {
+		s.entries[key] = s.order.PushFront(&entry{key: key, value: value, updated: now})
+	}
+	s.evict(now)
+	return previous, found, nil
+}
+
+// Len returns the number of entries in the store, including the expired ones that
+// haven't been evicted yet.
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+func (s *Store) expired(e *entry, now time.Time) bool {
+	return now.Sub(e.updated) >= s.ttl
+}
+
+func (s *Store) remove(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.entries, elem.Value.(*entry).key)
+}
+
+// evict removes the entries exceeding the maximum size, then the expired ones. Since
+// entries are ordered by update time, both are found at the back of the list.
+func (s *Store) evict(now time.Time) {
+	for s.order.Len() > s.maxEntries {
+		s.remove(s.order.Back())
+	}
+	for elem := s.order.Back(); elem != nil && s.expired(elem.Value.(*entry), now); elem = s.order.Back() {
+		s.remove(elem)
+	}
+}
+
+// persistedEntry is the representation of an entry in the storage extension. Only
+// one of the values is set, which keeps the type of the value across restarts.
+type persistedEntry struct {
+	Key     string    `json:"key"`
+	Updated time.Time `json:"updated"`
+	String  *string   `json:"string,omitempty"`
+	Int     *int64    `json:"int,omitempty"`
+	Double  *float64  `json:"double,omitempty"`
+	Bool    *bool     `json:"bool,omitempty"`
+}
+
+func (p persistedEntry) value() (any, bool) {
+	switch {
+	case p.String != nil:
+		return *p.String, true
+	case p.Int != nil:
+		return *p.Int, true
+	case p.Double != nil:
+		return *p.Double, true
+	case p.Bool != nil:
+		return *p.Bool, true
+	default:
+		return nil, false
+	}
+}
+
+func newPersistedEntry(e *entry) persistedEntry {
+	p := persistedEntry{Key: e.key, Updated: e.updated}
+	switch v := e.value.(type) {
+	case string:
+		p.String = &v
+	case int64:
+		p.Int = &v
+	case float64:
+		p.Double = &v
+	case bool:
+		p.Bool = &v
+	}
+	return p
+}
+
+// Start loads the persisted state when a storage extension is configured.
+func (s *Store) Start(ctx context.Context, host component.Host) error {
+	if s == nil || s.storageID == nil {
+		return nil
+	}
+
+	client, err := getStorageClient(ctx, host, *s.storageID, s.componentID, s.signal)
+	if err != nil {
+		return err
+	}
+
+	data, err := client.Get(ctx, snapshotKey)
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to load state: %w", err), client.Close(ctx))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.client = client
+	if data == nil {
+		return nil
+	}
+
+	var persisted []persistedEntry
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		// A corrupted state shouldn't prevent the processor from starting.
+		s.logger.Warn("Failed to decode the persisted state, starting with an empty state", zap.Error(err))
+		return nil
+	}
+
+	// Entries are persisted from the least to the most recently updated.
+	for _, p := range persisted {
+		value, ok := p.value()
+		if !ok {
+			continue
+		}
+		if elem, ok := s.entries[p.Key]; ok {
+			s.remove(elem)
+		}
+		s.entries[p.Key] = s.order.PushFront(&entry{key: p.Key, value: value, updated: p.Updated})
+	}
+	s.evict(s.now())
+	return nil
+}
+
+// Shutdown persists the state when a storage extension is configured.
+func (s *Store) Shutdown(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client == nil {
+		return nil
+	}
+
+	s.evict(s.now())
+	persisted := make([]persistedEntry, 0, s.order.Len())
+	for elem := s.order.Back(); elem != nil; elem = elem.Prev() {
+		persisted = append(persisted, newPersistedEntry(elem.Value.(*entry)))
+	}
+
+	var errs error
+	data, err := json.Marshal(persisted)
+	if err == nil {
+		err = s.client.Set(ctx, snapshotKey, data)
+	}
+	if err != nil {
+		errs = fmt.Errorf("failed to persist state: %w", err)
+	}
+	errs = errors.Join(errs, s.client.Close(ctx))
+	s.client = nil
+	return errs
+}
+
+// getStorageClient returns a client of the storage extension with the given ID.
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID, signal string) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindProcessor, componentID, signal)
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+var testComponentID = component.MustNewID("transform")
+
+func newTestStore(t *testing.T, cfg *Config) (*Store, *time.Time) {
+	t.Helper()
+	now := time.Unix(1000, 0)
+	store := NewStore(cfg, testComponentID, "logs", zap.NewNop())
+	require.NotNil(t, store)
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func TestNewStoreDisabled(t *testing.T) {
+	store := NewStore(nil, testComponentID, "logs", zap.NewNop())
+	assert.Nil(t, store)
+	assert.NoError(t, store.Start(t.Context(), componenttest.NewNopHost()))
+	assert.NoError(t, store.Shutdown(t.Context()))
+}
+
+func TestStoreSwap(t *testing.T) {
+	store, _ := newTestStore(t, &Config{MaxEntries: 10, TTL: time.Minute})
+
+	prev, found, err := store.Swap("a", int64(1))
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.Nil(t, prev)
+
+	prev, found, err = store.Swap("a", "two")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, int64(1), prev)
+
+	value, found := store.Get("a")
+	assert.True(t, found)
+	assert.Equal(t, "two", value)
+
+	_, found = store.Get("b")
+	assert.False(t, found)
+
+	_, _, err = store.Swap("a", []any{1})
+	assert.EqualError(t, err, "unsupported state value type []interface {}")
+}
+
+func TestStoreMaxEntries(t *testing.T) {
+	store, now := newTestStore(t, &Config{MaxEntries: 2, TTL: time.Minute})
+
+	for _, key := range []string{"a", "b", "c"} {
+		_, _, err := store.Swap(key, true)
+		require.NoError(t, err)
+		*now = now.Add(time.Second)
+	}
+	assert.Equal(t, 2, store.Len())
+
+	_, found := store.Get("a")
+	assert.False(t, found)
+
+	// updating a key makes it the most recently updated one
+	_, _, err := store.Swap("b", false)
+	require.NoError(t, err)
+	_, _, err = store.Swap("d", false)
+	require.NoError(t, err)
+
+	_, found = store.Get("c")
+	assert.False(t, found)
+	_, found = store.Get("b")
+	assert.True(t, found)
+	_, found = store.Get("d")
+	assert.True(t, found)
+}
+
+func TestStoreTTL(t *testing.T) {
+	store, now := newTestStore(t, &Config{MaxEntries: 10, TTL: time.Minute})
+
+	_, _, err := store.Swap("a", 1.5)
+	require.NoError(t, err)
+	*now = now.Add(30 * time.Second)
+	_, _, err = store.Swap("b", 2.5)
+	require.NoError(t, err)
+
+	*now = now.Add(30 * time.Second)
+	_, found := store.Get("a")
+	assert.False(t, found)
+
+	// an expired previous value isn't returned
+	prev, found, err := store.Swap("a", 3.5)
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.Nil(t, prev)
+
+	// expired entries are evicted on updates
+	*now = now.Add(45 * time.Second)
+	_, _, err = store.Swap("c", 4.5)
+	require.NoError(t, err)
+	assert.Equal(t, 2, store.Len())
+}
+
+func TestStorePersistence(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+	cfg := &Config{MaxEntries: 10, TTL: time.Minute, Storage: &storageID}
+
+	store, now := newTestStore(t, cfg)
+	require.NoError(t, store.Start(t.Context(), host))
+	_, _, err := store.Swap("old", "a")
+	require.NoError(t, err)
+	*now = now.Add(30 * time.Second)
+	_, _, err = store.Swap("recent", "b")
+	require.NoError(t, err)
+	require.NoError(t, store.Shutdown(t.Context()))
+
+	// entries expire while the processor is stopped
+	restarted, restartedNow := newTestStore(t, cfg)
+	*restartedNow = now.Add(45 * time.Second)
+	require.NoError(t, restarted.Start(t.Context(), host))
+	defer func() {
+		assert.NoError(t, restarted.Shutdown(t.Context()))
+	}()
+
+	assert.Equal(t, 1, restarted.Len())
+	value, found := restarted.Get("recent")
+	assert.True(t, found)
+	assert.Equal(t, "b", value)
+}
+
+func TestStorePersistenceTypes(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+	cfg := &Config{MaxEntries: 10, TTL: time.Minute, Storage: &storageID}
+	values := map[string]any{"string": "a", "int": int64(1), "double": 1.5, "bool": true}
+
+	store, _ := newTestStore(t, cfg)
+	require.NoError(t, store.Start(t.Context(), host))
+	for key, value := range values {
+		_, _, err := store.Swap(key, value)
+		require.NoError(t, err)
+	}
+	require.NoError(t, store.Shutdown(t.Context()))
+
+	restarted, _ := newTestStore(t, cfg)
+	require.NoError(t, restarted.Start(t.Context(), host))
+	defer func() {
+		assert.NoError(t, restarted.Shutdown(t.Context()))
+	}()
+
+	for key, expected := range values {
+		value, found := restarted.Get(key)
+		assert.True(t, found, key)
+		assert.Equal(t, expected, value, key)
+	}
+}
+
+func TestStoreStorageExtensionNotFound(t *testing.T) {
+	storageID := storagetest.NewStorageID("test")
+	store := NewStore(&Config{MaxEntries: 10, TTL: time.Minute, Storage: &storageID}, testComponentID, "logs", zap.NewNop())
+	err := store.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, `storage extension "test_storage/test" not found`)
+	assert.NoError(t, store.Shutdown(t.Context()))
+
+	nonStorageID := storagetest.NewNonStorageID("test")
+	store = NewStore(&Config{MaxEntries: 10, TTL: time.Minute, Storage: &nonStorageID}, testComponentID, "logs", zap.NewNop())
+	err = store.Start(t.Context(), storagetest.NewStorageHost().WithNonStorageExtension("test"))
+	assert.ErrorContains(t, err, `extension "non_storage/test" is not a storage extension`)
+}
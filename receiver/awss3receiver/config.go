@@ -66,6 +66,14 @@ type Config struct {
 	EndTime       string             `mapstructure:"endtime"`
 	Encodings     []Encoding         `mapstructure:"encodings"`
 	Notifications Notifications      `mapstructure:"notifications"`
+	// MaxConcurrency is the maximum number of objects of the time range fetched concurrently.
+	MaxConcurrency int `mapstructure:"max_concurrency"`
+	// Ordering controls the order in which the objects of the time range are processed.
+	// One of 'key', 'partition' or 'none'.
+	Ordering string `mapstructure:"ordering"`
+	// Storage is the ID of a storage extension used to checkpoint the last processed object
+	// of the time range, so that reading resumes from it after a restart.
+	Storage *component.ID `mapstructure:"storage"`
 	// SQS configures receiving S3 object change notifications via an SQS queue.
 	SQS *SQSConfig `mapstructure:"sqs"`
 }
@@ -75,6 +83,15 @@ const (
 	S3PartitionHour   = "hour"
 )
 
+const (
+	// OrderingKey processes the objects one at a time, in the order of their partitions and keys.
+	OrderingKey = "key"
+	// OrderingPartition processes the partitions one at a time, the objects of a partition concurrently.
+	OrderingPartition = "partition"
+	// OrderingNone processes the objects as soon as they are fetched.
+	OrderingNone = "none"
+)
+
 func createDefaultConfig() component.Config {
 	return &Config{
 		S3Downloader: S3DownloaderConfig{
@@ -82,6 +99,8 @@ func createDefaultConfig() component.Config {
 			S3Partition:         S3PartitionMinute,
 			EndpointPartitionID: "aws",
 		},
+		MaxConcurrency: 1,
+		Ordering:       OrderingKey,
 	}
 }
 
@@ -129,6 +148,18 @@ func (c Config) Validate() error {
 		}
 	}
 
+	if c.MaxConcurrency < 0 {
+		errs = multierr.Append(errs, errors.New("max_concurrency must not be negative"))
+	}
+	switch c.Ordering {
+	case "", OrderingKey, OrderingPartition, OrderingNone:
+	default:
+		errs = multierr.Append(errs, fmt.Errorf("ordering must be one of '%s', '%s' or '%s'", OrderingKey, OrderingPartition, OrderingNone))
+	}
+	if hasSQS && c.Storage != nil {
+		errs = multierr.Append(errs, errors.New("storage can only be used with starttime/endtime"))
+	}
+
 	// Validate SQS notifications if configured
 	if c.SQS != nil {
 		if c.SQS.QueueURL == "" {
@@ -67,6 +67,7 @@ func TestLoadConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 	opampExtension := component.NewIDWithName(component.MustNewType("opamp"), "bar")
+	fileStorage := component.MustNewID("file_storage")
 	tests := []struct {
 		id           component.ID
 		expected     component.Config
@@ -89,8 +90,10 @@ func TestLoadConfig(t *testing.T) {
 					S3Partition:         "minute",
 					EndpointPartitionID: "aws",
 				},
-				StartTime: "2024-01-31 15:00",
-				EndTime:   "2024-02-03",
+				StartTime:      "2024-01-31 15:00",
+				EndTime:        "2024-02-03",
+				MaxConcurrency: 1,
+				Ordering:       OrderingKey,
 			},
 		},
 		{
@@ -117,6 +120,8 @@ func TestLoadConfig(t *testing.T) {
 				Notifications: Notifications{
 					OpAMP: &opampExtension,
 				},
+				MaxConcurrency: 1,
+				Ordering:       OrderingKey,
 			},
 		},
 		{
@@ -128,8 +133,10 @@ func TestLoadConfig(t *testing.T) {
 					S3Partition:         "minute",
 					EndpointPartitionID: "aws",
 				},
-				StartTime: "2024-01-31T15:00:00Z",
-				EndTime:   "2024-02-03T00:00:00Z",
+				StartTime:      "2024-01-31T15:00:00Z",
+				EndTime:        "2024-02-03T00:00:00Z",
+				MaxConcurrency: 1,
+				Ordering:       OrderingKey,
 			},
 		},
 		{
@@ -146,8 +153,30 @@ func TestLoadConfig(t *testing.T) {
 					Region:   "us-east-1",
 					Endpoint: "http://localhost:4575",
 				},
+				MaxConcurrency: 1,
+				Ordering:       OrderingKey,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "6"),
+			expected: &Config{
+				S3Downloader: S3DownloaderConfig{
+					Region:              "us-east-1",
+					S3Bucket:            "abucket",
+					S3Partition:         "hour",
+					EndpointPartitionID: "aws",
+				},
+				StartTime:      "2024-01-31",
+				EndTime:        "2024-02-01",
+				MaxConcurrency: 8,
+				Ordering:       OrderingPartition,
+				Storage:        &fileStorage,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "7"),
+			errorMessage: "max_concurrency must not be negative; ordering must be one of 'key', 'partition' or 'none'; storage can only be used with starttime/endtime",
+		},
 	}
 
 	for _, tt := range tests {
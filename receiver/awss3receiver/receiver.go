@@ -11,9 +11,11 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -34,9 +36,13 @@ type receiverProcessor interface {
 }
 
 type awss3Receiver struct {
+	id              component.ID
 	reader          s3Reader
 	logger          *zap.Logger
 	cancel          context.CancelFunc
+	readerDone      sync.WaitGroup
+	storageID       *component.ID
+	storageClient   storage.Client
 	obsrecv         *receiverhelper.ObsReport
 	encodingsConfig []Encoding
 	telemetryType   string
@@ -76,6 +82,7 @@ func newAWSS3Receiver(ctx context.Context, cfg *Config, telemetryType string, se
 	}
 
 	return &awss3Receiver{
+		id:              settings.ID,
 		reader:          reader,
 		telemetryType:   telemetryType,
 		logger:          settings.Logger,
@@ -84,6 +91,7 @@ func newAWSS3Receiver(ctx context.Context, cfg *Config, telemetryType string, se
 		dataProcessor:   processor,
 		encodingsConfig: cfg.Encodings,
 		notifier:        notifier,
+		storageID:       cfg.Storage,
 	}, nil
 }
 
@@ -99,10 +107,21 @@ func (r *awss3Receiver) Start(ctx context.Context, host component.Host) error {
 	if err != nil {
 		return err
 	}
+	if r.storageID != nil {
+		if c, ok := r.reader.(checkpointer); ok {
+			r.storageClient, err = getStorageClient(ctx, host, *r.storageID, r.id, r.telemetryType)
+			if err != nil {
+				return err
+			}
+			c.setStorageClient(r.storageClient)
+		}
+	}
 
 	var cancelCtx context.Context
 	cancelCtx, r.cancel = context.WithCancel(context.Background())
+	r.readerDone.Add(1)
 	go func() {
+		defer r.readerDone.Done()
 		_ = r.reader.readAll(cancelCtx, r.telemetryType, r.receiveBytes)
 	}()
 	return nil
@@ -117,6 +136,10 @@ func (r *awss3Receiver) Shutdown(ctx context.Context) error {
 	if r.cancel != nil {
 		r.cancel()
 	}
+	r.readerDone.Wait()
+	if r.storageClient != nil {
+		return r.storageClient.Close(ctx)
+	}
 	return nil
 }
 
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.uber.org/zap"
+)
+
+const checkpointKey = "checkpoint"
+
+// checkpoint is the last object of a time range whose predecessors were all processed.
+type checkpoint struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Partition time.Time `json:"partition"`
+	Key       string    `json:"key"`
+}
+
+// loadCheckpoint returns the checkpoint of the configured time range, or nil if
+// reading the time range didn't start yet.
+func (s3Reader *s3TimeBasedReader) loadCheckpoint(ctx context.Context) (*checkpoint, error) {
+	if s3Reader.storageClient == nil {
+		return nil, nil
+	}
+	data, err := s3Reader.storageClient.Get(ctx, checkpointKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint: %w", err)
+	}
+	if !cp.StartTime.Equal(s3Reader.startTime) || !cp.EndTime.Equal(s3Reader.endTime) {
+		s3Reader.logger.Info("Ignoring checkpoint of another time range",
+			zap.Time("start_time", cp.StartTime), zap.Time("end_time", cp.EndTime))
+		return nil, nil
+	}
+	return &cp, nil
+}
+
+func (s3Reader *s3TimeBasedReader) saveCheckpoint(ctx context.Context, obj s3Object) {
+	if s3Reader.storageClient == nil {
+		return
+	}
+	data, err := json.Marshal(checkpoint{
+		StartTime: s3Reader.startTime,
+		EndTime:   s3Reader.endTime,
+		Partition: obj.partition,
+		Key:       obj.key,
+	})
+	if err == nil {
+		err = s3Reader.storageClient.Set(ctx, checkpointKey, data)
+	}
+	if err != nil {
+		s3Reader.logger.Warn("Failed to save checkpoint", zap.String("key", obj.key), zap.Error(err))
+	}
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID, telemetryType string) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindReceiver, componentID, telemetryType)
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver/internal/metadata"
+)
+
+func newTestStorageClient() *storagetest.TestClient {
+	return storagetest.NewInMemoryClient(component.KindReceiver, component.MustNewID("awss3"), "traces")
+}
+
+func Test_readAll_ResumesFromCheckpoint(t *testing.T) {
+	tests := []struct {
+		name           string
+		ordering       string
+		maxConcurrency int
+	}{
+		{name: "ordering key", ordering: OrderingKey, maxConcurrency: 1},
+		{name: "ordering none", ordering: OrderingNone, maxConcurrency: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestStorageClient()
+			bucket := newFakeBucket()
+			bucket.fail = func(index int) bool {
+				return index == 4
+			}
+
+			first := bucket.reader(tt.ordering, tt.maxConcurrency)
+			first.setStorageClient(client)
+			require.Error(t, first.readAll(t.Context(), "traces", (&recorder{}).callback))
+
+			// The objects before the failed one were all processed.
+			cp, err := first.loadCheckpoint(t.Context())
+			require.NoError(t, err)
+			require.NotNil(t, cp)
+			assert.Equal(t, testTime.Add(time.Minute), cp.Partition)
+			assert.Equal(t, bucket.keys[3], cp.Key)
+
+			bucket.fail = nil
+			bucket.listings = nil
+			second := bucket.reader(tt.ordering, tt.maxConcurrency)
+			second.setStorageClient(client)
+			rec := &recorder{}
+			require.NoError(t, second.readAll(t.Context(), "traces", rec.callback))
+			assert.ElementsMatch(t, bucket.keys[4:], rec.received())
+
+			// The first partition is skipped, the second one is listed after the checkpoint.
+			require.Len(t, bucket.listings, 2)
+			assert.Equal(t, bucket.keys[3], *bucket.listings[0].StartAfter)
+			assert.Nil(t, bucket.listings[1].StartAfter)
+		})
+	}
+}
+
+func Test_readAll_CompletedCheckpoint(t *testing.T) {
+	client := newTestStorageClient()
+	bucket := newFakeBucket()
+
+	first := bucket.reader(OrderingKey, 2)
+	first.setStorageClient(client)
+	require.NoError(t, first.readAll(t.Context(), "traces", (&recorder{}).callback))
+
+	notifier := mockNotifier{}
+	second := bucket.reader(OrderingKey, 2)
+	second.setStorageClient(client)
+	second.notifier = &notifier
+	rec := &recorder{}
+	require.NoError(t, second.readAll(t.Context(), "traces", rec.callback))
+	assert.Empty(t, rec.received())
+	require.Len(t, notifier.messages, 2)
+	assert.Equal(t, testTime.Add(2*time.Minute), notifier.messages[0].IngestTime)
+	assert.Equal(t, IngestStatusCompleted, notifier.messages[1].IngestStatus)
+}
+
+func Test_loadCheckpoint_OtherTimeRange(t *testing.T) {
+	client := newTestStorageClient()
+	bucket := newFakeBucket()
+
+	reader := bucket.reader(OrderingKey, 1)
+	reader.setStorageClient(client)
+	reader.saveCheckpoint(t.Context(), s3Object{partition: testTime, key: bucket.keys[0]})
+	cp, err := reader.loadCheckpoint(t.Context())
+	require.NoError(t, err)
+	require.NotNil(t, cp)
+
+	reader.endTime = reader.endTime.Add(time.Hour)
+	cp, err = reader.loadCheckpoint(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, cp)
+}
+
+func Test_loadCheckpoint_Error(t *testing.T) {
+	client := newTestStorageClient()
+	require.NoError(t, client.Set(t.Context(), checkpointKey, []byte("{")))
+	notifier := mockNotifier{}
+	reader := newFakeBucket().reader(OrderingKey, 1)
+	reader.setStorageClient(client)
+	reader.notifier = &notifier
+
+	err := reader.readAll(t.Context(), "traces", func(context.Context, string, []byte) error {
+		return errors.New("unexpected call")
+	})
+	require.ErrorContains(t, err, "failed to decode checkpoint")
+	require.Len(t, notifier.messages, 1)
+	assert.Equal(t, IngestStatusFailed, notifier.messages[0].IngestStatus)
+}
+
+func Test_receiver_StorageNotFound(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.S3Downloader.S3Bucket = "bucket"
+	cfg.StartTime = "2024-01-31"
+	cfg.EndTime = "2024-02-03"
+	storageID := storagetest.NewStorageID("missing")
+	cfg.Storage = &storageID
+
+	r, err := newAWSS3TraceReceiver(t.Context(), cfg, consumertest.NewNop(), receivertest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	require.ErrorContains(t, r.Start(t.Context(), componenttest.NewNopHost()), `storage extension "test_storage/missing" not found`)
+	require.NoError(t, r.Shutdown(t.Context()))
+}
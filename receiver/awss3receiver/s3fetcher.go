@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// s3Object is an object listed while reading a time range.
+type s3Object struct {
+	// seq is the position of the object in the listing order.
+	seq       uint64
+	partition time.Time
+	key       string
+}
+
+// objectFetcher fetches and processes the objects of a time range concurrently.
+//
+// It keeps track of the processed objects to checkpoint the progress of the read: the
+// checkpoint is the last object of the listing order whose predecessors were all processed,
+// whatever the order in which the objects were actually processed.
+type objectFetcher struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	reader   *s3TimeBasedReader
+	callback s3ObjectCallback
+	// ordered is set when the callback must be called in the listing order.
+	ordered bool
+	slots   chan struct{}
+	wg      sync.WaitGroup
+
+	mu sync.Mutex
+	// turn is signaled when an object was delivered to the callback in ordered mode.
+	turn      *sync.Cond
+	submitted uint64
+	delivered uint64
+	processed map[uint64]s3Object
+	// lowWater is the sequence number of the first object that wasn't processed yet.
+	lowWater uint64
+	// err is the first error met, the partition of the object which failed is kept in failed.
+	err    error
+	failed time.Time
+}
+
+func newObjectFetcher(ctx context.Context, reader *s3TimeBasedReader, callback s3ObjectCallback) *objectFetcher {
+	concurrency := reader.maxConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	f := &objectFetcher{
+		reader:    reader,
+		callback:  callback,
+		ordered:   reader.ordering != OrderingPartition && reader.ordering != OrderingNone,
+		slots:     make(chan struct{}, concurrency),
+		processed: make(map[uint64]s3Object),
+	}
+	f.ctx, f.cancel = context.WithCancel(ctx)
+	f.turn = sync.NewCond(&f.mu)
+	return f
+}
+
+// submit schedules the processing of an object, it blocks while the maximum number of
+// objects are being processed. It returns the first error met by the fetcher.
+func (f *objectFetcher) submit(partition time.Time, key string) error {
+	select {
+	case f.slots <- struct{}{}:
+	case <-f.ctx.Done():
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.err != nil {
+			return f.err
+		}
+		return f.ctx.Err()
+	}
+
+	f.mu.Lock()
+	if f.err != nil {
+		f.mu.Unlock()
+		<-f.slots
+		return f.err
+	}
+	obj := s3Object{seq: f.submitted, partition: partition, key: key}
+	f.submitted++
+	f.mu.Unlock()
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		defer func() { <-f.slots }()
+		f.done(obj, f.process(obj))
+	}()
+	return nil
+}
+
+func (f *objectFetcher) process(obj s3Object) error {
+	data, err := retrieveS3Object(f.ctx, f.reader.getObjectClient, f.reader.s3Bucket, obj.key)
+	if err != nil {
+		return err
+	}
+	f.reader.logger.Debug("Retrieved telemetry", zap.String("key", obj.key))
+
+	if !f.ordered {
+		return f.callback(f.ctx, obj.key, data)
+	}
+
+	f.mu.Lock()
+	for f.delivered != obj.seq && f.err == nil {
+		f.turn.Wait()
+	}
+	err = f.err
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = f.callback(f.ctx, obj.key, data)
+
+	f.mu.Lock()
+	f.delivered++
+	f.turn.Broadcast()
+	f.mu.Unlock()
+	return err
+}
+
+func (f *objectFetcher) done(obj s3Object, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err != nil {
+		if f.err == nil {
+			f.err = err
+			f.failed = obj.partition
+			f.cancel()
+			f.turn.Broadcast()
+		}
+		return
+	}
+
+	f.processed[obj.seq] = obj
+	last, advanced := s3Object{}, false
+	for {
+		next, ok := f.processed[f.lowWater]
+		if !ok {
+			break
+		}
+		delete(f.processed, f.lowWater)
+		f.lowWater++
+		last, advanced = next, true
+	}
+	if advanced {
+		f.reader.saveCheckpoint(context.WithoutCancel(f.ctx), last)
+	}
+}
+
+// wait waits for the submitted objects to be processed. It returns the first error met
+// and the partition of the object which failed.
+func (f *objectFetcher) wait() (time.Time, error) {
+	f.wg.Wait()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.failed, f.err
+}
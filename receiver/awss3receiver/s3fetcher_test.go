@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeBucket serves three minute partitions of three objects each, honoring the
+// prefix and the start after parameters of the listings.
+type fakeBucket struct {
+	keys []string
+	// delay is applied before returning the content of an object.
+	delay func(index int) time.Duration
+	// fail makes the retrieval of an object fail.
+	fail func(index int) bool
+
+	mu       sync.Mutex
+	listings []s3.ListObjectsV2Input
+}
+
+func newFakeBucket() *fakeBucket {
+	b := &fakeBucket{}
+	reader := b.reader(OrderingKey, 1)
+	for partition := range 3 {
+		prefix := reader.getObjectPrefixForTime(testTime.Add(time.Duration(partition)*time.Minute), "traces")
+		for i := range 3 {
+			b.keys = append(b.keys, fmt.Sprintf("%s%d", prefix, i))
+		}
+	}
+	return b
+}
+
+func (b *fakeBucket) reader(ordering string, maxConcurrency int) *s3TimeBasedReader {
+	return &s3TimeBasedReader{
+		listObjectsClient: mockListObjectsAPI(b.list),
+		getObjectClient:   mockGetObjectAPI(b.get),
+		logger:            zap.NewNop(),
+		s3Bucket:          "bucket",
+		s3Partition:       S3PartitionMinute,
+		startTime:         testTime,
+		endTime:           testTime.Add(3 * time.Minute),
+		maxConcurrency:    maxConcurrency,
+		ordering:          ordering,
+	}
+}
+
+func (b *fakeBucket) list(params *s3.ListObjectsV2Input) ListObjectsV2Pager {
+	b.mu.Lock()
+	b.listings = append(b.listings, *params)
+	b.mu.Unlock()
+
+	var contents []types.Object
+	for _, key := range b.keys {
+		if strings.HasPrefix(key, *params.Prefix) && (params.StartAfter == nil || key > *params.StartAfter) {
+			contents = append(contents, types.Object{Key: &key})
+		}
+	}
+	return &mockListObjectsV2Pager{Pages: []*s3.ListObjectsV2Output{{Contents: contents}}}
+}
+
+func (b *fakeBucket) get(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	index := b.index(*params.Key)
+	if b.delay != nil {
+		time.Sleep(b.delay(index))
+	}
+	if b.fail != nil && b.fail(index) {
+		return nil, fmt.Errorf("failed to get %s", *params.Key)
+	}
+	return &s3.GetObjectOutput{
+		Body: io.NopCloser(bytes.NewReader([]byte(*params.Key))),
+	}, nil
+}
+
+func (b *fakeBucket) index(key string) int {
+	for i, k := range b.keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// recorder records the keys passed to the callback.
+type recorder struct {
+	mu       sync.Mutex
+	keys     []string
+	inFlight atomic.Int32
+	maxInUse atomic.Int32
+	pause    time.Duration
+}
+
+func (r *recorder) callback(_ context.Context, key string, data []byte) error {
+	if key != string(data) {
+		return errors.New("unexpected content")
+	}
+	inFlight := r.inFlight.Add(1)
+	defer r.inFlight.Add(-1)
+	for {
+		maxInUse := r.maxInUse.Load()
+		if inFlight <= maxInUse || r.maxInUse.CompareAndSwap(maxInUse, inFlight) {
+			break
+		}
+	}
+	time.Sleep(r.pause)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = append(r.keys, key)
+	return nil
+}
+
+func (r *recorder) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.keys...)
+}
+
+func Test_readAll_OrderingKey(t *testing.T) {
+	bucket := newFakeBucket()
+	// The last objects are retrieved first.
+	bucket.delay = func(index int) time.Duration {
+		return time.Duration(len(bucket.keys)-index) * time.Millisecond
+	}
+	rec := &recorder{}
+
+	require.NoError(t, bucket.reader(OrderingKey, 4).readAll(t.Context(), "traces", rec.callback))
+	assert.Equal(t, bucket.keys, rec.received())
+	assert.Equal(t, int32(1), rec.maxInUse.Load())
+}
+
+func Test_readAll_OrderingPartition(t *testing.T) {
+	bucket := newFakeBucket()
+	bucket.delay = func(index int) time.Duration {
+		return time.Duration(len(bucket.keys)-index) * time.Millisecond
+	}
+	rec := &recorder{}
+
+	require.NoError(t, bucket.reader(OrderingPartition, 4).readAll(t.Context(), "traces", rec.callback))
+	received := rec.received()
+	require.ElementsMatch(t, bucket.keys, received)
+	// The objects of a partition are all processed before the next partition.
+	for partition := range 3 {
+		assert.ElementsMatch(t, bucket.keys[partition*3:partition*3+3], received[partition*3:partition*3+3])
+	}
+}
+
+func Test_readAll_OrderingNone(t *testing.T) {
+	bucket := newFakeBucket()
+	rec := &recorder{pause: 20 * time.Millisecond}
+
+	require.NoError(t, bucket.reader(OrderingNone, 3).readAll(t.Context(), "traces", rec.callback))
+	assert.ElementsMatch(t, bucket.keys, rec.received())
+	assert.Greater(t, rec.maxInUse.Load(), int32(1))
+	assert.LessOrEqual(t, rec.maxInUse.Load(), int32(3))
+}
+
+func Test_readAll_ConcurrentError(t *testing.T) {
+	bucket := newFakeBucket()
+	bucket.fail = func(index int) bool {
+		return index == 4
+	}
+	notifier := mockNotifier{}
+	reader := bucket.reader(OrderingNone, 4)
+	reader.notifier = &notifier
+	rec := &recorder{}
+
+	err := reader.readAll(t.Context(), "traces", rec.callback)
+	require.EqualError(t, err, "failed to get "+bucket.keys[4])
+	assert.NotContains(t, rec.received(), bucket.keys[4])
+
+	last := notifier.messages[len(notifier.messages)-1]
+	assert.Equal(t, IngestStatusFailed, last.IngestStatus)
+	assert.Equal(t, testTime.Add(time.Minute), last.IngestTime)
+	assert.Equal(t, "failed to get "+bucket.keys[4], last.FailureMessage)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.uber.org/zap"
 )
 
@@ -25,6 +26,9 @@ type s3TimeBasedReader struct {
 	startTime         time.Time
 	endTime           time.Time
 	notifier          statusNotifier
+	maxConcurrency    int
+	ordering          string
+	storageClient     storage.Client
 }
 
 func newS3TimeBasedReader(ctx context.Context, notifier statusNotifier, logger *zap.Logger, cfg *Config) (*s3TimeBasedReader, error) {
@@ -55,9 +59,16 @@ func newS3TimeBasedReader(ctx context.Context, notifier statusNotifier, logger *
 		startTime:         startTime,
 		endTime:           endTime,
 		notifier:          notifier,
+		maxConcurrency:    cfg.MaxConcurrency,
+		ordering:          cfg.Ordering,
 	}, nil
 }
 
+// setStorageClient implements the checkpointer interface
+func (s3Reader *s3TimeBasedReader) setStorageClient(client storage.Client) {
+	s3Reader.storageClient = client
+}
+
 // readAll implements the s3Reader interface
 func (s3Reader *s3TimeBasedReader) readAll(ctx context.Context, telemetryType string, dataCallback s3ObjectCallback) error {
 	var timeStep time.Duration
@@ -66,8 +77,27 @@ func (s3Reader *s3TimeBasedReader) readAll(ctx context.Context, telemetryType st
 	} else {
 		timeStep = time.Minute
 	}
+	cp, err := s3Reader.loadCheckpoint(ctx)
+	if err != nil {
+		return s3Reader.readFailed(ctx, telemetryType, nil, s3Reader.startTime, err)
+	}
+	if cp != nil {
+		s3Reader.logger.Info("Resuming reading telemetry from checkpoint", zap.Time("time", cp.Partition), zap.String("key", cp.Key))
+	}
+	fetcher := newObjectFetcher(ctx, s3Reader, dataCallback)
+	defer fetcher.cancel()
+
 	s3Reader.logger.Info("Start reading telemetry", zap.Time("start_time", s3Reader.startTime), zap.Time("end_time", s3Reader.endTime))
 	for currentTime := s3Reader.startTime; currentTime.Before(s3Reader.endTime); currentTime = currentTime.Add(timeStep) {
+		startAfter := ""
+		if cp != nil {
+			if currentTime.Before(cp.Partition) {
+				continue
+			}
+			if currentTime.Equal(cp.Partition) {
+				startAfter = cp.Key
+			}
+		}
 		s3Reader.sendStatus(ctx, statusNotification{
 			TelemetryType: telemetryType,
 			IngestStatus:  IngestStatusIngesting,
@@ -78,32 +108,22 @@ func (s3Reader *s3TimeBasedReader) readAll(ctx context.Context, telemetryType st
 
 		select {
 		case <-ctx.Done():
-			s3Reader.sendStatus(ctx, statusNotification{
-				TelemetryType:  telemetryType,
-				IngestStatus:   IngestStatusFailed,
-				StartTime:      s3Reader.startTime,
-				EndTime:        s3Reader.endTime,
-				IngestTime:     currentTime,
-				FailureMessage: ctx.Err().Error(),
-			})
 			s3Reader.logger.Error("Context cancelled, stopping reading telemetry", zap.Time("time", currentTime))
-			return ctx.Err()
+			return s3Reader.readFailed(ctx, telemetryType, fetcher, currentTime, ctx.Err())
 		default:
 			s3Reader.logger.Info("Reading telemetry", zap.Time("time", currentTime))
-			if err := s3Reader.readTelemetryForTime(ctx, currentTime, telemetryType, dataCallback); err != nil {
-				s3Reader.sendStatus(ctx, statusNotification{
-					TelemetryType:  telemetryType,
-					IngestStatus:   IngestStatusFailed,
-					StartTime:      s3Reader.startTime,
-					EndTime:        s3Reader.endTime,
-					IngestTime:     currentTime,
-					FailureMessage: err.Error(),
-				})
-				s3Reader.logger.Error("Error reading telemetry", zap.Error(err), zap.Time("time", currentTime))
-				return err
+			err := s3Reader.readTelemetryForTime(ctx, currentTime, telemetryType, startAfter, fetcher)
+			if err == nil && s3Reader.ordering == OrderingPartition {
+				_, err = fetcher.wait()
+			}
+			if err != nil {
+				return s3Reader.readFailed(ctx, telemetryType, fetcher, currentTime, err)
 			}
 		}
 	}
+	if failed, err := fetcher.wait(); err != nil {
+		return s3Reader.readFailed(ctx, telemetryType, fetcher, failed, err)
+	}
 	s3Reader.sendStatus(ctx, statusNotification{
 		TelemetryType: telemetryType,
 		IngestStatus:  IngestStatusCompleted,
@@ -115,12 +135,37 @@ func (s3Reader *s3TimeBasedReader) readAll(ctx context.Context, telemetryType st
 	return nil
 }
 
-func (s3Reader *s3TimeBasedReader) readTelemetryForTime(ctx context.Context, t time.Time, telemetryType string, dataCallback s3ObjectCallback) error {
+// readFailed waits for the objects being processed and reports the failure of the read.
+// The first error met while processing the objects takes precedence over err.
+func (s3Reader *s3TimeBasedReader) readFailed(ctx context.Context, telemetryType string, fetcher *objectFetcher, ingestTime time.Time, err error) error {
+	if fetcher != nil {
+		if failed, fetchErr := fetcher.wait(); fetchErr != nil {
+			ingestTime, err = failed, fetchErr
+		}
+	}
+	s3Reader.sendStatus(ctx, statusNotification{
+		TelemetryType:  telemetryType,
+		IngestStatus:   IngestStatusFailed,
+		StartTime:      s3Reader.startTime,
+		EndTime:        s3Reader.endTime,
+		IngestTime:     ingestTime,
+		FailureMessage: err.Error(),
+	})
+	s3Reader.logger.Error("Error reading telemetry", zap.Error(err), zap.Time("time", ingestTime))
+	return err
+}
+
+// readTelemetryForTime lists the objects of the partition starting at t and submits them to the fetcher.
+// Only the objects whose key comes after startAfter are listed, if it is set.
+func (s3Reader *s3TimeBasedReader) readTelemetryForTime(ctx context.Context, t time.Time, telemetryType, startAfter string, fetcher *objectFetcher) error {
 	params := &s3.ListObjectsV2Input{
 		Bucket: &s3Reader.s3Bucket,
 	}
 	prefix := s3Reader.getObjectPrefixForTime(t, telemetryType)
 	params.Prefix = &prefix
+	if startAfter != "" {
+		params.StartAfter = &startAfter
+	}
 	s3Reader.logger.Debug("Finding telemetry with prefix", zap.String("prefix", prefix))
 	p := s3Reader.listObjectsClient.NewListObjectsV2Paginator(params)
 
@@ -134,12 +179,7 @@ func (s3Reader *s3TimeBasedReader) readTelemetryForTime(ctx context.Context, t t
 			s3Reader.logger.Info("No telemetry found for time", zap.String("prefix", prefix), zap.Time("time", t))
 		} else {
 			for _, obj := range page.Contents {
-				data, err := retrieveS3Object(ctx, s3Reader.getObjectClient, s3Reader.s3Bucket, *obj.Key)
-				if err != nil {
-					return err
-				}
-				s3Reader.logger.Debug("Retrieved telemetry", zap.String("key", *obj.Key))
-				if err := dataCallback(ctx, *obj.Key, data); err != nil {
+				if err := fetcher.submit(t, *obj.Key); err != nil {
 					return err
 				}
 			}
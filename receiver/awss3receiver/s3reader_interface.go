@@ -5,6 +5,8 @@ package awss3receiver // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"context"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 )
 
 // s3ObjectCallback is a function that processes a single S3 object content
@@ -15,3 +17,8 @@ type s3Reader interface {
 	// readAll processes all S3 objects matching specified criteria and passes content to callback
 	readAll(ctx context.Context, telemetryType string, callback s3ObjectCallback) error
 }
+
+// checkpointer is implemented by the readers able to checkpoint their progress in a storage extension
+type checkpointer interface {
+	setStorageClient(client storage.Client)
+}
@@ -133,6 +133,17 @@ func (m *mockListObjectsV2Pager) NextPage(_ context.Context, _ ...func(*s3.Optio
 	return output, nil
 }
 
+// readTelemetryForTime reads the partition of testTime and waits for its objects to be processed.
+func readTelemetryForTime(t *testing.T, reader *s3TimeBasedReader, callback s3ObjectCallback) error {
+	fetcher := newObjectFetcher(t.Context(), reader, callback)
+	defer fetcher.cancel()
+	if err := reader.readTelemetryForTime(t.Context(), testTime, "traces", "", fetcher); err != nil {
+		return err
+	}
+	_, err := fetcher.wait()
+	return err
+}
+
 func Test_readTelemetryForTime(t *testing.T) {
 	testKey1 := "year=2021/month=02/day=01/hour=17/minute=32/traces_1"
 	testKey2 := "year=2021/month=02/day=01/hour=17/minute=32/traces_2"
@@ -180,7 +191,7 @@ func Test_readTelemetryForTime(t *testing.T) {
 
 	dataCallbackKeys := make([]string, 0)
 
-	err := reader.readTelemetryForTime(t.Context(), testTime, "traces", func(_ context.Context, key string, data []byte) error {
+	err := readTelemetryForTime(t, &reader, func(_ context.Context, key string, data []byte) error {
 		t.Helper()
 		require.Equal(t, "this is the body of the object", string(data))
 		dataCallbackKeys = append(dataCallbackKeys, key)
@@ -227,7 +238,7 @@ func Test_readTelemetryForTime_GetObjectError(t *testing.T) {
 		endTime:     testTime.Add(time.Minute),
 	}
 
-	err := reader.readTelemetryForTime(t.Context(), testTime, "traces", func(_ context.Context, _ string, _ []byte) error {
+	err := readTelemetryForTime(t, &reader, func(_ context.Context, _ string, _ []byte) error {
 		t.Helper()
 		t.Fail()
 		return nil
@@ -262,7 +273,7 @@ func Test_readTelemetryForTime_ListObjectsNoResults(t *testing.T) {
 		endTime:     testTime.Add(time.Minute),
 	}
 
-	err := reader.readTelemetryForTime(t.Context(), testTime, "traces", func(_ context.Context, _ string, _ []byte) error {
+	err := readTelemetryForTime(t, &reader, func(_ context.Context, _ string, _ []byte) error {
 		t.Helper()
 		t.Fail()
 		return nil
@@ -309,7 +320,7 @@ func Test_readTelemetryForTime_NextPageError(t *testing.T) {
 		endTime:     testTime.Add(time.Minute),
 	}
 
-	err := reader.readTelemetryForTime(t.Context(), testTime, "traces", func(_ context.Context, _ string, _ []byte) error {
+	err := readTelemetryForTime(t, &reader, func(_ context.Context, _ string, _ []byte) error {
 		t.Helper()
 		t.Fail()
 		return nil
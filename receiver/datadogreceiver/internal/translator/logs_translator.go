@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/datadogreceiver/internal/translator"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+)
+
+const (
+	// The reserved attributes of the logs intake, see https://docs.datadoghq.com/api/latest/logs/#send-logs
+	logKeyMessage   = "message"
+	logKeyStatus    = "status"
+	logKeyTimestamp = "timestamp"
+	logKeyHostname  = "hostname"
+	logKeyService   = "service"
+	logKeySource    = "ddsource"
+	logKeyTags      = "ddtags"
+
+	// attributeDatadogLogSource holds the integration which produced the log, such as nginx.
+	attributeDatadogLogSource = "datadog.log.source"
+)
+
+// datadogStatusToSeverity maps the statuses of Datadog logs to severities, the statuses
+// are the syslog severities with a few aliases.
+var datadogStatusToSeverity = map[string]plog.SeverityNumber{
+	"trace":     plog.SeverityNumberTrace,
+	"debug":     plog.SeverityNumberDebug,
+	"info":      plog.SeverityNumberInfo,
+	"ok":        plog.SeverityNumberInfo,
+	"notice":    plog.SeverityNumberInfo2,
+	"warn":      plog.SeverityNumberWarn,
+	"warning":   plog.SeverityNumberWarn,
+	"error":     plog.SeverityNumberError,
+	"critical":  plog.SeverityNumberFatal,
+	"alert":     plog.SeverityNumberFatal2,
+	"emergency": plog.SeverityNumberFatal3,
+}
+
+// Log is a log of the logs intake. The reserved attributes are translated to their
+// OpenTelemetry equivalent, the others are kept as log attributes.
+type Log map[string]any
+
+type LogsTranslator struct {
+	buildInfo  component.BuildInfo
+	stringPool *StringPool
+}
+
+func NewLogsTranslator(buildInfo component.BuildInfo) *LogsTranslator {
+	return &LogsTranslator{
+		buildInfo:  buildInfo,
+		stringPool: newStringPool(),
+	}
+}
+
+// HandleLogsPayload decodes the logs of a request to the logs intake, either a JSON
+// array of logs, as sent by the Datadog Agent, or a single JSON log.
+func (*LogsTranslator) HandleLogsPayload(req *http.Request) ([]Log, error) {
+	buf := GetBuffer()
+	defer PutBuffer(buf)
+	if _, err := io.Copy(buf, req.Body); err != nil {
+		return nil, err
+	}
+
+	payload := bytes.TrimSpace(buf.Bytes())
+	if len(payload) == 0 {
+		return nil, errors.New("empty logs payload")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	// Keep the precision of large integers, such as IDs.
+	decoder.UseNumber()
+
+	var logs []Log
+	if payload[0] == '[' {
+		if err := decoder.Decode(&logs); err != nil {
+			return nil, err
+		}
+		return logs, nil
+	}
+
+	var log Log
+	if err := decoder.Decode(&log); err != nil {
+		return nil, err
+	}
+	return []Log{log}, nil
+}
+
+// TranslateLogs translates logs of the logs intake, grouping them by resource.
+func (lt *LogsTranslator) TranslateLogs(logs []Log) plog.Logs {
+	result := plog.NewLogs()
+	scopeLogs := map[identity.Resource]plog.ScopeLogs{}
+	observed := pcommon.NewTimestampFromTime(time.Now())
+
+	for _, log := range logs {
+		hostname, _ := log[logKeyHostname].(string)
+		tags, _ := log[logKeyTags].(string)
+		attrs := tagsToAttributes(splitTags(tags), hostname, lt.stringPool)
+
+		resource := pcommon.NewResource()
+		attrs.resource.MoveTo(resource.Attributes())
+		if service, ok := log[logKeyService].(string); ok && service != "" {
+			resource.Attributes().PutStr(string(semconv.ServiceNameKey), lt.stringPool.Intern(service))
+		}
+		if source, ok := log[logKeySource].(string); ok && source != "" {
+			resource.Attributes().PutStr(attributeDatadogLogSource, lt.stringPool.Intern(source))
+		}
+
+		resourceID := identity.OfResource(resource)
+		sl, ok := scopeLogs[resourceID]
+		if !ok {
+			rl := result.ResourceLogs().AppendEmpty()
+			resource.MoveTo(rl.Resource())
+			sl = rl.ScopeLogs().AppendEmpty()
+			sl.Scope().SetName("github.com/open-telemetry/opentelemetry-collector-contrib/receiver/datadogreceiver/internal/translator")
+			sl.Scope().SetVersion(lt.buildInfo.Version)
+			scopeLogs[resourceID] = sl
+		}
+
+		record := sl.LogRecords().AppendEmpty()
+		record.SetObservedTimestamp(observed)
+		attrs.dp.MoveTo(record.Attributes())
+		for key, value := range log {
+			switch key {
+			case logKeyHostname, logKeyTags, logKeyService, logKeySource:
+			case logKeyMessage:
+				record.Body().SetStr(fmt.Sprint(value))
+			case logKeyStatus:
+				status := fmt.Sprint(value)
+				record.SetSeverityText(status)
+				record.SetSeverityNumber(datadogStatusToSeverity[strings.ToLower(status)])
+			case logKeyTimestamp:
+				if ts, ok := parseLogTimestamp(value); ok {
+					record.SetTimestamp(ts)
+				} else {
+					putValue(record.Attributes().PutEmpty(key), value)
+				}
+			default:
+				putValue(record.Attributes().PutEmpty(lt.stringPool.Intern(translateDatadogKeyToOTel(key))), value)
+			}
+		}
+	}
+	return result
+}
+
+// splitTags splits the comma separated tags of a log.
+func splitTags(tags string) []string {
+	if tags == "" {
+		return nil
+	}
+	split := strings.Split(tags, ",")
+	for i, tag := range split {
+		split[i] = strings.TrimSpace(tag)
+	}
+	return split
+}
+
+// parseLogTimestamp parses a timestamp in milliseconds since the epoch, as sent by the
+// Datadog Agent, or in the RFC 3339 format.
+func parseLogTimestamp(value any) (pcommon.Timestamp, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		ms, err := v.Int64()
+		if err != nil {
+			return 0, false
+		}
+		return pcommon.NewTimestampFromTime(time.UnixMilli(ms)), true
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return 0, false
+		}
+		return pcommon.NewTimestampFromTime(t), true
+	}
+	return 0, false
+}
+
+// putValue sets a decoded JSON value into dest.
+func putValue(dest pcommon.Value, value any) {
+	switch v := value.(type) {
+	case nil:
+	case string:
+		dest.SetStr(v)
+	case bool:
+		dest.SetBool(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			dest.SetInt(i)
+		} else if f, err := v.Float64(); err == nil {
+			dest.SetDouble(f)
+		} else {
+			dest.SetStr(v.String())
+		}
+	case []any:
+		s := dest.SetEmptySlice()
+		s.EnsureCapacity(len(v))
+		for _, item := range v {
+			putValue(s.AppendEmpty(), item)
+		}
+	case map[string]any:
+		m := dest.SetEmptyMap()
+		m.EnsureCapacity(len(v))
+		for key, item := range v {
+			putValue(m.PutEmpty(key), item)
+		}
+	default:
+		dest.SetStr(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func createLogsTranslator() *LogsTranslator {
+	return NewLogsTranslator(component.BuildInfo{
+		Command:     "otelcol",
+		Description: "OpenTelemetry Collector",
+		Version:     "latest",
+	})
+}
+
+func handleLogsPayload(t *testing.T, payload string) ([]Log, error) {
+	req, err := http.NewRequest(http.MethodPost, "/api/v2/logs", strings.NewReader(payload))
+	require.NoError(t, err)
+	return createLogsTranslator().HandleLogsPayload(req)
+}
+
+func TestHandleLogsPayload(t *testing.T) {
+	logs, err := handleLogsPayload(t, `[{"message": "first"}, {"message": "second", "dd.trace_id": 12345678901234567890}]`)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.Equal(t, "second", logs[1]["message"])
+	// The large integers aren't rounded.
+	assert.Equal(t, json.Number("12345678901234567890"), logs[1]["dd.trace_id"])
+
+	logs, err = handleLogsPayload(t, ` {"message": "single"}`)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "single", logs[0]["message"])
+
+	_, err = handleLogsPayload(t, "")
+	assert.EqualError(t, err, "empty logs payload")
+
+	_, err = handleLogsPayload(t, `[{"message": }]`)
+	assert.Error(t, err)
+}
+
+func TestTranslateLogs(t *testing.T) {
+	logs, err := handleLogsPayload(t, `[
+		{
+			"message": "GET /checkout 200",
+			"status": "warning",
+			"timestamp": 1700000000123,
+			"hostname": "hosta",
+			"service": "checkout",
+			"ddsource": "nginx",
+			"ddtags": "env:prod, version:1.2.3,team:shop",
+			"http.method": "GET",
+			"duration": 1.5,
+			"network": {"client": {"ip": "10.0.0.1"}},
+			"retries": [1, 2]
+		},
+		{
+			"message": "payment accepted",
+			"status": "INFO",
+			"timestamp": "2024-01-01T00:00:00.5Z",
+			"hostname": "hosta",
+			"service": "checkout",
+			"ddsource": "nginx",
+			"ddtags": "env:prod,version:1.2.3,team:payments"
+		},
+		{
+			"message": "disk full",
+			"status": "emergency",
+			"hostname": "hostb"
+		}
+	]`)
+	require.NoError(t, err)
+
+	result := createLogsTranslator().TranslateLogs(logs)
+	require.Equal(t, 3, result.LogRecordCount())
+	// The logs of the same host, service and source are grouped.
+	require.Equal(t, 2, result.ResourceLogs().Len())
+
+	rl := result.ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{
+		"host.name":                   "hosta",
+		"service.name":                "checkout",
+		"service.version":             "1.2.3",
+		"deployment.environment.name": "prod",
+		"datadog.log.source":          "nginx",
+	}, rl.Resource().Attributes().AsRaw())
+	scope := rl.ScopeLogs().At(0).Scope()
+	assert.Equal(t, "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/datadogreceiver/internal/translator", scope.Name())
+	assert.Equal(t, "latest", scope.Version())
+
+	records := rl.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+
+	first := records.At(0)
+	assert.Equal(t, "GET /checkout 200", first.Body().Str())
+	assert.Equal(t, "warning", first.SeverityText())
+	assert.Equal(t, plog.SeverityNumberWarn, first.SeverityNumber())
+	assert.Equal(t, time.UnixMilli(1700000000123).UTC(), first.Timestamp().AsTime())
+	assert.NotZero(t, first.ObservedTimestamp())
+	assert.Equal(t, map[string]any{
+		"team":                "shop",
+		"http.request.method": "GET",
+		"duration":            1.5,
+		"network":             map[string]any{"client": map[string]any{"ip": "10.0.0.1"}},
+		"retries":             []any{int64(1), int64(2)},
+	}, first.Attributes().AsRaw())
+
+	second := records.At(1)
+	assert.Equal(t, plog.SeverityNumberInfo, second.SeverityNumber())
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 500_000_000, time.UTC), second.Timestamp().AsTime())
+	assert.Equal(t, map[string]any{"team": "payments"}, second.Attributes().AsRaw())
+
+	other := result.ResourceLogs().At(1)
+	assert.Equal(t, map[string]any{"host.name": "hostb"}, other.Resource().Attributes().AsRaw())
+	third := other.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, plog.SeverityNumberFatal3, third.SeverityNumber())
+	assert.Zero(t, third.Timestamp())
+}
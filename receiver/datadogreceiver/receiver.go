@@ -40,9 +40,11 @@ type datadogReceiver struct {
 
 	nextTracesConsumer  consumer.Traces
 	nextMetricsConsumer consumer.Metrics
+	nextLogsConsumer    consumer.Logs
 
 	metricsTranslator *translator.MetricsTranslator
 	statsTranslator   *translator.StatsTranslator
+	logsTranslator    *translator.LogsTranslator
 
 	server    *http.Server
 	tReceiver *receiverhelper.ObsReport
@@ -138,6 +140,13 @@ func (ddr *datadogReceiver) getEndpoints() []endpoint {
 		}...)
 	}
 
+	if ddr.nextLogsConsumer != nil {
+		endpoints = append(endpoints, endpoint{
+			Pattern: "/api/v2/logs",
+			Handler: ddr.handleLogs,
+		})
+	}
+
 	infoResponse, _ := ddr.buildInfoResponse(endpoints)
 
 	endpoints = append(endpoints, endpoint{
@@ -196,6 +205,7 @@ func newDataDogReceiver(ctx context.Context, config *Config, params receiver.Set
 		tReceiver:         instance,
 		metricsTranslator: translator.NewMetricsTranslator(params.BuildInfo),
 		statsTranslator:   translator.NewStatsTranslator(),
+		logsTranslator:    translator.NewLogsTranslator(params.BuildInfo),
 		traceIDCache:      cache,
 	}, nil
 }
@@ -526,6 +536,38 @@ func (ddr *datadogReceiver) handleStats(w http.ResponseWriter, req *http.Request
 	_, _ = w.Write([]byte("OK"))
 }
 
+// handleLogs handles the logs intake endpoint https://docs.datadoghq.com/api/latest/logs/#send-logs
+func (ddr *datadogReceiver) handleLogs(w http.ResponseWriter, req *http.Request) {
+	obsCtx := ddr.tReceiver.StartLogsOp(req.Context())
+	var err error
+	var logsCount int
+	defer func(logsCount *int) {
+		ddr.tReceiver.EndLogsOp(obsCtx, "datadog", *logsCount, err)
+	}(&logsCount)
+
+	var ddLogs []translator.Log
+	ddLogs, err = ddr.logsTranslator.HandleLogsPayload(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		ddr.params.Logger.Error(err.Error())
+		return
+	}
+
+	logs := ddr.logsTranslator.TranslateLogs(ddLogs)
+	logsCount = logs.LogRecordCount()
+
+	err = ddr.nextLogsConsumer.ConsumeLogs(obsCtx, logs)
+	if err != nil {
+		errorutil.HTTPError(w, err)
+		ddr.params.Logger.Error("logs consumer errored out", zap.Error(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_, _ = w.Write([]byte("{}"))
+}
+
 func createIntakeReverseProxyDirector(site, key string) func(*http.Request) {
 	host := fmt.Sprintf("api.%s", site)
 	query := fmt.Sprintf("api_key=%s", key)
@@ -5,6 +5,7 @@ package datadogreceiver
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
@@ -22,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.uber.org/multierr"
@@ -198,6 +200,7 @@ func TestDatadogInfoEndpoint(t *testing.T) {
 		name            string
 		tracesConsumer  consumer.Traces
 		metricsConsumer consumer.Metrics
+		logsConsumer    consumer.Logs
 
 		expectContent string
 	}{
@@ -258,6 +261,21 @@ func TestDatadogInfoEndpoint(t *testing.T) {
 	"span_meta_structs": false,
 	"long_running_spans": false,
 	"config": null
+}`,
+		},
+		{
+			name:         "Logs consumer only",
+			logsConsumer: consumertest.NewNop(),
+			expectContent: `{
+	"version": "datadogreceiver-otelcol-latest",
+	"endpoints": [
+		"/",
+		"/api/v2/logs"
+	],
+	"client_drop_p0s": false,
+	"span_meta_structs": false,
+	"long_running_spans": false,
+	"config": null
 }`,
 		},
 		{
@@ -305,6 +323,7 @@ func TestDatadogInfoEndpoint(t *testing.T) {
 
 			dd.(*datadogReceiver).nextTracesConsumer = tc.tracesConsumer
 			dd.(*datadogReceiver).nextMetricsConsumer = tc.metricsConsumer
+			dd.(*datadogReceiver).nextLogsConsumer = tc.logsConsumer
 
 			require.NoError(t, dd.Start(ctx, componenttest.NewNopHost()))
 			defer func() {
@@ -806,3 +825,103 @@ func TestDatadogServices_EndToEnd(t *testing.T) {
 	hostName, _ := got.ResourceMetrics().At(0).Resource().Attributes().Get("host.name")
 	assert.Equal(t, "hosta", hostName.AsString())
 }
+
+func TestDatadogLogs_EndToEnd(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0" // Using a randomly assigned address
+	sink := new(consumertest.LogsSink)
+
+	dd, err := newDataDogReceiver(
+		t.Context(),
+		cfg,
+		receivertest.NewNopSettings(metadata.Type),
+	)
+	require.NoError(t, err, "Must not error when creating receiver")
+	dd.(*datadogReceiver).nextLogsConsumer = sink
+
+	require.NoError(t, dd.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, dd.Shutdown(t.Context()))
+	}()
+
+	logsPayload := []byte(`[
+		{
+			"message": "GET /checkout 200",
+			"status": "info",
+			"timestamp": 1700000000000,
+			"hostname": "hosta",
+			"service": "checkout",
+			"ddsource": "nginx",
+			"ddtags": "env:prod,kube_namespace:shop"
+		}
+	]`)
+
+	// The Datadog Agent compresses the logs payloads.
+	var compressed bytes.Buffer
+	gw := gzip.NewWriter(&compressed)
+	_, err = gw.Write(logsPayload)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("http://%s/api/v2/logs", dd.(*datadogReceiver).address),
+		&compressed,
+	)
+	require.NoError(t, err, "Must not error when creating request")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Must not error performing request")
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, multierr.Combine(err, resp.Body.Close()), "Must not error when reading body")
+	require.JSONEq(t, `{}`, string(body))
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	logs := sink.AllLogs()
+	require.Len(t, logs, 1)
+	require.Equal(t, 1, logs[0].LogRecordCount())
+	rl := logs[0].ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{
+		"host.name":                   "hosta",
+		"service.name":                "checkout",
+		"datadog.log.source":          "nginx",
+		"deployment.environment.name": "prod",
+		"k8s.namespace.name":          "shop",
+	}, rl.Resource().Attributes().AsRaw())
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "GET /checkout 200", lr.Body().Str())
+	assert.Equal(t, plog.SeverityNumberInfo, lr.SeverityNumber())
+	assert.Equal(t, int64(1700000000000), lr.Timestamp().AsTime().UnixMilli())
+}
+
+func TestDatadogLogs_InvalidPayload(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0" // Using a randomly assigned address
+	sink := new(consumertest.LogsSink)
+
+	dd, err := newDataDogReceiver(
+		t.Context(),
+		cfg,
+		receivertest.NewNopSettings(metadata.Type),
+	)
+	require.NoError(t, err, "Must not error when creating receiver")
+	dd.(*datadogReceiver).nextLogsConsumer = sink
+
+	require.NoError(t, dd.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, dd.Shutdown(t.Context()))
+	}()
+
+	resp, err := http.Post(
+		fmt.Sprintf("http://%s/api/v2/logs", dd.(*datadogReceiver).address),
+		"application/json",
+		strings.NewReader(`[{"message": `),
+	)
+	require.NoError(t, err, "Must not error performing request")
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Empty(t, sink.AllLogs())
+}
@@ -0,0 +1,287 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/value"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// quantileLabel is the label holding the quantile of a summary series.
+const quantileLabel = "quantile"
+
+// classicSeriesKind tells which part of a classic histogram or summary a series holds.
+type classicSeriesKind int
+
+const (
+	// classicBound is a histogram bucket ("le" label) or a summary quantile ("quantile" label).
+	classicBound classicSeriesKind = iota
+	classicSum
+	classicCount
+)
+
+// classicKey identifies a single classic histogram or summary data point.
+// All series composing the data point share the metric, the labels (apart from
+// the metric name suffix and the "le"/"quantile" label) and the sample timestamp.
+type classicKey struct {
+	metricKey  uint64
+	labelsHash uint64
+	timestamp  int64
+}
+
+// classicPoint accumulates the samples of the series composing a classic histogram or summary data point.
+type classicPoint struct {
+	metric         pmetric.Metric
+	ls             labels.Labels
+	timestamp      int64
+	startTimestamp int64
+	// stale is set when any of the series carries a staleness marker, i.e. the series is gone.
+	stale bool
+	// bounds holds the cumulative bucket counts for histograms, keyed by the "le" label value,
+	// or the quantile values for summaries, keyed by the "quantile" label value.
+	bounds   map[float64]float64
+	sum      float64
+	count    float64
+	hasSum   bool
+	hasCount bool
+}
+
+// classicAssembler groups the series of classic histograms and summaries found in a remote-write request.
+// Those metrics are split into several independent series (buckets or quantiles, _sum and _count), so their
+// data points can only be built once all the series of the request were read.
+type classicAssembler struct {
+	logger *zap.Logger
+	points map[classicKey]*classicPoint
+	// order keeps the data points in the order they were first seen, so the output is deterministic.
+	order []classicKey
+	buf   []byte
+}
+
+func newClassicAssembler(logger *zap.Logger) *classicAssembler {
+	return &classicAssembler{
+		logger: logger,
+		points: make(map[classicKey]*classicPoint),
+	}
+}
+
+// add records a sample of a series belonging to a classic histogram or summary metric.
+// The metric must be either a Histogram or a Summary. The timestamps are in milliseconds,
+// a zero startTimestamp means the created timestamp of the series is unknown.
+func (a *classicAssembler) add(metric pmetric.Metric, metricKey uint64, ls labels.Labels, kind classicSeriesKind, startTimestamp, timestamp int64, v float64) error {
+	var labelsHash uint64
+	labelsHash, a.buf = ls.HashWithoutLabels(a.buf, labels.BucketLabel, quantileLabel)
+	key := classicKey{metricKey: metricKey, labelsHash: labelsHash, timestamp: timestamp}
+
+	point, ok := a.points[key]
+	if !ok {
+		point = &classicPoint{
+			metric:    metric,
+			ls:        labels.NewBuilder(ls).Del(labels.BucketLabel, quantileLabel).Labels(),
+			timestamp: timestamp,
+			bounds:    make(map[float64]float64),
+		}
+		a.points[key] = point
+		a.order = append(a.order, key)
+	}
+	if point.startTimestamp == 0 {
+		point.startTimestamp = startTimestamp
+	}
+	if value.IsStaleNaN(v) {
+		point.stale = true
+		return nil
+	}
+
+	switch kind {
+	case classicSum:
+		point.sum = v
+		point.hasSum = true
+	case classicCount:
+		point.count = v
+		point.hasCount = true
+	case classicBound:
+		boundLabel := labels.BucketLabel
+		if metric.Type() == pmetric.MetricTypeSummary {
+			boundLabel = quantileLabel
+		}
+		bound, err := strconv.ParseFloat(ls.Get(boundLabel), 64)
+		if err != nil {
+			return fmt.Errorf("invalid %q label value %q for metric %q: %w", boundLabel, ls.Get(boundLabel), metric.Name(), err)
+		}
+		point.bounds[bound] = v
+	}
+	return nil
+}
+
+// appendDatapoints converts the accumulated samples into data points of their metrics.
+func (a *classicAssembler) appendDatapoints() {
+	for _, key := range a.order {
+		point := a.points[key]
+		switch point.metric.Type() {
+		case pmetric.MetricTypeHistogram:
+			if !point.appendHistogramDatapoint(point.metric.Histogram().DataPoints()) {
+				a.logger.Info("Dropping classic histogram data point with non-monotonic bucket counts",
+					zap.String("timeseries", point.metric.Name()))
+			}
+		case pmetric.MetricTypeSummary:
+			point.appendSummaryDatapoint(point.metric.Summary().DataPoints())
+		}
+	}
+}
+
+// classicDatapoint is implemented by both histogram and summary data points.
+type classicDatapoint interface {
+	SetStartTimestamp(pcommon.Timestamp)
+	SetTimestamp(pcommon.Timestamp)
+	SetFlags(pmetric.DataPointFlags)
+	Attributes() pcommon.Map
+}
+
+// setCommonFields sets the timestamps and the attributes of the data point. Stale points are flagged
+// as having no recorded value, following the OpenTelemetry specification for Prometheus staleness markers.
+// Ref: https://opentelemetry.io/docs/specs/otel/compatibility/prometheus_and_openmetrics/#prometheus-stale-markers
+func (p *classicPoint) setCommonFields(dp classicDatapoint) {
+	dp.SetStartTimestamp(pcommon.Timestamp(p.startTimestamp * int64(time.Millisecond)))
+	dp.SetTimestamp(pcommon.Timestamp(p.timestamp * int64(time.Millisecond)))
+	if p.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	}
+	extractAttributes(p.ls).CopyTo(dp.Attributes())
+}
+
+// appendHistogramDatapoint converts the cumulative bucket counts into an OTLP histogram data point.
+// It returns false, without appending anything, if the cumulative counts are decreasing.
+func (p *classicPoint) appendHistogramDatapoint(datapoints pmetric.HistogramDataPointSlice) bool {
+	if p.stale {
+		p.setCommonFields(datapoints.AppendEmpty())
+		return true
+	}
+
+	bounds := make([]float64, 0, len(p.bounds))
+	for bound := range p.bounds {
+		bounds = append(bounds, bound)
+	}
+	slices.Sort(bounds)
+
+	explicitBounds := make([]float64, 0, len(bounds))
+	bucketCounts := make([]uint64, 0, len(bounds)+1)
+	var previous float64
+	var hasInf bool
+	for _, bound := range bounds {
+		cumulative := p.bounds[bound]
+		if cumulative < previous {
+			return false
+		}
+		if math.IsInf(bound, +1) {
+			hasInf = true
+		} else {
+			explicitBounds = append(explicitBounds, bound)
+		}
+		bucketCounts = append(bucketCounts, uint64(cumulative-previous))
+		previous = cumulative
+	}
+
+	count := previous
+	if p.hasCount {
+		count = p.count
+	}
+	// Without a +Inf bucket the observations above the last bound are only known through the count.
+	if !hasInf {
+		if count < previous {
+			return false
+		}
+		bucketCounts = append(bucketCounts, uint64(count-previous))
+	}
+
+	dp := datapoints.AppendEmpty()
+	p.setCommonFields(dp)
+	dp.SetCount(uint64(count))
+	if p.hasSum {
+		dp.SetSum(p.sum)
+	}
+	if len(p.bounds) > 0 {
+		dp.ExplicitBounds().FromRaw(explicitBounds)
+		dp.BucketCounts().FromRaw(bucketCounts)
+	}
+	return true
+}
+
+// appendSummaryDatapoint converts the quantile values, sum and count into an OTLP summary data point.
+func (p *classicPoint) appendSummaryDatapoint(datapoints pmetric.SummaryDataPointSlice) {
+	if p.stale {
+		p.setCommonFields(datapoints.AppendEmpty())
+		return
+	}
+
+	quantiles := make([]float64, 0, len(p.bounds))
+	for quantile := range p.bounds {
+		quantiles = append(quantiles, quantile)
+	}
+	slices.Sort(quantiles)
+
+	dp := datapoints.AppendEmpty()
+	p.setCommonFields(dp)
+	dp.SetCount(uint64(p.count))
+	dp.SetSum(p.sum)
+	for _, quantile := range quantiles {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(quantile)
+		qv.SetValue(p.bounds[quantile])
+	}
+}
+
+// classicFamilyName returns the metric family name of a classic histogram or summary series,
+// i.e. the series name without the "_bucket", "_sum" or "_count" suffix.
+func classicFamilyName(metricName string, isSummary bool) string {
+	suffixes := []string{"_sum", "_count", "_bucket"}
+	if isSummary {
+		suffixes = suffixes[:2]
+	}
+	for _, suffix := range suffixes {
+		if familyName, found := strings.CutSuffix(metricName, suffix); found {
+			return familyName
+		}
+	}
+	return metricName
+}
+
+// classicKindOf tells which part of a classic histogram or summary the series holds.
+func classicKindOf(metricName, familyName string) classicSeriesKind {
+	switch metricName {
+	case familyName + "_sum":
+		return classicSum
+	case familyName + "_count":
+		return classicCount
+	default:
+		return classicBound
+	}
+}
+
+// setClassicHistogramMetric appends a new cumulative histogram metric, used for classic histograms.
+func setClassicHistogramMetric(scope pmetric.ScopeMetrics, metricName, unit, description string) pmetric.Metric {
+	metric := setMetric(scope, metricName, unit, description)
+	hist := metric.SetEmptyHistogram()
+	hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "histogram")
+	return metric
+}
+
+// setSummaryMetric appends a new summary metric.
+func setSummaryMetric(scope pmetric.ScopeMetrics, metricName, unit, description string) pmetric.Metric {
+	metric := setMetric(scope, metricName, unit, description)
+	metric.SetEmptySummary()
+	metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "summary")
+	return metric
+}
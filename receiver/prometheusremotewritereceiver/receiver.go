@@ -18,6 +18,7 @@ import (
 	promconfig "github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
 	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	promremote "github.com/prometheus/prometheus/storage/remote"
 	"go.opentelemetry.io/collector/component"
@@ -38,6 +39,10 @@ func newRemoteWriteReceiver(settings receiver.Settings, cfg *Config, nextConsume
 	if err != nil {
 		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
 	}
+	mdCache, err := lru.New[string, prompb.MetricMetadata](10000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metadata LRU cache: %w", err)
+	}
 
 	return &prometheusRemoteWriteReceiver{
 		settings:     settings,
@@ -46,7 +51,8 @@ func newRemoteWriteReceiver(settings receiver.Settings, cfg *Config, nextConsume
 		server: &http.Server{
 			ReadTimeout: 60 * time.Second,
 		},
-		rmCache: cache,
+		rmCache:       cache,
+		metadataCache: mdCache,
 	}, nil
 }
 
@@ -59,7 +65,10 @@ type prometheusRemoteWriteReceiver struct {
 	wg     sync.WaitGroup
 
 	rmCache *lru.Cache[uint64, pmetric.ResourceMetrics]
-	obsrecv *receiverhelper.ObsReport
+	// metadataCache holds the Remote-Write v1 metadata keyed by metric family name.
+	// v1 senders transmit metadata separately from the samples, usually in dedicated requests.
+	metadataCache *lru.Cache[string, prompb.MetricMetadata]
+	obsrecv       *receiverhelper.ObsReport
 }
 
 // metricIdentity contains all the components that uniquely identify a metric
@@ -161,11 +170,6 @@ func (prw *prometheusRemoteWriteReceiver) handlePRW(w http.ResponseWriter, req *
 		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
 		return
 	}
-	if msgType != promconfig.RemoteWriteProtoMsgV2 {
-		prw.settings.Logger.Warn("message received with unsupported proto version, rejecting")
-		http.Error(w, "Unsupported proto version", http.StatusUnsupportedMediaType)
-		return
-	}
 
 	// After parsing the content-type header, the next step would be to handle content-encoding.
 	// Luckly confighttp's Server has middleware that already decompress the request body for us.
@@ -177,17 +181,39 @@ func (prw *prometheusRemoteWriteReceiver) handlePRW(w http.ResponseWriter, req *
 		return
 	}
 
-	var prw2Req writev2.Request
-	if err = proto.Unmarshal(body, &prw2Req); err != nil {
-		prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
+	var m pmetric.Metrics
+	switch msgType {
+	case promconfig.RemoteWriteProtoMsgV1:
+		var prw1Req prompb.WriteRequest
+		if err = proto.Unmarshal(body, &prw1Req); err != nil {
+			prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 
-	m, stats, err := prw.translateV2(req.Context(), &prw2Req)
-	stats.SetHeaders(w)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest) // Following instructions at https://prometheus.io/docs/specs/remote_write_spec_2_0/#invalid-samples
+		m, err = prw.translateV1(req.Context(), &prw1Req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case promconfig.RemoteWriteProtoMsgV2:
+		var prw2Req writev2.Request
+		if err = proto.Unmarshal(body, &prw2Req); err != nil {
+			prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var stats promremote.WriteResponseStats
+		m, stats, err = prw.translateV2(req.Context(), &prw2Req)
+		stats.SetHeaders(w)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest) // Following instructions at https://prometheus.io/docs/specs/remote_write_spec_2_0/#invalid-samples
+			return
+		}
+	default:
+		prw.settings.Logger.Warn("message received with unsupported proto version, rejecting")
+		http.Error(w, "Unsupported proto version", http.StatusUnsupportedMediaType)
 		return
 	}
 
@@ -245,6 +271,7 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 		}
 		// The key is composed by: resource_hash:scope_name:scope_version:metric_name:unit:type
 		metricCache = make(map[uint64]pmetric.Metric)
+		classic     = newClassicAssembler(prw.settings.Logger)
 	)
 
 	for _, ts := range req.Timeseries {
@@ -260,25 +287,7 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 		// If the metric name is equal to target_info, we use its labels as attributes of the resource
 		// Ref: https://opentelemetry.io/docs/specs/otel/compatibility/prometheus_and_openmetrics/#resource-attributes-1
 		if ls.Get(labels.MetricName) == "target_info" {
-			var rm pmetric.ResourceMetrics
-			hashedLabels := xxhash.Sum64String(ls.Get("job") + string([]byte{'\xff'}) + ls.Get("instance"))
-
-			if existingRM, ok := prw.rmCache.Get(hashedLabels); ok {
-				rm = existingRM
-			} else {
-				rm = otelMetrics.ResourceMetrics().AppendEmpty()
-			}
-
-			attrs := rm.Resource().Attributes()
-			parseJobAndInstance(attrs, ls.Get("job"), ls.Get("instance"))
-
-			// Add the remaining labels as resource attributes
-			for labelName, labelValue := range ls.Map() {
-				if labelName != "job" && labelName != "instance" && labelName != labels.MetricName {
-					attrs.PutStr(labelName, labelValue)
-				}
-			}
-			prw.rmCache.Add(hashedLabels, rm)
+			prw.addTargetInfo(otelMetrics, ls)
 			continue
 		}
 
@@ -299,22 +308,22 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 
 		// Handle histograms separately due to their complex mixed-schema processing
 		if ts.Metadata.Type == writev2.Metadata_METRIC_TYPE_HISTOGRAM {
-			prw.processHistogramTimeSeries(otelMetrics, ls, ts, scopeName, scopeVersion, metricName, unit, description, metricCache, &stats)
+			if err := prw.processHistogramTimeSeries(otelMetrics, ls, ts, scopeName, scopeVersion, metricName, unit, description, metricCache, classic, &stats); err != nil {
+				badRequestErrors = errors.Join(badRequestErrors, err)
+			}
 			continue
 		}
 
-		// Handle regular metrics (gauge, counter, summary)
-		hashedLabels := xxhash.Sum64String(ls.Get("job") + string([]byte{'\xff'}) + ls.Get("instance"))
-		existingRM, ok := prw.rmCache.Get(hashedLabels)
-		var rm pmetric.ResourceMetrics
-		if ok {
-			rm = existingRM
-		} else {
-			rm = otelMetrics.ResourceMetrics().AppendEmpty()
-			parseJobAndInstance(rm.Resource().Attributes(), ls.Get("job"), ls.Get("instance"))
-			prw.rmCache.Add(hashedLabels, rm)
+		// Summaries are split into several series, just like classic histograms.
+		if ts.Metadata.Type == writev2.Metadata_METRIC_TYPE_SUMMARY {
+			if err := prw.addClassicSeries(otelMetrics, ls, ts, scopeName, scopeVersion, unit, description, metricCache, classic, &stats); err != nil {
+				badRequestErrors = errors.Join(badRequestErrors, err)
+			}
+			continue
 		}
 
+		// Handle regular metrics (gauge, counter)
+		rm := prw.getOrCreateResourceMetrics(otelMetrics, ls)
 		resourceID := identity.OfResource(rm.Resource())
 		metricIdentity := createMetricIdentity(
 			resourceID.String(), // Resource identity
@@ -326,23 +335,7 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 		)
 
 		metricKey := metricIdentity.Hash()
-
-		// Find or create scope
-		var scope pmetric.ScopeMetrics
-		var foundScope bool
-		for i := 0; i < rm.ScopeMetrics().Len(); i++ {
-			s := rm.ScopeMetrics().At(i)
-			if s.Scope().Name() == scopeName && s.Scope().Version() == scopeVersion {
-				scope = s
-				foundScope = true
-				break
-			}
-		}
-		if !foundScope {
-			scope = rm.ScopeMetrics().AppendEmpty()
-			scope.Scope().SetName(scopeName)
-			scope.Scope().SetVersion(scopeVersion)
-		}
+		scope := getOrCreateScope(rm, scopeName, scopeVersion)
 
 		// Get or create metric
 		metric, exists := metricCache[metricKey]
@@ -362,9 +355,6 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 				sum.SetIsMonotonic(true)
 				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
 				metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "counter")
-			case writev2.Metadata_METRIC_TYPE_SUMMARY:
-				// Drop summary series as we will not handle them.
-				continue
 			default:
 				badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("unsupported metric type %q for metric %q", ts.Metadata.Type, metricName))
 				continue
@@ -381,13 +371,11 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 			addNumberDatapoints(metric.Gauge().DataPoints(), ls, ts, &stats)
 		case writev2.Metadata_METRIC_TYPE_COUNTER:
 			addNumberDatapoints(metric.Sum().DataPoints(), ls, ts, &stats)
-		case writev2.Metadata_METRIC_TYPE_SUMMARY:
-			// Drop summary series as we will not handle them.
-			continue
 		default:
 			badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("unsupported metric type %q for metric %q", ts.Metadata.Type, metricName))
 		}
 	}
+	classic.appendDatapoints()
 
 	return otelMetrics, stats, badRequestErrors
 }
@@ -399,19 +387,17 @@ func (prw *prometheusRemoteWriteReceiver) processHistogramTimeSeries(
 	ts writev2.TimeSeries,
 	scopeName, scopeVersion, metricName, unit, description string,
 	metricCache map[uint64]pmetric.Metric,
+	classic *classicAssembler,
 	stats *promremote.WriteResponseStats,
-) {
-	// Drop classic histogram series (those with samples)
+) error {
+	// Classic histogram series (those with samples) are assembled with the other series of the same histogram.
 	if len(ts.Samples) != 0 {
-		prw.settings.Logger.Info("Dropping classic histogram series. Please configure Prometheus to convert classic histograms into Native Histograms Custom Buckets",
-			zapcore.Field{Key: "timeseries", Type: zapcore.StringType, String: ls.Get("__name__")})
-		return
+		return prw.addClassicSeries(otelMetrics, ls, ts, scopeName, scopeVersion, unit, description, metricCache, classic, stats)
 	}
 
 	var rm pmetric.ResourceMetrics
-	var hashedLabels uint64
 	var resourceID identity.Resource
-	var scope pmetric.ScopeMetrics
+	var resourceFound bool
 
 	for _, histogram := range ts.Histograms {
 		if histogram.ResetHint == writev2.Histogram_RESET_HINT_GAUGE {
@@ -432,34 +418,14 @@ func (prw *prometheusRemoteWriteReceiver) processHistogramTimeSeries(
 			continue
 		}
 		// Create resource if needed (only for the first valid histogram)
-		if hashedLabels == 0 {
-			hashedLabels = xxhash.Sum64String(ls.Get("job") + string([]byte{'\xff'}) + ls.Get("instance"))
-			existingRM, ok := prw.rmCache.Get(hashedLabels)
-			if ok {
-				rm = existingRM
-			} else {
-				rm = otelMetrics.ResourceMetrics().AppendEmpty()
-				parseJobAndInstance(rm.Resource().Attributes(), ls.Get("job"), ls.Get("instance"))
-				prw.rmCache.Add(hashedLabels, rm)
-			}
+		if !resourceFound {
+			rm = prw.getOrCreateResourceMetrics(otelMetrics, ls)
 			resourceID = identity.OfResource(rm.Resource())
+			resourceFound = true
 		}
 
 		// Find or create scope (search each time since different histograms might need different scopes)
-		var foundScope bool
-		for i := 0; i < rm.ScopeMetrics().Len(); i++ {
-			s := rm.ScopeMetrics().At(i)
-			if s.Scope().Name() == scopeName && s.Scope().Version() == scopeVersion {
-				scope = s
-				foundScope = true
-				break
-			}
-		}
-		if !foundScope {
-			scope = rm.ScopeMetrics().AppendEmpty()
-			scope.Scope().SetName(scopeName)
-			scope.Scope().SetVersion(scopeVersion)
-		}
+		scope := getOrCreateScope(rm, scopeName, scopeVersion)
 
 		metricID := fmt.Sprintf("%s:%s:%s:%s:%s:%s:%s",
 			resourceID.String(),
@@ -496,6 +462,102 @@ func (prw *prometheusRemoteWriteReceiver) processHistogramTimeSeries(
 			prw.addExponentialHistogramDatapoint(histMetric.ExponentialHistogram().DataPoints(), histogram, ls, ts.CreatedTimestamp, stats)
 		}
 	}
+	return nil
+}
+
+// addClassicSeries records the samples of a classic histogram or summary series. Its data points are built
+// by the classicAssembler once the other series of the same histogram or summary in the request were read.
+func (prw *prometheusRemoteWriteReceiver) addClassicSeries(
+	otelMetrics pmetric.Metrics,
+	ls labels.Labels,
+	ts writev2.TimeSeries,
+	scopeName, scopeVersion, unit, description string,
+	metricCache map[uint64]pmetric.Metric,
+	classic *classicAssembler,
+	stats *promremote.WriteResponseStats,
+) error {
+	metricName := ls.Get(labels.MetricName)
+	isSummary := ts.Metadata.Type == writev2.Metadata_METRIC_TYPE_SUMMARY
+	familyName := classicFamilyName(metricName, isSummary)
+
+	rm := prw.getOrCreateResourceMetrics(otelMetrics, ls)
+	resourceID := identity.OfResource(rm.Resource())
+	metricKey := createMetricIdentity(resourceID.String(), scopeName, scopeVersion, familyName, unit, ts.Metadata.Type).Hash()
+
+	metric, exists := metricCache[metricKey]
+	if !exists {
+		scope := getOrCreateScope(rm, scopeName, scopeVersion)
+		if isSummary {
+			metric = setSummaryMetric(scope, familyName, unit, description)
+		} else {
+			metric = setClassicHistogramMetric(scope, familyName, unit, description)
+		}
+		metricCache[metricKey] = metric
+	} else if len(metric.Description()) < len(description) {
+		// When the new description is longer than the existing one, we should update the metric description.
+		// Reference to this behavior: https://opentelemetry.io/docs/specs/otel/metrics/data-model/#opentelemetry-protocol-data-model-producer-recommendations
+		metric.SetDescription(description)
+	}
+
+	kind := classicKindOf(metricName, familyName)
+	for _, sample := range ts.Samples {
+		if err := classic.add(metric, metricKey, ls, kind, ts.CreatedTimestamp, sample.Timestamp, sample.Value); err != nil {
+			return err
+		}
+	}
+	stats.Samples += len(ts.Samples)
+	return nil
+}
+
+// addTargetInfo uses the labels of a target_info series as attributes of the resource identified by its job and instance labels.
+// Ref: https://opentelemetry.io/docs/specs/otel/compatibility/prometheus_and_openmetrics/#resource-attributes-1
+func (prw *prometheusRemoteWriteReceiver) addTargetInfo(otelMetrics pmetric.Metrics, ls labels.Labels) {
+	var rm pmetric.ResourceMetrics
+	hashedLabels := xxhash.Sum64String(ls.Get("job") + string([]byte{'\xff'}) + ls.Get("instance"))
+
+	if existingRM, ok := prw.rmCache.Get(hashedLabels); ok {
+		rm = existingRM
+	} else {
+		rm = otelMetrics.ResourceMetrics().AppendEmpty()
+	}
+
+	attrs := rm.Resource().Attributes()
+	parseJobAndInstance(attrs, ls.Get("job"), ls.Get("instance"))
+
+	// Add the remaining labels as resource attributes
+	for labelName, labelValue := range ls.Map() {
+		if labelName != "job" && labelName != "instance" && labelName != labels.MetricName {
+			attrs.PutStr(labelName, labelValue)
+		}
+	}
+	prw.rmCache.Add(hashedLabels, rm)
+}
+
+// getOrCreateResourceMetrics returns the resource metrics identified by the job and instance labels,
+// creating and caching a new one if it wasn't seen before.
+func (prw *prometheusRemoteWriteReceiver) getOrCreateResourceMetrics(otelMetrics pmetric.Metrics, ls labels.Labels) pmetric.ResourceMetrics {
+	hashedLabels := xxhash.Sum64String(ls.Get("job") + string([]byte{'\xff'}) + ls.Get("instance"))
+	if existingRM, ok := prw.rmCache.Get(hashedLabels); ok {
+		return existingRM
+	}
+	rm := otelMetrics.ResourceMetrics().AppendEmpty()
+	parseJobAndInstance(rm.Resource().Attributes(), ls.Get("job"), ls.Get("instance"))
+	prw.rmCache.Add(hashedLabels, rm)
+	return rm
+}
+
+// getOrCreateScope returns the scope metrics with the given name and version, appending a new one if not found.
+func getOrCreateScope(rm pmetric.ResourceMetrics, scopeName, scopeVersion string) pmetric.ScopeMetrics {
+	for i := 0; i < rm.ScopeMetrics().Len(); i++ {
+		s := rm.ScopeMetrics().At(i)
+		if s.Scope().Name() == scopeName && s.Scope().Version() == scopeVersion {
+			return s
+		}
+	}
+	scope := rm.ScopeMetrics().AppendEmpty()
+	scope.Scope().SetName(scopeName)
+	scope.Scope().SetVersion(scopeVersion)
+	return scope
 }
 
 // setMetric append a new empty metric and assign the name, unit and description to it.
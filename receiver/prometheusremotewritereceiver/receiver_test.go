@@ -121,7 +121,7 @@ func TestHandlePRWContentTypeNegotiation(t *testing.T) {
 		{
 			name:         "x-protobuf/no proto parameter",
 			contentType:  "application/x-protobuf",
-			expectedCode: http.StatusUnsupportedMediaType,
+			expectedCode: http.StatusNoContent,
 			expectedStats: remote.WriteResponseStats{
 				Confirmed:  false,
 				Samples:    0,
@@ -132,7 +132,7 @@ func TestHandlePRWContentTypeNegotiation(t *testing.T) {
 		{
 			name:         "x-protobuf/v1 proto parameter",
 			contentType:  fmt.Sprintf("application/x-protobuf;proto=%s", promconfig.RemoteWriteProtoMsgV1),
-			expectedCode: http.StatusUnsupportedMediaType,
+			expectedCode: http.StatusNoContent,
 			expectedStats: remote.WriteResponseStats{
 				Confirmed:  false,
 				Samples:    0,
@@ -172,7 +172,7 @@ func TestHandlePRWContentTypeNegotiation(t *testing.T) {
 			resp := w.Result()
 
 			assert.Equal(t, tc.expectedCode, resp.StatusCode)
-			if tc.expectedCode == http.StatusNoContent { // We went until the end
+			if tc.expectedStats.Confirmed { // We went until the end of a v2 request
 				assert.NotEmpty(t, resp.Header.Get("X-Prometheus-Remote-Write-Samples-Written"))
 				assert.NotEmpty(t, resp.Header.Get("X-Prometheus-Remote-Write-Histograms-Written"))
 				assert.NotEmpty(t, resp.Header.Get("X-Prometheus-Remote-Write-Exemplars-Written"))
@@ -1036,71 +1036,181 @@ func TestTranslateV2(t *testing.T) {
 			expectedMetrics: pmetric.NewMetrics(), // Reset hint gauge should be dropped completely, no resources should be created
 		},
 		{
-			name: "classic histogram - should be dropped",
+			name: "classic histogram",
 			request: &writev2.Request{
 				Symbols: []string{
 					"",
-					"__name__", "test_metric", // 1, 2
-					"job", "service-x/test", // 3, 4
-					"instance", "107cn001", // 5, 6
-					"otel_scope_name", "scope1", // 7, 8
-					"otel_scope_version", "v1", // 9, 10
-					"attr1", "attr1", // 11, 12
+					"__name__", "test_classic_histogram_bucket", // 1, 2
+					"test_classic_histogram_sum", "test_classic_histogram_count", // 3, 4
+					"job", "service-x/test", // 5, 6
+					"instance", "107cn001", // 7, 8
+					"le", "1", "+Inf", // 9, 10, 11
+					"Test classic histogram", // 12
 				},
 				Timeseries: []writev2.TimeSeries{
 					{
-						Metadata: writev2.Metadata{
-							Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM,
-						},
-						// Classic histograms populate samples instead of histograms. Those should be dropped.
-						Histograms: []writev2.Histogram{},
-						Samples: []writev2.Sample{
-							{
-								Value:     1,
-								Timestamp: 1,
-							},
-						},
-						LabelsRefs: []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+						Metadata:         writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM, HelpRef: 12},
+						LabelsRefs:       []uint32{1, 2, 5, 6, 7, 8, 9, 10},
+						Samples:          []writev2.Sample{{Value: 3, Timestamp: 2}},
+						CreatedTimestamp: 1,
+					},
+					{
+						Metadata:         writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM, HelpRef: 12},
+						LabelsRefs:       []uint32{1, 2, 5, 6, 7, 8, 9, 11},
+						Samples:          []writev2.Sample{{Value: 5, Timestamp: 2}},
+						CreatedTimestamp: 1,
+					},
+					{
+						Metadata:         writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM, HelpRef: 12},
+						LabelsRefs:       []uint32{1, 3, 5, 6, 7, 8},
+						Samples:          []writev2.Sample{{Value: 7.5, Timestamp: 2}},
+						CreatedTimestamp: 1,
+					},
+					{
+						Metadata:         writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM, HelpRef: 12},
+						LabelsRefs:       []uint32{1, 4, 5, 6, 7, 8},
+						Samples:          []writev2.Sample{{Value: 5, Timestamp: 2}},
+						CreatedTimestamp: 1,
 					},
 				},
 			},
 			expectedStats: remote.WriteResponseStats{
 				Confirmed:  true,
-				Samples:    0,
+				Samples:    4,
 				Histograms: 0,
 				Exemplars:  0,
 			},
-			expectedMetrics: pmetric.NewMetrics(), // Classic histograms should be dropped completely, no resources should be created
+			expectedMetrics: func() pmetric.Metrics {
+				metrics := pmetric.NewMetrics()
+				rm := metrics.ResourceMetrics().AppendEmpty()
+				attrs := rm.Resource().Attributes()
+				attrs.PutStr("service.namespace", "service-x")
+				attrs.PutStr("service.name", "test")
+				attrs.PutStr("service.instance.id", "107cn001")
+
+				sm := rm.ScopeMetrics().AppendEmpty()
+				sm.Scope().SetName("OpenTelemetry Collector")
+				sm.Scope().SetVersion("latest")
+
+				m := sm.Metrics().AppendEmpty()
+				m.SetName("test_classic_histogram")
+				m.SetUnit("")
+				m.SetDescription("Test classic histogram")
+				m.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "histogram")
+				hist := m.SetEmptyHistogram()
+				hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+				dp := hist.DataPoints().AppendEmpty()
+				dp.SetStartTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp.SetTimestamp(pcommon.Timestamp(2 * int64(time.Millisecond)))
+				dp.SetSum(7.5)
+				dp.SetCount(5)
+				dp.ExplicitBounds().FromRaw([]float64{1})
+				dp.BucketCounts().FromRaw([]uint64{3, 2})
+
+				return metrics
+			}(),
 		},
 		{
-			name: "summary - should be dropped",
+			name: "classic histogram with stale marker",
 			request: &writev2.Request{
 				Symbols: []string{
 					"",
-					"__name__", "test_metric", // 1, 2
-					"job", "service-x/test", // 3, 4
-					"instance", "107cn001", // 5, 6
-					"otel_scope_name", "scope1", // 7, 8
-					"otel_scope_version", "v1", // 9, 10
+					"__name__", "test_classic_histogram_bucket", // 1, 2
+					"test_classic_histogram_count", // 3
+					"job", "service-x/test",        // 4, 5
+					"instance", "107cn001", // 6, 7
+					"le", "+Inf", // 8, 9
 				},
 				Timeseries: []writev2.TimeSeries{
 					{
-						Metadata: writev2.Metadata{
-							Type: writev2.Metadata_METRIC_TYPE_SUMMARY,
-						},
-						Samples: []writev2.Sample{
-							{
-								Value:     1,
-								Timestamp: 1,
-							},
-						},
-						LabelsRefs: []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM},
+						LabelsRefs: []uint32{1, 2, 4, 5, 6, 7, 8, 9},
+						Samples:    []writev2.Sample{{Value: math.Float64frombits(value.StaleNaN), Timestamp: 2}},
+					},
+					{
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM},
+						LabelsRefs: []uint32{1, 3, 4, 5, 6, 7},
+						Samples:    []writev2.Sample{{Value: math.Float64frombits(value.StaleNaN), Timestamp: 2}},
 					},
 				},
 			},
 			expectedStats: remote.WriteResponseStats{
 				Confirmed:  true,
-				Samples:    0,
+				Samples:    2,
+				Histograms: 0,
+				Exemplars:  0,
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				metrics := pmetric.NewMetrics()
+				rm := metrics.ResourceMetrics().AppendEmpty()
+				attrs := rm.Resource().Attributes()
+				attrs.PutStr("service.namespace", "service-x")
+				attrs.PutStr("service.name", "test")
+				attrs.PutStr("service.instance.id", "107cn001")
+
+				sm := rm.ScopeMetrics().AppendEmpty()
+				sm.Scope().SetName("OpenTelemetry Collector")
+				sm.Scope().SetVersion("latest")
+
+				m := sm.Metrics().AppendEmpty()
+				m.SetName("test_classic_histogram")
+				m.SetUnit("")
+				m.SetDescription("")
+				m.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "histogram")
+				hist := m.SetEmptyHistogram()
+				hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+				dp := hist.DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(2 * int64(time.Millisecond)))
+				dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+
+				return metrics
+			}(),
+		},
+		{
+			name: "summary",
+			request: &writev2.Request{
+				Symbols: []string{
+					"",
+					"__name__", "test_summary", // 1, 2
+					"test_summary_sum", "test_summary_count", // 3, 4
+					"job", "service-x/test", // 5, 6
+					"instance", "107cn001", // 7, 8
+					"otel_scope_name", "scope1", // 9, 10
+					"otel_scope_version", "v1", // 11, 12
+					"quantile", "0.5", "0.9", // 13, 14, 15
+				},
+				Timeseries: []writev2.TimeSeries{
+					{
+						Metadata:         writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_SUMMARY},
+						LabelsRefs:       []uint32{1, 2, 5, 6, 7, 8, 9, 10, 11, 12, 13, 15},
+						Samples:          []writev2.Sample{{Value: 9, Timestamp: 2}},
+						CreatedTimestamp: 1,
+					},
+					{
+						Metadata:         writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_SUMMARY},
+						LabelsRefs:       []uint32{1, 2, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14},
+						Samples:          []writev2.Sample{{Value: 5, Timestamp: 2}},
+						CreatedTimestamp: 1,
+					},
+					{
+						Metadata:         writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_SUMMARY},
+						LabelsRefs:       []uint32{1, 3, 5, 6, 7, 8, 9, 10, 11, 12},
+						Samples:          []writev2.Sample{{Value: 100, Timestamp: 2}},
+						CreatedTimestamp: 1,
+					},
+					{
+						Metadata:         writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_SUMMARY},
+						LabelsRefs:       []uint32{1, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+						Samples:          []writev2.Sample{{Value: 20, Timestamp: 2}},
+						CreatedTimestamp: 1,
+					},
+				},
+			},
+			expectedStats: remote.WriteResponseStats{
+				Confirmed:  true,
+				Samples:    4,
 				Histograms: 0,
 				Exemplars:  0,
 			},
@@ -1116,9 +1226,41 @@ func TestTranslateV2(t *testing.T) {
 				sm.Scope().SetName("scope1")
 				sm.Scope().SetVersion("v1")
 
+				m := sm.Metrics().AppendEmpty()
+				m.SetName("test_summary")
+				m.SetUnit("")
+				m.SetDescription("")
+				m.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "summary")
+
+				dp := m.SetEmptySummary().DataPoints().AppendEmpty()
+				dp.SetStartTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp.SetTimestamp(pcommon.Timestamp(2 * int64(time.Millisecond)))
+				dp.SetSum(100)
+				dp.SetCount(20)
+				q1 := dp.QuantileValues().AppendEmpty()
+				q1.SetQuantile(0.5)
+				q1.SetValue(5)
+				q2 := dp.QuantileValues().AppendEmpty()
+				q2.SetQuantile(0.9)
+				q2.SetValue(9)
+
 				return metrics
 			}(),
 		},
+		{
+			name: "summary without quantile label",
+			request: &writev2.Request{
+				Symbols: []string{"", "__name__", "test_summary"},
+				Timeseries: []writev2.TimeSeries{
+					{
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_SUMMARY},
+						LabelsRefs: []uint32{1, 2},
+						Samples:    []writev2.Sample{{Value: 1, Timestamp: 1}},
+					},
+				},
+			},
+			expectError: `invalid "quantile" label value "" for metric "test_summary"`,
+		},
 		{
 			name: "NHCB translation",
 			request: &writev2.Request{
@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	promremote "github.com/prometheus/prometheus/storage/remote"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// translateV1 translates a v1 remote-write request into OTLP metrics.
+//
+// Remote-Write v1 series don't carry their metadata, so the metric type, unit and description are
+// looked up by metric family name in the metadata received so far (see metadataCache). When no metadata
+// is known, Prometheus naming conventions are used to recognize counters, classic histograms and summaries.
+func (prw *prometheusRemoteWriteReceiver) translateV1(_ context.Context, req *prompb.WriteRequest) (pmetric.Metrics, error) {
+	var (
+		badRequestErrors error
+		otelMetrics      = pmetric.NewMetrics()
+		labelsBuilder    = labels.NewScratchBuilder(0)
+		// The key is composed by: resource_hash:scope_name:scope_version:metric_name:unit:type
+		metricCache = make(map[uint64]pmetric.Metric)
+		classic     = newClassicAssembler(prw.settings.Logger)
+	)
+
+	for _, md := range req.Metadata {
+		prw.metadataCache.Add(md.MetricFamilyName, md)
+	}
+	classicFamilies := detectClassicFamilies(req.Timeseries)
+
+	for _, ts := range req.Timeseries {
+		ls := ts.ToLabels(&labelsBuilder, nil)
+		if !ls.Has(labels.MetricName) {
+			badRequestErrors = errors.Join(badRequestErrors, errors.New("missing metric name in labels"))
+			continue
+		} else if duplicateLabel, hasDuplicate := ls.HasDuplicateLabelNames(); hasDuplicate {
+			badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("duplicate label %q in labels", duplicateLabel))
+			continue
+		}
+
+		metricName := ls.Get(labels.MetricName)
+		if metricName == "target_info" {
+			prw.addTargetInfo(otelMetrics, ls)
+			continue
+		}
+
+		scopeName, scopeVersion := prw.extractScopeInfo(ls)
+
+		// Native histograms are converted to their v2 representation and share the v2 processing.
+		if len(ts.Histograms) > 0 {
+			md, _ := prw.metadataCache.Get(metricName)
+			if err := prw.processHistogramTimeSeries(otelMetrics, ls, toV2HistogramTimeSeries(ts.Histograms), scopeName, scopeVersion, metricName, md.Unit, md.Help, metricCache, classic, &promremote.WriteResponseStats{}); err != nil {
+				badRequestErrors = errors.Join(badRequestErrors, err)
+			}
+			continue
+		}
+
+		familyName, md := prw.lookupV1Metadata(metricName, classicFamilies)
+		rm := prw.getOrCreateResourceMetrics(otelMetrics, ls)
+		resourceID := identity.OfResource(rm.Resource())
+		metricKey := createMetricIdentity(
+			resourceID.String(),                  // Resource identity
+			scopeName,                            // Scope name
+			scopeVersion,                         // Scope version
+			familyName,                           // Metric name
+			md.Unit,                              // Unit
+			writev2.Metadata_MetricType(md.Type), // Metric type, v1 and v2 share the same enum values
+		).Hash()
+		scope := getOrCreateScope(rm, scopeName, scopeVersion)
+
+		metric, exists := metricCache[metricKey]
+		if !exists {
+			switch md.Type {
+			case prompb.MetricMetadata_GAUGE, prompb.MetricMetadata_UNKNOWN, prompb.MetricMetadata_INFO, prompb.MetricMetadata_STATESET:
+				// Info and stateset series are plain gauges whose value is 0 or 1.
+				metric = setMetric(scope, familyName, md.Unit, md.Help)
+				metric.SetEmptyGauge()
+				metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, strings.ToLower(md.Type.String()))
+			case prompb.MetricMetadata_COUNTER:
+				metric = setMetric(scope, familyName, md.Unit, md.Help)
+				sum := metric.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "counter")
+			case prompb.MetricMetadata_HISTOGRAM:
+				metric = setClassicHistogramMetric(scope, familyName, md.Unit, md.Help)
+			case prompb.MetricMetadata_SUMMARY:
+				metric = setSummaryMetric(scope, familyName, md.Unit, md.Help)
+			default:
+				badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("unsupported metric type %q for metric %q", md.Type, metricName))
+				continue
+			}
+			metricCache[metricKey] = metric
+		} else if len(metric.Description()) < len(md.Help) {
+			// When the new description is longer than the existing one, we should update the metric description.
+			// Reference to this behavior: https://opentelemetry.io/docs/specs/otel/metrics/data-model/#opentelemetry-protocol-data-model-producer-recommendations
+			metric.SetDescription(md.Help)
+		}
+
+		switch metric.Type() {
+		case pmetric.MetricTypeGauge:
+			addV1NumberDatapoints(metric.Gauge().DataPoints(), ls, ts.Samples)
+		case pmetric.MetricTypeSum:
+			addV1NumberDatapoints(metric.Sum().DataPoints(), ls, ts.Samples)
+		case pmetric.MetricTypeHistogram, pmetric.MetricTypeSummary:
+			kind := classicKindOf(metricName, familyName)
+			for _, sample := range ts.Samples {
+				if err := classic.add(metric, metricKey, ls, kind, 0, sample.Timestamp, sample.Value); err != nil {
+					badRequestErrors = errors.Join(badRequestErrors, err)
+					break
+				}
+			}
+		}
+	}
+	classic.appendDatapoints()
+
+	return otelMetrics, badRequestErrors
+}
+
+// lookupV1Metadata returns the metric family name and metadata of a v1 series.
+// Metadata received from the sender always wins; otherwise classic histogram and summary series
+// are recognized by the shape of the request (see detectClassicFamilies) and "_total" series are
+// considered counters, following the Prometheus naming conventions.
+func (prw *prometheusRemoteWriteReceiver) lookupV1Metadata(metricName string, classicFamilies map[string]prompb.MetricMetadata_MetricType) (string, prompb.MetricMetadata) {
+	if md, ok := prw.metadataCache.Get(metricName); ok {
+		return metricName, md
+	}
+
+	for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+		familyName, found := strings.CutSuffix(metricName, suffix)
+		if !found {
+			continue
+		}
+		if md, ok := prw.metadataCache.Get(familyName); ok && (md.Type == prompb.MetricMetadata_HISTOGRAM || md.Type == prompb.MetricMetadata_SUMMARY) {
+			return familyName, md
+		}
+		if metricType, ok := classicFamilies[familyName]; ok {
+			return familyName, prompb.MetricMetadata{Type: metricType, MetricFamilyName: familyName}
+		}
+	}
+	if metricType, ok := classicFamilies[metricName]; ok {
+		return metricName, prompb.MetricMetadata{Type: metricType, MetricFamilyName: metricName}
+	}
+
+	// Counters exposed with the OpenMetrics format have their metadata stored without the _total suffix.
+	if familyName, found := strings.CutSuffix(metricName, "_total"); found {
+		if md, ok := prw.metadataCache.Get(familyName); ok {
+			return metricName, md
+		}
+		return metricName, prompb.MetricMetadata{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: metricName}
+	}
+
+	return metricName, prompb.MetricMetadata{Type: prompb.MetricMetadata_UNKNOWN, MetricFamilyName: metricName}
+}
+
+// detectClassicFamilies returns the metric families of the request that look like classic histograms
+// (series ending with "_bucket" that carry a "le" label) or summaries (series carrying a "quantile" label).
+func detectClassicFamilies(timeseries []prompb.TimeSeries) map[string]prompb.MetricMetadata_MetricType {
+	families := make(map[string]prompb.MetricMetadata_MetricType)
+	for _, ts := range timeseries {
+		var metricName string
+		var hasBucket, hasQuantile bool
+		for _, l := range ts.Labels {
+			switch l.Name {
+			case labels.MetricName:
+				metricName = l.Value
+			case labels.BucketLabel:
+				hasBucket = true
+			case quantileLabel:
+				hasQuantile = true
+			}
+		}
+
+		if familyName, found := strings.CutSuffix(metricName, "_bucket"); found && hasBucket {
+			families[familyName] = prompb.MetricMetadata_HISTOGRAM
+		} else if hasQuantile {
+			families[metricName] = prompb.MetricMetadata_SUMMARY
+		}
+	}
+	return families
+}
+
+// toV2HistogramTimeSeries converts v1 native histograms into a v2 histogram time series.
+func toV2HistogramTimeSeries(histograms []prompb.Histogram) writev2.TimeSeries {
+	ts := writev2.TimeSeries{
+		Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM},
+		Histograms: make([]writev2.Histogram, 0, len(histograms)),
+	}
+	for _, h := range histograms {
+		if h.IsFloatHistogram() {
+			ts.Histograms = append(ts.Histograms, writev2.FromFloatHistogram(h.Timestamp, h.ToFloatHistogram()))
+		} else {
+			ts.Histograms = append(ts.Histograms, writev2.FromIntHistogram(h.Timestamp, h.ToIntHistogram()))
+		}
+	}
+	return ts
+}
+
+// addV1NumberDatapoints adds the v1 samples as datapoints, with the labels as datapoints attributes.
+// Remote-Write v1 doesn't send created timestamps, so the start timestamp is left unset.
+func addV1NumberDatapoints(datapoints pmetric.NumberDataPointSlice, ls labels.Labels, samples []prompb.Sample) {
+	for _, sample := range samples {
+		dp := datapoints.AppendEmpty()
+		// Set timestamp in nanoseconds (Prometheus uses milliseconds)
+		dp.SetTimestamp(pcommon.Timestamp(sample.Timestamp * int64(time.Millisecond)))
+		dp.SetDoubleValue(sample.Value)
+		extractAttributes(ls).CopyTo(dp.Attributes())
+	}
+}
@@ -0,0 +1,432 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+func v1Labels(kv ...string) []prompb.Label {
+	ls := make([]prompb.Label, 0, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		ls = append(ls, prompb.Label{Name: kv[i], Value: kv[i+1]})
+	}
+	return ls
+}
+
+func newV1ExpectedScope(expected pmetric.Metrics) pmetric.ScopeMetrics {
+	rm := expected.ResourceMetrics().AppendEmpty()
+	attrs := rm.Resource().Attributes()
+	attrs.PutStr("service.name", "test_job")
+	attrs.PutStr("service.instance.id", "test_instance")
+
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("OpenTelemetry Collector")
+	sm.Scope().SetVersion("latest")
+	return sm
+}
+
+func TestTranslateV1(t *testing.T) {
+	prwReceiver := setupMetricsReceiver(t)
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	for _, tc := range []struct {
+		name            string
+		request         *prompb.WriteRequest
+		expectError     string
+		expectedMetrics pmetric.Metrics
+	}{
+		{
+			name: "missing metric name",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels:  v1Labels("foo", "bar"),
+						Samples: []prompb.Sample{{Value: 1, Timestamp: 1}},
+					},
+				},
+			},
+			expectError: "missing metric name in labels",
+		},
+		{
+			name: "invalid bucket label",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels:  v1Labels("__name__", "test_hist_bucket", "le", "abc"),
+						Samples: []prompb.Sample{{Value: 1, Timestamp: 1}},
+					},
+				},
+			},
+			expectError: `invalid "le" label value "abc" for metric "test_hist"`,
+		},
+		{
+			name: "gauge and counter with metadata",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels:  v1Labels("__name__", "test_gauge", "instance", "test_instance", "job", "test_job", "foo", "bar"),
+						Samples: []prompb.Sample{{Value: 1, Timestamp: 1}, {Value: 2, Timestamp: 2}},
+					},
+					{
+						Labels:  v1Labels("__name__", "test_requests_total", "instance", "test_instance", "job", "test_job"),
+						Samples: []prompb.Sample{{Value: 10, Timestamp: 1}},
+					},
+				},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "test_gauge", Help: "Test gauge", Unit: "bytes"},
+					{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "test_requests", Help: "Test counter"},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				sm := newV1ExpectedScope(expected)
+
+				gauge := sm.Metrics().AppendEmpty()
+				gauge.SetName("test_gauge")
+				gauge.SetUnit("bytes")
+				gauge.SetDescription("Test gauge")
+				gauge.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "gauge")
+				dps := gauge.SetEmptyGauge().DataPoints()
+				dp1 := dps.AppendEmpty()
+				dp1.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp1.SetDoubleValue(1)
+				dp1.Attributes().PutStr("foo", "bar")
+				dp2 := dps.AppendEmpty()
+				dp2.SetTimestamp(pcommon.Timestamp(2 * int64(time.Millisecond)))
+				dp2.SetDoubleValue(2)
+				dp2.Attributes().PutStr("foo", "bar")
+
+				counter := sm.Metrics().AppendEmpty()
+				counter.SetName("test_requests_total")
+				counter.SetDescription("Test counter")
+				counter.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "counter")
+				sum := counter.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := sum.DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp.SetDoubleValue(10)
+				return expected
+			}(),
+		},
+		{
+			name: "counter and unknown without metadata",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels:  v1Labels("__name__", "test_nometadata_total", "instance", "test_instance", "job", "test_job"),
+						Samples: []prompb.Sample{{Value: 5, Timestamp: 1}},
+					},
+					{
+						Labels:  v1Labels("__name__", "test_nometadata", "instance", "test_instance", "job", "test_job"),
+						Samples: []prompb.Sample{{Value: 6, Timestamp: 1}},
+					},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				sm := newV1ExpectedScope(expected)
+
+				counter := sm.Metrics().AppendEmpty()
+				counter.SetName("test_nometadata_total")
+				counter.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "counter")
+				sum := counter.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := sum.DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp.SetDoubleValue(5)
+
+				unknown := sm.Metrics().AppendEmpty()
+				unknown.SetName("test_nometadata")
+				unknown.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "unknown")
+				gdp := unknown.SetEmptyGauge().DataPoints().AppendEmpty()
+				gdp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				gdp.SetDoubleValue(6)
+				return expected
+			}(),
+		},
+		{
+			name: "classic histogram",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels:  v1Labels("__name__", "test_latency_bucket", "instance", "test_instance", "job", "test_job", "le", "0.1"),
+						Samples: []prompb.Sample{{Value: 2, Timestamp: 1}},
+					},
+					{
+						Labels:  v1Labels("__name__", "test_latency_bucket", "instance", "test_instance", "job", "test_job", "le", "1"),
+						Samples: []prompb.Sample{{Value: 5, Timestamp: 1}},
+					},
+					{
+						Labels:  v1Labels("__name__", "test_latency_bucket", "instance", "test_instance", "job", "test_job", "le", "+Inf"),
+						Samples: []prompb.Sample{{Value: 6, Timestamp: 1}},
+					},
+					{
+						Labels:  v1Labels("__name__", "test_latency_count", "instance", "test_instance", "job", "test_job"),
+						Samples: []prompb.Sample{{Value: 6, Timestamp: 1}},
+					},
+					{
+						Labels:  v1Labels("__name__", "test_latency_sum", "instance", "test_instance", "job", "test_job"),
+						Samples: []prompb.Sample{{Value: 3.5, Timestamp: 1}},
+					},
+				},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "test_latency", Help: "Test histogram", Unit: "seconds"},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				sm := newV1ExpectedScope(expected)
+
+				metric := sm.Metrics().AppendEmpty()
+				metric.SetName("test_latency")
+				metric.SetUnit("seconds")
+				metric.SetDescription("Test histogram")
+				metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "histogram")
+				hist := metric.SetEmptyHistogram()
+				hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := hist.DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp.SetCount(6)
+				dp.SetSum(3.5)
+				dp.ExplicitBounds().FromRaw([]float64{0.1, 1})
+				dp.BucketCounts().FromRaw([]uint64{2, 3, 1})
+				return expected
+			}(),
+		},
+		{
+			name: "classic histogram without metadata nor +Inf bucket",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels:  v1Labels("__name__", "test_size_bucket", "instance", "test_instance", "job", "test_job", "le", "10", "path", "/"),
+						Samples: []prompb.Sample{{Value: 1, Timestamp: 1}, {Value: 3, Timestamp: 2}},
+					},
+					{
+						Labels:  v1Labels("__name__", "test_size_count", "instance", "test_instance", "job", "test_job", "path", "/"),
+						Samples: []prompb.Sample{{Value: 2, Timestamp: 1}, {Value: 4, Timestamp: 2}},
+					},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				sm := newV1ExpectedScope(expected)
+
+				metric := sm.Metrics().AppendEmpty()
+				metric.SetName("test_size")
+				metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "histogram")
+				hist := metric.SetEmptyHistogram()
+				hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp1 := hist.DataPoints().AppendEmpty()
+				dp1.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp1.SetCount(2)
+				dp1.ExplicitBounds().FromRaw([]float64{10})
+				dp1.BucketCounts().FromRaw([]uint64{1, 1})
+				dp1.Attributes().PutStr("path", "/")
+				dp2 := hist.DataPoints().AppendEmpty()
+				dp2.SetTimestamp(pcommon.Timestamp(2 * int64(time.Millisecond)))
+				dp2.SetCount(4)
+				dp2.ExplicitBounds().FromRaw([]float64{10})
+				dp2.BucketCounts().FromRaw([]uint64{3, 1})
+				dp2.Attributes().PutStr("path", "/")
+				return expected
+			}(),
+		},
+		{
+			name: "summary",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels:  v1Labels("__name__", "test_gc_duration", "instance", "test_instance", "job", "test_job", "quantile", "0.99"),
+						Samples: []prompb.Sample{{Value: 0.9, Timestamp: 1}},
+					},
+					{
+						Labels:  v1Labels("__name__", "test_gc_duration", "instance", "test_instance", "job", "test_job", "quantile", "0.5"),
+						Samples: []prompb.Sample{{Value: 0.5, Timestamp: 1}},
+					},
+					{
+						Labels:  v1Labels("__name__", "test_gc_duration_sum", "instance", "test_instance", "job", "test_job"),
+						Samples: []prompb.Sample{{Value: 12, Timestamp: 1}},
+					},
+					{
+						Labels:  v1Labels("__name__", "test_gc_duration_count", "instance", "test_instance", "job", "test_job"),
+						Samples: []prompb.Sample{{Value: 20, Timestamp: 1}},
+					},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				sm := newV1ExpectedScope(expected)
+
+				metric := sm.Metrics().AppendEmpty()
+				metric.SetName("test_gc_duration")
+				metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "summary")
+				dp := metric.SetEmptySummary().DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp.SetCount(20)
+				dp.SetSum(12)
+				q1 := dp.QuantileValues().AppendEmpty()
+				q1.SetQuantile(0.5)
+				q1.SetValue(0.5)
+				q2 := dp.QuantileValues().AppendEmpty()
+				q2.SetQuantile(0.99)
+				q2.SetValue(0.9)
+				return expected
+			}(),
+		},
+		{
+			name: "native histogram",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels: v1Labels("__name__", "test_native", "instance", "test_instance", "job", "test_job"),
+						Histograms: []prompb.Histogram{
+							{
+								Count:          &prompb.Histogram_CountInt{CountInt: 3},
+								Sum:            6,
+								Schema:         0,
+								ZeroThreshold:  1e-128,
+								ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+								PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 2}},
+								PositiveDeltas: []int64{1, 0},
+								Timestamp:      1,
+							},
+						},
+					},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				sm := newV1ExpectedScope(expected)
+
+				metric := sm.Metrics().AppendEmpty()
+				metric.SetName("test_native")
+				hist := metric.SetEmptyExponentialHistogram()
+				hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := hist.DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp.SetScale(0)
+				dp.SetZeroThreshold(1e-128)
+				dp.SetZeroCount(1)
+				dp.SetCount(3)
+				dp.SetSum(6)
+				dp.Positive().SetOffset(0)
+				dp.Positive().BucketCounts().FromRaw([]uint64{1, 1})
+				return expected
+			}(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// since we are using the rmCache to store values across requests, we need to clear it after each test, otherwise it will affect the next test
+			prwReceiver.rmCache.Purge()
+			prwReceiver.metadataCache.Purge()
+			metrics, err := prwReceiver.translateV1(ctx, tc.request)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NoError(t, pmetrictest.CompareMetrics(tc.expectedMetrics, metrics))
+			assert.Equal(t, buildMetaDataMapByID(tc.expectedMetrics), buildMetaDataMapByID(metrics))
+		})
+	}
+}
+
+func TestTranslateV1MetadataAcrossRequests(t *testing.T) {
+	prwReceiver := setupMetricsReceiver(t)
+
+	// Prometheus sends the metadata in dedicated requests, without samples.
+	_, err := prwReceiver.translateV1(t.Context(), &prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "test_temperature", Help: "Test temperature", Unit: "celsius"},
+		},
+	})
+	require.NoError(t, err)
+
+	metrics, err := prwReceiver.translateV1(t.Context(), &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  v1Labels("__name__", "test_temperature", "job", "test_job"),
+				Samples: []prompb.Sample{{Value: 21.5, Timestamp: 1}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.MetricCount())
+
+	metric := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, pmetric.MetricTypeGauge, metric.Type())
+	assert.Equal(t, "celsius", metric.Unit())
+	assert.Equal(t, "Test temperature", metric.Description())
+}
+
+func TestTranslateV1NonMonotonicHistogram(t *testing.T) {
+	prwReceiver := setupMetricsReceiver(t)
+
+	metrics, err := prwReceiver.translateV1(t.Context(), &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  v1Labels("__name__", "test_hist_bucket", "le", "1"),
+				Samples: []prompb.Sample{{Value: 5, Timestamp: 1}},
+			},
+			{
+				Labels:  v1Labels("__name__", "test_hist_bucket", "le", "+Inf"),
+				Samples: []prompb.Sample{{Value: 3, Timestamp: 1}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.MetricCount())
+	assert.Equal(t, 0, metrics.DataPointCount())
+}
+
+func TestHandlePRWV1(t *testing.T) {
+	prwReceiver := setupMetricsReceiver(t)
+	mockConsumer := new(mockConsumer)
+	prwReceiver.nextConsumer = mockConsumer
+
+	body, err := proto.Marshal(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  v1Labels("__name__", "test_gauge", "job", "test_job"),
+				Samples: []prompb.Sample{{Value: math.Pi, Timestamp: 1}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/write", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	w := httptest.NewRecorder()
+	prwReceiver.handlePRW(w, req)
+
+	resp := w.Result()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("X-Prometheus-Remote-Write-Samples-Written"))
+
+	mockConsumer.mu.Lock()
+	defer mockConsumer.mu.Unlock()
+	require.Len(t, mockConsumer.metrics, 1)
+	assert.Equal(t, 1, mockConsumer.dataPoints)
+}
@@ -17,6 +17,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
+const (
+	serviceChecksAsLogs    = "logs"
+	serviceChecksAsMetrics = "metrics"
+)
+
 // Config defines configuration for StatsD receiver.
 type Config struct {
 	NetAddr                 confignet.AddrConfig             `mapstructure:",squash"`
@@ -26,6 +31,9 @@ type Config struct {
 	EnableSimpleTags        bool                             `mapstructure:"enable_simple_tags"`
 	IsMonotonicCounter      bool                             `mapstructure:"is_monotonic_counter"`
 	TimerHistogramMapping   []protocol.TimerHistogramMapping `mapstructure:"timer_histogram_mapping"`
+	// ServiceChecksAs tells whether DogStatsD service checks are converted to log records ("logs")
+	// or to gauges whose value is the status of the check ("metrics").
+	ServiceChecksAs string `mapstructure:"service_checks_as"`
 	// Will only be used when transport set to 'unixgram'.
 	SocketPermissions os.FileMode `mapstructure:"socket_permissions"`
 }
@@ -37,6 +45,13 @@ func (c *Config) Validate() error {
 		errs = multierr.Append(errs, errors.New("aggregation_interval must be a positive duration"))
 	}
 
+	switch c.ServiceChecksAs {
+	case "", serviceChecksAsLogs, serviceChecksAsMetrics:
+		// do nothing
+	default:
+		errs = multierr.Append(errs, fmt.Errorf("service_checks_as must be either %q or %q: %s", serviceChecksAsLogs, serviceChecksAsMetrics, c.ServiceChecksAs))
+	}
+
 	var TimerHistogramMappingMissingObjectName bool
 	for _, eachMap := range c.TimerHistogramMapping {
 		if eachMap.StatsdType == "" {
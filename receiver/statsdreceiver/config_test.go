@@ -45,6 +45,7 @@ func TestLoadConfig(t *testing.T) {
 				},
 				SocketPermissions:   0o622,
 				AggregationInterval: 70 * time.Second,
+				ServiceChecksAs:     "metrics",
 				TimerHistogramMapping: []protocol.TimerHistogramMapping{
 					{
 						StatsdType:   "histogram",
@@ -109,9 +110,18 @@ func TestValidate(t *testing.T) {
 		invalidHistogramErr               = "histogram configuration requires observer_type: histogram"
 		invalidSummaryErr                 = "summary configuration requires observer_type: summary"
 		invalidExplicitBucketNoPatternErr = "explicit bucket [0] matcher_pattern must not be empty"
+		invalidServiceChecksAsErr         = "service_checks_as must be either \"logs\" or \"metrics\": traces"
 	)
 
 	tests := []test{
+		{
+			name: "invalidServiceChecksAs",
+			cfg: &Config{
+				AggregationInterval: 10,
+				ServiceChecksAs:     "traces",
+			},
+			expectedErr: invalidServiceChecksAsErr,
+		},
 		{
 			name: "negativeAggregationInterval",
 			cfg: &Config{
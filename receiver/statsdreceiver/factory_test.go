@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
 )
 
@@ -30,3 +31,15 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 }
+
+func TestCreateMetricsAndLogsReceiversShareServer(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0" // Endpoint is required, not going to be used here.
+
+	params := receivertest.NewNopSettings(metadata.Type)
+	mReceiver, err := createMetricsReceiver(t.Context(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	lReceiver, err := createLogsReceiver(t.Context(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.Same(t, mReceiver.(*sharedcomponent.SharedComponent).Unwrap(), lReceiver.(*sharedcomponent.SharedComponent).Unwrap())
+}
@@ -0,0 +1,314 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parser // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/parser"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.22.0"
+)
+
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+
+	// ServiceCheckType is the type of the gauges built from DogStatsD service checks,
+	// so they are not mixed with regular gauges sharing the same name and tags.
+	ServiceCheckType MetricType = "sc"
+
+	attrEventTitle          = "dogstatsd.event.title"
+	attrEventPriority       = "dogstatsd.event.priority"
+	attrEventAlertType      = "dogstatsd.event.alert_type"
+	attrEventAggregationKey = "dogstatsd.event.aggregation_key"
+	attrEventSourceTypeName = "dogstatsd.event.source_type_name"
+	attrServiceCheckName    = "dogstatsd.service_check.name"
+	attrServiceCheckStatus  = "dogstatsd.service_check.status"
+
+	defaultEventPriority  = "normal"
+	defaultEventAlertType = "info"
+)
+
+var (
+	errEmptyEventTitle       = errors.New("empty event title")
+	errEmptyServiceCheckName = errors.New("empty service check name")
+)
+
+// serviceCheckStatuses are the names of the DogStatsD service check statuses, indexed by status value.
+var serviceCheckStatuses = []string{"OK", "WARNING", "CRITICAL", "UNKNOWN"}
+
+// dogStatsDEvent is a DogStatsD event.
+// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=events
+type dogStatsDEvent struct {
+	title          string
+	text           string
+	timestamp      uint64
+	hostname       string
+	priority       string
+	alertType      string
+	aggregationKey string
+	sourceTypeName string
+	attrs          []attribute.KeyValue
+}
+
+// dogStatsDServiceCheck is a DogStatsD service check.
+// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=servicechecks
+type dogStatsDServiceCheck struct {
+	name      string
+	status    int64
+	timestamp uint64
+	hostname  string
+	message   string
+	attrs     []attribute.KeyValue
+}
+
+// parseEvent parses a DogStatsD event with the format:
+// _e{<TITLE_UTF8_LENGTH>,<TEXT_UTF8_LENGTH>}:<TITLE>|<TEXT>|d:<TIMESTAMP>|h:<HOSTNAME>|p:<PRIORITY>|t:<ALERT_TYPE>|k:<AGGREGATION_KEY>|s:<SOURCE_TYPE_NAME>|#<TAG_KEY_1>:<TAG_VALUE_1>,<TAG_2>
+func parseEvent(line string, enableSimpleTags bool) (dogStatsDEvent, error) {
+	result := dogStatsDEvent{
+		priority:  defaultEventPriority,
+		alertType: defaultEventAlertType,
+	}
+
+	lengths, rest, found := strings.Cut(strings.TrimPrefix(line, eventPrefix), "}:")
+	if !found {
+		return result, fmt.Errorf("invalid event format: %s", line)
+	}
+	titleLengthStr, textLengthStr, found := strings.Cut(lengths, ",")
+	if !found {
+		return result, fmt.Errorf("invalid event lengths: %s", lengths)
+	}
+	titleLength, err := strconv.Atoi(titleLengthStr)
+	if err != nil || titleLength < 0 {
+		return result, fmt.Errorf("invalid event title length: %s", titleLengthStr)
+	}
+	if titleLength == 0 {
+		return result, errEmptyEventTitle
+	}
+	textLength, err := strconv.Atoi(textLengthStr)
+	if err != nil || textLength < 0 {
+		return result, fmt.Errorf("invalid event text length: %s", textLengthStr)
+	}
+
+	// The title and the text are separated by a "|", their lengths are the number of bytes.
+	// The lengths are checked one at a time so that their sum cannot overflow.
+	if titleLength >= len(rest) || textLength > len(rest)-titleLength-1 || rest[titleLength] != '|' {
+		return result, fmt.Errorf("event title and text don't match their lengths: %s", line)
+	}
+	result.title = unescapeNewlines(rest[:titleLength])
+	result.text = unescapeNewlines(rest[titleLength+1 : titleLength+1+textLength])
+
+	additionalParts := rest[titleLength+1+textLength:]
+	if additionalParts != "" {
+		if additionalParts[0] != '|' {
+			return result, fmt.Errorf("event title and text don't match their lengths: %s", line)
+		}
+		additionalParts = additionalParts[1:]
+	}
+
+	var part string
+	part, additionalParts, _ = strings.Cut(additionalParts, "|")
+	for ; part != ""; part, additionalParts, _ = strings.Cut(additionalParts, "|") {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			result.timestamp, err = parseTimestamp(strings.TrimPrefix(part, "d:"))
+			if err != nil {
+				return result, err
+			}
+		case strings.HasPrefix(part, "h:"):
+			result.hostname = strings.TrimPrefix(part, "h:")
+		case strings.HasPrefix(part, "p:"):
+			result.priority = strings.TrimPrefix(part, "p:")
+			if result.priority != "normal" && result.priority != "low" {
+				return result, fmt.Errorf("invalid event priority: %s", result.priority)
+			}
+		case strings.HasPrefix(part, "t:"):
+			result.alertType = strings.TrimPrefix(part, "t:")
+			if _, ok := eventSeverities[result.alertType]; !ok {
+				return result, fmt.Errorf("invalid event alert type: %s", result.alertType)
+			}
+		case strings.HasPrefix(part, "k:"):
+			result.aggregationKey = strings.TrimPrefix(part, "k:")
+		case strings.HasPrefix(part, "s:"):
+			result.sourceTypeName = strings.TrimPrefix(part, "s:")
+		case strings.HasPrefix(part, "#"):
+			result.attrs, err = parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags, result.attrs)
+			if err != nil {
+				return result, err
+			}
+		case strings.HasPrefix(part, "c:"):
+			result.attrs = appendContainerID(strings.TrimPrefix(part, "c:"), result.attrs)
+		default:
+			return result, fmt.Errorf("unrecognized event part: %s", part)
+		}
+	}
+
+	return result, nil
+}
+
+// parseServiceCheck parses a DogStatsD service check with the format:
+// _sc|<NAME>|<STATUS>|d:<TIMESTAMP>|h:<HOSTNAME>|#<TAG_KEY_1>:<TAG_VALUE_1>,<TAG_2>|m:<SERVICE_CHECK_MESSAGE>
+func parseServiceCheck(line string, enableSimpleTags bool) (dogStatsDServiceCheck, error) {
+	result := dogStatsDServiceCheck{}
+
+	name, rest, _ := strings.Cut(strings.TrimPrefix(line, serviceCheckPrefix), "|")
+	if name == "" {
+		return result, errEmptyServiceCheckName
+	}
+	result.name = name
+
+	statusStr, additionalParts, _ := strings.Cut(rest, "|")
+	status, err := strconv.ParseInt(statusStr, 10, 64)
+	if err != nil || status < 0 || status >= int64(len(serviceCheckStatuses)) {
+		return result, fmt.Errorf("invalid service check status: %s", statusStr)
+	}
+	result.status = status
+
+	var part string
+	part, additionalParts, _ = strings.Cut(additionalParts, "|")
+	for ; part != ""; part, additionalParts, _ = strings.Cut(additionalParts, "|") {
+		switch {
+		case strings.HasPrefix(part, "m:"):
+			// The message is always the last field, it spans until the end of the datagram
+			// and may contain "|".
+			result.message = strings.TrimPrefix(part, "m:")
+			if additionalParts != "" {
+				result.message += "|" + additionalParts
+				additionalParts = ""
+			}
+			result.message = unescapeNewlines(result.message)
+		case strings.HasPrefix(part, "d:"):
+			result.timestamp, err = parseTimestamp(strings.TrimPrefix(part, "d:"))
+			if err != nil {
+				return result, err
+			}
+		case strings.HasPrefix(part, "h:"):
+			result.hostname = strings.TrimPrefix(part, "h:")
+		case strings.HasPrefix(part, "#"):
+			result.attrs, err = parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags, result.attrs)
+			if err != nil {
+				return result, err
+			}
+		case strings.HasPrefix(part, "c:"):
+			result.attrs = appendContainerID(strings.TrimPrefix(part, "c:"), result.attrs)
+		default:
+			return result, fmt.Errorf("unrecognized service check part: %s", part)
+		}
+	}
+
+	return result, nil
+}
+
+// parseTimestamp parses a timestamp in seconds and returns it in nanoseconds.
+func parseTimestamp(timestampStr string) (uint64, error) {
+	timestampSeconds, err := strconv.ParseUint(timestampStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp: %s", timestampStr)
+	}
+	if timestampSeconds > math.MaxUint64/uint64(time.Second) {
+		return 0, fmt.Errorf("timestamp out of range: %s", timestampStr)
+	}
+	return timestampSeconds * 1e9, nil
+}
+
+// unescapeNewlines restores the line breaks that DogStatsD clients escape as "\n".
+func unescapeNewlines(s string) string {
+	return strings.ReplaceAll(s, `\n`, "\n")
+}
+
+var eventSeverities = map[string]plog.SeverityNumber{
+	"error":   plog.SeverityNumberError,
+	"warning": plog.SeverityNumberWarn,
+	"info":    plog.SeverityNumberInfo,
+	"success": plog.SeverityNumberInfo,
+}
+
+var serviceCheckSeverities = []plog.SeverityNumber{
+	plog.SeverityNumberInfo,
+	plog.SeverityNumberWarn,
+	plog.SeverityNumberError,
+	plog.SeverityNumberUnspecified,
+}
+
+// buildEventLogRecord appends the event as a log record, the text being the body.
+func buildEventLogRecord(event dogStatsDEvent, timeNow time.Time, lrs plog.LogRecordSlice) {
+	lr := lrs.AppendEmpty()
+	setLogRecordTimestamps(lr, event.timestamp, timeNow)
+	lr.Body().SetStr(event.text)
+	lr.SetSeverityNumber(eventSeverities[event.alertType])
+	lr.SetSeverityText(event.alertType)
+
+	attrs := lr.Attributes()
+	attrs.PutStr(attrEventTitle, event.title)
+	attrs.PutStr(attrEventPriority, event.priority)
+	attrs.PutStr(attrEventAlertType, event.alertType)
+	if event.aggregationKey != "" {
+		attrs.PutStr(attrEventAggregationKey, event.aggregationKey)
+	}
+	if event.sourceTypeName != "" {
+		attrs.PutStr(attrEventSourceTypeName, event.sourceTypeName)
+	}
+	putCommonLogAttributes(attrs, event.hostname, event.attrs)
+}
+
+// buildServiceCheckLogRecord appends the service check as a log record, the message being the body.
+func buildServiceCheckLogRecord(serviceCheck dogStatsDServiceCheck, timeNow time.Time, lrs plog.LogRecordSlice) {
+	lr := lrs.AppendEmpty()
+	setLogRecordTimestamps(lr, serviceCheck.timestamp, timeNow)
+	lr.Body().SetStr(serviceCheck.message)
+	lr.SetSeverityNumber(serviceCheckSeverities[serviceCheck.status])
+	lr.SetSeverityText(serviceCheckStatuses[serviceCheck.status])
+
+	attrs := lr.Attributes()
+	attrs.PutStr(attrServiceCheckName, serviceCheck.name)
+	attrs.PutInt(attrServiceCheckStatus, serviceCheck.status)
+	putCommonLogAttributes(attrs, serviceCheck.hostname, serviceCheck.attrs)
+}
+
+// serviceCheckToMetric converts the service check into a gauge whose value is the status of the check.
+func serviceCheckToMetric(serviceCheck dogStatsDServiceCheck) statsDMetric {
+	result := statsDMetric{
+		description: statsDMetricDescription{
+			name:       serviceCheck.name,
+			metricType: ServiceCheckType,
+		},
+		asFloat:   float64(serviceCheck.status),
+		timestamp: serviceCheck.timestamp,
+	}
+
+	kvs := serviceCheck.attrs
+	if serviceCheck.hostname != "" {
+		kvs = append(kvs, attribute.String(string(semconv.HostNameKey), serviceCheck.hostname))
+	}
+	if len(kvs) != 0 {
+		result.description.attrs = attribute.NewSet(kvs...)
+	}
+	return result
+}
+
+func setLogRecordTimestamps(lr plog.LogRecord, timestamp uint64, timeNow time.Time) {
+	observed := pcommon.NewTimestampFromTime(timeNow)
+	lr.SetObservedTimestamp(observed)
+	if timestamp != 0 {
+		lr.SetTimestamp(pcommon.Timestamp(timestamp))
+	} else {
+		lr.SetTimestamp(observed)
+	}
+}
+
+func putCommonLogAttributes(attrs pcommon.Map, hostname string, kvs []attribute.KeyValue) {
+	if hostname != "" {
+		attrs.PutStr(string(semconv.HostNameKey), hostname)
+	}
+	for _, kv := range kvs {
+		attrs.PutStr(string(kv.Key), kv.Value.AsString())
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.22.0"
+)
+
+func Test_ParseEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantEvent dogStatsDEvent
+		err       error
+	}{
+		{
+			name:  "title and text only",
+			input: "_e{5,4}:title|text",
+			wantEvent: dogStatsDEvent{
+				title:     "title",
+				text:      "text",
+				priority:  "normal",
+				alertType: "info",
+			},
+		},
+		{
+			name:  "empty text",
+			input: "_e{5,0}:title|",
+			wantEvent: dogStatsDEvent{
+				title:     "title",
+				priority:  "normal",
+				alertType: "info",
+			},
+		},
+		{
+			name:  "text containing a pipe and an escaped new line",
+			input: `_e{5,11}:title|te|xt\nline|t:error`,
+			wantEvent: dogStatsDEvent{
+				title:     "title",
+				text:      "te|xt\nline",
+				priority:  "normal",
+				alertType: "error",
+			},
+		},
+		{
+			name:  "all fields",
+			input: "_e{5,4}:title|text|d:1640995200|h:myhost|p:low|t:warning|k:mykey|s:mysource|#key:value,simple:tag|c:abc123",
+			wantEvent: dogStatsDEvent{
+				title:          "title",
+				text:           "text",
+				timestamp:      1640995200 * 1e9,
+				hostname:       "myhost",
+				priority:       "low",
+				alertType:      "warning",
+				aggregationKey: "mykey",
+				sourceTypeName: "mysource",
+				attrs: []attribute.KeyValue{
+					attribute.String("key", "value"),
+					attribute.String("simple", "tag"),
+					attribute.String(string(semconv.ContainerIDKey), "abc123"),
+				},
+			},
+		},
+		{
+			name:  "missing lengths",
+			input: "_e{5,4:title|text",
+			err:   errors.New("invalid event format: _e{5,4:title|text"),
+		},
+		{
+			name:  "invalid title length",
+			input: "_e{a,4}:title|text",
+			err:   errors.New("invalid event title length: a"),
+		},
+		{
+			name:  "empty title",
+			input: "_e{0,4}:|text",
+			err:   errors.New("empty event title"),
+		},
+		{
+			name:  "invalid text length",
+			input: "_e{5,-1}:title|text",
+			err:   errors.New("invalid event text length: -1"),
+		},
+		{
+			name:  "lengths not matching",
+			input: "_e{5,10}:title|text",
+			err:   errors.New("event title and text don't match their lengths: _e{5,10}:title|text"),
+		},
+		{
+			name:  "text longer than its length",
+			input: "_e{5,2}:title|text",
+			err:   errors.New("event title and text don't match their lengths: _e{5,2}:title|text"),
+		},
+		{
+			name:  "overflowing lengths",
+			input: "_e{1,9223372036854775807}:a|b",
+			err:   errors.New("event title and text don't match their lengths: _e{1,9223372036854775807}:a|b"),
+		},
+		{
+			name:  "title longer than the event",
+			input: "_e{9223372036854775807,1}:a|b",
+			err:   errors.New("event title and text don't match their lengths: _e{9223372036854775807,1}:a|b"),
+		},
+		{
+			name:  "invalid priority",
+			input: "_e{5,4}:title|text|p:high",
+			err:   errors.New("invalid event priority: high"),
+		},
+		{
+			name:  "invalid alert type",
+			input: "_e{5,4}:title|text|t:fatal",
+			err:   errors.New("invalid event alert type: fatal"),
+		},
+		{
+			name:  "invalid timestamp",
+			input: "_e{5,4}:title|text|d:abc",
+			err:   errors.New("invalid timestamp: abc"),
+		},
+		{
+			name:  "timestamp out of range",
+			input: "_e{5,4}:title|text|d:18446744074",
+			err:   errors.New("timestamp out of range: 18446744074"),
+		},
+		{
+			name:  "unrecognized part",
+			input: "_e{5,4}:title|text|x:foo",
+			err:   errors.New("unrecognized event part: x:foo"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEvent(tt.input, false)
+
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantEvent, got)
+			}
+		})
+	}
+}
+
+func Test_ParseServiceCheck(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            string
+		wantServiceCheck dogStatsDServiceCheck
+		err              error
+	}{
+		{
+			name:  "name and status only",
+			input: "_sc|my.check|0",
+			wantServiceCheck: dogStatsDServiceCheck{
+				name:   "my.check",
+				status: 0,
+			},
+		},
+		{
+			name:  "all fields",
+			input: "_sc|my.check|2|d:1640995200|h:myhost|#key:value|c:abc123|m:it's down",
+			wantServiceCheck: dogStatsDServiceCheck{
+				name:      "my.check",
+				status:    2,
+				timestamp: 1640995200 * 1e9,
+				hostname:  "myhost",
+				message:   "it's down",
+				attrs: []attribute.KeyValue{
+					attribute.String("key", "value"),
+					attribute.String(string(semconv.ContainerIDKey), "abc123"),
+				},
+			},
+		},
+		{
+			name:  "message containing pipes and an escaped new line",
+			input: `_sc|my.check|1|m:first|second\nthird`,
+			wantServiceCheck: dogStatsDServiceCheck{
+				name:    "my.check",
+				status:  1,
+				message: "first|second\nthird",
+			},
+		},
+		{
+			name:  "empty name",
+			input: "_sc||0",
+			err:   errors.New("empty service check name"),
+		},
+		{
+			name:  "missing status",
+			input: "_sc|my.check",
+			err:   errors.New("invalid service check status: "),
+		},
+		{
+			name:  "status out of range",
+			input: "_sc|my.check|4",
+			err:   errors.New("invalid service check status: 4"),
+		},
+		{
+			name:  "invalid tag",
+			input: "_sc|my.check|0|#simple",
+			err:   errors.New("invalid tag format: \"simple\""),
+		},
+		{
+			name:  "unrecognized part",
+			input: "_sc|my.check|0|x:foo",
+			err:   errors.New("unrecognized service check part: x:foo"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseServiceCheck(tt.input, false)
+
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantServiceCheck, got)
+			}
+		})
+	}
+}
+
+func TestStatsDParser_AggregateEventsAndServiceChecks(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+	defer func() {
+		timeNowFunc = time.Now
+	}()
+
+	p := &StatsDParser{}
+	require.NoError(t, p.Initialize(false, false, false, false, nil))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+
+	require.NoError(t, p.Aggregate("_e{5,4}:title|text|t:error|#key:value", addr))
+	require.NoError(t, p.Aggregate("_sc|my.check|1|d:1640995200|h:myhost|m:degraded", addr))
+	require.NoError(t, p.Aggregate("test.metric:42|c", addr))
+	assert.Error(t, p.Aggregate("_sc|my.check|9", addr))
+
+	batches := p.GetLogs()
+	require.Len(t, batches, 1)
+	assert.Equal(t, addr, batches[0].Info.Addr)
+
+	logs := batches[0].Logs
+	require.Equal(t, 1, logs.ResourceLogs().Len())
+	sl := logs.ResourceLogs().At(0).ScopeLogs().At(0)
+	assert.Equal(t, receiverName, sl.Scope().Name())
+	require.Equal(t, 2, sl.LogRecords().Len())
+
+	event := sl.LogRecords().At(0)
+	assert.Equal(t, "text", event.Body().Str())
+	assert.Equal(t, plog.SeverityNumberError, event.SeverityNumber())
+	assert.Equal(t, "error", event.SeverityText())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), event.Timestamp())
+	assert.Equal(t, map[string]any{
+		"dogstatsd.event.title":      "title",
+		"dogstatsd.event.priority":   "normal",
+		"dogstatsd.event.alert_type": "error",
+		"key":                        "value",
+	}, event.Attributes().AsRaw())
+
+	serviceCheck := sl.LogRecords().At(1)
+	assert.Equal(t, "degraded", serviceCheck.Body().Str())
+	assert.Equal(t, plog.SeverityNumberWarn, serviceCheck.SeverityNumber())
+	assert.Equal(t, "WARNING", serviceCheck.SeverityText())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(1640995200, 0)), serviceCheck.Timestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), serviceCheck.ObservedTimestamp())
+	assert.Equal(t, map[string]any{
+		"dogstatsd.service_check.name":   "my.check",
+		"dogstatsd.service_check.status": int64(1),
+		"host.name":                      "myhost",
+	}, serviceCheck.Attributes().AsRaw())
+
+	// The metrics are not affected by the events and service checks.
+	assert.Equal(t, 1, p.GetMetrics()[0].Metrics.MetricCount())
+	assert.Empty(t, p.GetLogs())
+}
+
+func TestStatsDParser_AggregateServiceChecksAsMetrics(t *testing.T) {
+	p := &StatsDParser{
+		ServiceChecksAsMetrics: true,
+	}
+	require.NoError(t, p.Initialize(false, false, false, false, nil))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+
+	require.NoError(t, p.Aggregate("_sc|my.check|0|h:myhost", addr))
+	require.NoError(t, p.Aggregate("_sc|my.check|2|d:1640995200|h:myhost", addr))
+	// A regular gauge with the same name is kept apart.
+	require.NoError(t, p.Aggregate("my.check:5|g|#host.name:myhost", addr))
+
+	assert.Empty(t, p.GetLogs())
+
+	batches := p.GetMetrics()
+	require.Len(t, batches, 1)
+	require.Equal(t, 2, batches[0].Metrics.MetricCount())
+
+	desc := statsDMetricDescription{
+		name:       "my.check",
+		metricType: ServiceCheckType,
+		attrs:      attribute.NewSet(attribute.String("host.name", "myhost")),
+	}
+	expected := buildGaugeMetric(statsDMetric{description: desc, asFloat: 2}, time.Unix(1640995200, 0))
+
+	var found bool
+	sms := batches[0].Metrics.ResourceMetrics().At(0).ScopeMetrics()
+	for i := 0; i < sms.Len(); i++ {
+		metric := sms.At(i).Metrics().At(0)
+		dp := metric.Gauge().DataPoints().At(0)
+		if dp.Timestamp() != pcommon.NewTimestampFromTime(time.Unix(1640995200, 0)) {
+			continue
+		}
+		found = true
+		assert.Equal(t, pmetric.MetricTypeGauge, metric.Type())
+		assert.Equal(t, expected.Metrics().At(0).Name(), metric.Name())
+		assert.Equal(t, expected.Metrics().At(0).Gauge().DataPoints().At(0).DoubleValue(), dp.DoubleValue())
+		assert.Equal(t, map[string]any{"host.name": "myhost"}, dp.Attributes().AsRaw())
+	}
+	assert.True(t, found, "service check gauge not found")
+}
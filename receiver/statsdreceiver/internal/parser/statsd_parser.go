@@ -17,6 +17,7 @@ import (
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.22.0"
@@ -64,7 +65,16 @@ type StatsDParser struct {
 	timerEvents             ObserverCategory
 	histogramEvents         ObserverCategory
 	lastIntervalTime        time.Time
+	logsByAddress           map[netAddr]*addressLogs
 	BuildInfo               component.BuildInfo
+	// ServiceChecksAsMetrics converts DogStatsD service checks into gauges instead of log records.
+	ServiceChecksAsMetrics bool
+}
+
+// addressLogs holds the log records built from the DogStatsD events and service checks sent by a client.
+type addressLogs struct {
+	addr net.Addr
+	logs plog.Logs
 }
 
 type instruments struct {
@@ -203,6 +213,7 @@ func (p *StatsDParser) resetState(when time.Time) {
 
 func (p *StatsDParser) Initialize(enableMetricType, enableSimpleTags, isMonotonicCounter, enableIPOnlyAggregation bool, sendTimerHistogram []protocol.TimerHistogramMapping) error {
 	p.resetState(timeNowFunc())
+	p.logsByAddress = make(map[netAddr]*addressLogs)
 
 	p.histogramEvents = defaultObserverCategory
 	p.timerEvents = defaultObserverCategory
@@ -314,6 +325,21 @@ func (p *StatsDParser) GetMetrics() []BatchMetrics {
 	return batchMetrics
 }
 
+// GetLogs gets the log records built from DogStatsD events and service checks, and resets them.
+func (p *StatsDParser) GetLogs() []BatchLogs {
+	batchLogs := make([]BatchLogs, 0, len(p.logsByAddress))
+	for _, al := range p.logsByAddress {
+		batchLogs = append(batchLogs, BatchLogs{
+			Info: client.Info{
+				Addr: al.addr,
+			},
+			Logs: al.logs,
+		})
+	}
+	p.logsByAddress = make(map[netAddr]*addressLogs)
+	return batchLogs
+}
+
 func (p *StatsDParser) copyMetricAndScope(rm pmetric.ResourceMetrics, metric pmetric.ScopeMetrics) {
 	ilm := rm.ScopeMetrics().AppendEmpty()
 	metric.CopyTo(ilm)
@@ -340,21 +366,19 @@ func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 
 // Aggregate for each metric line.
 func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
+	switch {
+	case strings.HasPrefix(line, eventPrefix):
+		return p.aggregateEvent(line, addr)
+	case strings.HasPrefix(line, serviceCheckPrefix):
+		return p.aggregateServiceCheck(line, addr)
+	}
+
 	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType, p.enableSimpleTags)
 	if err != nil {
 		return err
 	}
 
-	addrKey := newNetAddr(addr)
-	if p.enableIPOnlyAggregation {
-		addrKey = newIPOnlyNetAddr(addr)
-	}
-
-	instrument, ok := p.instrumentsByAddress[addrKey]
-	if !ok {
-		instrument = newInstruments(addr)
-		p.instrumentsByAddress[addrKey] = instrument
-	}
+	instrument := p.instrumentsFor(addr)
 
 	switch parsedMetric.description.metricType {
 	case GaugeType:
@@ -451,6 +475,68 @@ func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
 	return nil
 }
 
+func (p *StatsDParser) aggregateEvent(line string, addr net.Addr) error {
+	event, err := parseEvent(line, p.enableSimpleTags)
+	if err != nil {
+		return err
+	}
+	buildEventLogRecord(event, timeNowFunc(), p.logRecordsFor(addr))
+	return nil
+}
+
+func (p *StatsDParser) aggregateServiceCheck(line string, addr net.Addr) error {
+	serviceCheck, err := parseServiceCheck(line, p.enableSimpleTags)
+	if err != nil {
+		return err
+	}
+
+	if !p.ServiceChecksAsMetrics {
+		buildServiceCheckLogRecord(serviceCheck, timeNowFunc(), p.logRecordsFor(addr))
+		return nil
+	}
+
+	// Only the latest status of a service check is kept, like for gauges.
+	parsedMetric := serviceCheckToMetric(serviceCheck)
+	timeNow := timeNowFunc()
+	if parsedMetric.timestamp != 0 {
+		timeNow = time.Unix(0, int64(parsedMetric.timestamp))
+	}
+	p.instrumentsFor(addr).gauges[parsedMetric.description] = buildGaugeMetric(parsedMetric, timeNow)
+	return nil
+}
+
+func (p *StatsDParser) addrKey(addr net.Addr) netAddr {
+	if p.enableIPOnlyAggregation {
+		return newIPOnlyNetAddr(addr)
+	}
+	return newNetAddr(addr)
+}
+
+func (p *StatsDParser) instrumentsFor(addr net.Addr) *instruments {
+	addrKey := p.addrKey(addr)
+	instrument, ok := p.instrumentsByAddress[addrKey]
+	if !ok {
+		instrument = newInstruments(addr)
+		p.instrumentsByAddress[addrKey] = instrument
+	}
+	return instrument
+}
+
+func (p *StatsDParser) logRecordsFor(addr net.Addr) plog.LogRecordSlice {
+	addrKey := p.addrKey(addr)
+	al, ok := p.logsByAddress[addrKey]
+	if !ok {
+		al = &addressLogs{
+			addr: addr,
+			logs: plog.NewLogs(),
+		}
+		sl := al.logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+		p.setVersionAndNameScope(sl.Scope())
+		p.logsByAddress[addrKey] = al
+	}
+	return al.logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+}
+
 func parseMessageToMetric(line string, enableMetricType, enableSimpleTags bool) (statsDMetric, error) {
 	result := statsDMetric{}
 
@@ -500,38 +586,13 @@ func parseMessageToMetric(line string, enableMetricType, enableSimpleTags bool)
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
-			tagsStr := strings.TrimPrefix(part, "#")
-
-			// handle an empty tag set
-			// where the tags part was still sent (some clients do this)
-			if tagsStr == "" {
-				continue
-			}
-
-			var tagSet string
-			tagSet, tagsStr, _ = strings.Cut(tagsStr, ",")
-			for ; tagSet != ""; tagSet, tagsStr, _ = strings.Cut(tagsStr, ",") {
-				k, v, _ := strings.Cut(tagSet, ":")
-				if k == "" {
-					return result, fmt.Errorf("invalid tag format: %q", tagSet)
-				}
-
-				// support both simple tags (w/o value) and dimension tags (w/ value).
-				// dogstatsd notably allows simple tags.
-				if v == "" && !enableSimpleTags {
-					return result, fmt.Errorf("invalid tag format: %q", tagSet)
-				}
-
-				kvs = append(kvs, attribute.String(k, v))
+			var err error
+			kvs, err = parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags, kvs)
+			if err != nil {
+				return result, err
 			}
 		case strings.HasPrefix(part, "c:"):
-			// As per DogStatD protocol v1.2:
-			// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=metrics#dogstatsd-protocol-v12
-			containerID := strings.TrimPrefix(part, "c:")
-
-			if containerID != "" {
-				kvs = append(kvs, attribute.String(string(semconv.ContainerIDKey), containerID))
-			}
+			kvs = appendContainerID(strings.TrimPrefix(part, "c:"), kvs)
 		case strings.HasPrefix(part, "T"):
 			// As per DogStatD protocol v1.3:
 			// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=metrics#dogstatsd-protocol-v13
@@ -570,6 +631,43 @@ func parseMessageToMetric(line string, enableMetricType, enableSimpleTags bool)
 	return result, nil
 }
 
+// parseTags parses the comma separated tags of a message and appends them to kvs.
+func parseTags(tagsStr string, enableSimpleTags bool, kvs []attribute.KeyValue) ([]attribute.KeyValue, error) {
+	// handle an empty tag set
+	// where the tags part was still sent (some clients do this)
+	if tagsStr == "" {
+		return kvs, nil
+	}
+
+	var tagSet string
+	tagSet, tagsStr, _ = strings.Cut(tagsStr, ",")
+	for ; tagSet != ""; tagSet, tagsStr, _ = strings.Cut(tagsStr, ",") {
+		k, v, _ := strings.Cut(tagSet, ":")
+		if k == "" {
+			return kvs, fmt.Errorf("invalid tag format: %q", tagSet)
+		}
+
+		// support both simple tags (w/o value) and dimension tags (w/ value).
+		// dogstatsd notably allows simple tags.
+		if v == "" && !enableSimpleTags {
+			return kvs, fmt.Errorf("invalid tag format: %q", tagSet)
+		}
+
+		kvs = append(kvs, attribute.String(k, v))
+	}
+	return kvs, nil
+}
+
+// appendContainerID appends the container ID of a message to kvs.
+func appendContainerID(containerID string, kvs []attribute.KeyValue) []attribute.KeyValue {
+	// As per DogStatD protocol v1.2:
+	// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=metrics#dogstatsd-protocol-v12
+	if containerID != "" {
+		kvs = append(kvs, attribute.String(string(semconv.ContainerIDKey), containerID))
+	}
+	return kvs
+}
+
 type netAddr struct {
 	Network string
 	String  string
@@ -6,8 +6,6 @@ package transport // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"errors"
 	"net"
-
-	"go.opentelemetry.io/collector/consumer"
 )
 
 type packetServer struct {
@@ -17,11 +15,10 @@ type packetServer struct {
 
 // ListenAndServe starts the server ready to receive metrics.
 func (u *packetServer) ListenAndServe(
-	nextConsumer consumer.Metrics,
 	reporter Reporter,
 	transferChan chan<- Metric,
 ) error {
-	if nextConsumer == nil || reporter == nil {
+	if reporter == nil {
 		return errNilListenAndServeParameters
 	}
 
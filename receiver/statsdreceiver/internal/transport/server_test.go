@@ -13,7 +13,6 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.opentelemetry.io/collector/consumer/consumertest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport/client"
@@ -53,8 +52,6 @@ func Test_Server_ListenAndServe(t *testing.T) {
 			r.NoError(err)
 			r.NotNil(srv)
 
-			mc := new(consumertest.MetricsSink)
-			r.NoError(err)
 			mr := NewMockReporter(1)
 			transferChan := make(chan Metric, 10)
 
@@ -62,7 +59,7 @@ func Test_Server_ListenAndServe(t *testing.T) {
 			wgListenAndServe.Add(1)
 			go func() {
 				defer wgListenAndServe.Done()
-				assert.Error(t, srv.ListenAndServe(mc, mr, transferChan))
+				assert.Error(t, srv.ListenAndServe(mr, transferChan))
 			}()
 
 			runtime.Gosched()
@@ -25,19 +25,24 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport"
 )
 
-var _ receiver.Metrics = (*statsdReceiver)(nil)
+var (
+	_ receiver.Metrics = (*statsdReceiver)(nil)
+	_ receiver.Logs    = (*statsdReceiver)(nil)
+)
 
-// statsdReceiver implements the receiver.Metrics for StatsD protocol.
+// statsdReceiver implements the receiver.Metrics for StatsD protocol,
+// and the receiver.Logs for DogStatsD events and service checks.
 type statsdReceiver struct {
 	settings receiver.Settings
 	config   *Config
 
-	server       transport.Server
-	reporter     *reporter
-	obsrecv      *receiverhelper.ObsReport
-	parser       parser.Parser
-	nextConsumer consumer.Metrics
-	cancel       context.CancelFunc
+	server           transport.Server
+	reporter         *reporter
+	obsrecv          *receiverhelper.ObsReport
+	parser           parser.Parser
+	nextConsumer     consumer.Metrics
+	nextLogsConsumer consumer.Logs
+	cancel           context.CancelFunc
 }
 
 // newReceiver creates the StatsD receiver with the given parameters.
@@ -78,7 +83,8 @@ func newReceiver(
 		obsrecv:      obsrecv,
 		reporter:     rep,
 		parser: &parser.StatsDParser{
-			BuildInfo: set.BuildInfo,
+			BuildInfo:              set.BuildInfo,
+			ServiceChecksAsMetrics: config.ServiceChecksAs == serviceChecksAsMetrics,
 		},
 	}
 	return r, nil
@@ -118,8 +124,11 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 	if err != nil {
 		return err
 	}
+	if r.config.ServiceChecksAs == serviceChecksAsMetrics && r.nextConsumer == nil {
+		r.settings.Logger.Warn("service_checks_as is set to metrics but the receiver is not used in a metrics pipeline, service checks will be dropped")
+	}
 	go func() {
-		if err := r.server.ListenAndServe(r.nextConsumer, r.reporter, transferChan); err != nil {
+		if err := r.server.ListenAndServe(r.reporter, transferChan); err != nil {
 			if !errors.Is(err, net.ErrClosed) {
 				componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
 			}
@@ -131,15 +140,31 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 			select {
 			case <-ticker.C:
 				batchMetrics := r.parser.GetMetrics()
-				for _, batch := range batchMetrics {
-					batchCtx := client.NewContext(ctx, batch.Info)
-					numPoints := batch.Metrics.DataPointCount()
-					flushCtx := r.obsrecv.StartMetricsOp(batchCtx)
-					err := r.Flush(flushCtx, batch.Metrics, r.nextConsumer)
-					if err != nil {
-						r.reporter.OnDebugf("Error flushing metrics", zap.Error(err))
+				// The metrics are still aggregated and reset when only the logs receiver is used.
+				if r.nextConsumer != nil {
+					for _, batch := range batchMetrics {
+						batchCtx := client.NewContext(ctx, batch.Info)
+						numPoints := batch.Metrics.DataPointCount()
+						flushCtx := r.obsrecv.StartMetricsOp(batchCtx)
+						err := r.Flush(flushCtx, batch.Metrics, r.nextConsumer)
+						if err != nil {
+							r.reporter.OnDebugf("Error flushing metrics", zap.Error(err))
+						}
+						r.obsrecv.EndMetricsOp(flushCtx, metadata.Type.String(), numPoints, err)
+					}
+				}
+				batchLogs := r.parser.GetLogs()
+				if r.nextLogsConsumer != nil {
+					for _, batch := range batchLogs {
+						batchCtx := client.NewContext(ctx, batch.Info)
+						numRecords := batch.Logs.LogRecordCount()
+						flushCtx := r.obsrecv.StartLogsOp(batchCtx)
+						err := r.nextLogsConsumer.ConsumeLogs(flushCtx, batch.Logs)
+						if err != nil {
+							r.reporter.OnDebugf("Error flushing logs", zap.Error(err))
+						}
+						r.obsrecv.EndLogsOp(flushCtx, metadata.Type.String(), numRecords, err)
 					}
-					r.obsrecv.EndMetricsOp(flushCtx, metadata.Type.String(), numPoints, err)
 				}
 			case metric := <-transferChan:
 				err := r.parser.Aggregate(metric.Raw, metric.Addr)
@@ -17,6 +17,8 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
@@ -59,6 +61,25 @@ func Test_statsdreceiver_Start(t *testing.T) {
 	}
 }
 
+func TestStatsdReceiver_ServiceChecksAsMetricsWithoutMetricsPipeline(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopSettings(metadata.Type)
+	settings.Logger = zap.New(core)
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0"
+	cfg.ServiceChecksAs = serviceChecksAsMetrics
+
+	rcv, err := newReceiver(settings, *cfg, nil)
+	require.NoError(t, err)
+	rcv.(*statsdReceiver).nextLogsConsumer = consumertest.NewNop()
+	require.NoError(t, rcv.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, rcv.Shutdown(t.Context()))
+	}()
+
+	assert.Equal(t, 1, logs.FilterMessageSnippet("service checks will be dropped").Len())
+}
+
 func TestStatsdReceiver_ShutdownBeforeStart(t *testing.T) {
 	ctx := t.Context()
 	cfg := createDefaultConfig().(*Config)